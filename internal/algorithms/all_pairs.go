@@ -0,0 +1,253 @@
+package algorithms
+
+import "math"
+
+// AllPairsResult holds the distance and next-hop matrices of an all-pairs shortest path run
+type AllPairsResult struct {
+	Dist          [][]float64 // Dist[i][j] is the shortest distance from i to j (+Inf if unreachable)
+	Next          [][]int     // Next[i][j] is the first hop on the shortest path from i to j (-1 if none)
+	NegativeCycle bool        // Set when a negative cycle makes the distances meaningless
+}
+
+// newAllPairsResult creates an n x n result with every pair unreachable
+func newAllPairsResult(numNodes int) AllPairsResult {
+	r := AllPairsResult{
+		Dist: make([][]float64, numNodes),
+		Next: make([][]int, numNodes),
+	}
+	for i := 0; i < numNodes; i++ {
+		r.Dist[i] = make([]float64, numNodes)
+		r.Next[i] = make([]int, numNodes)
+		for j := 0; j < numNodes; j++ {
+			r.Dist[i][j] = math.Inf(1)
+			r.Next[i][j] = -1
+		}
+	}
+	return r
+}
+
+// ReconstructPath follows the next-hop matrix from one node to another
+// Returns nil if there is no path
+func ReconstructPath(next [][]int, from, to int) []int {
+	if from < 0 || from >= len(next) || to < 0 || to >= len(next) {
+		return nil
+	}
+	if from == to {
+		return []int{from}
+	}
+	if next[from][to] == -1 {
+		return nil
+	}
+
+	path := []int{from}
+	for current := from; current != to; {
+		current = next[current][to]
+		if current == -1 || len(path) > len(next) {
+			return nil // Broken chain or negative cycle
+		}
+		path = append(path, current)
+	}
+	return path
+}
+
+// FloydWarshallStepper runs Floyd-Warshall one intermediate node (k) at a time
+type FloydWarshallStepper struct {
+	AllPairsResult
+	K       int      // Next intermediate node to process, equals the node count when done
+	Updated [][]bool // Cells improved by the most recent step
+}
+
+// NewFloydWarshallStepper initializes the matrices from the direct edges
+func NewFloydWarshallStepper(neighbors map[int][]Edge, numNodes int) *FloydWarshallStepper {
+	fw := &FloydWarshallStepper{AllPairsResult: newAllPairsResult(numNodes)}
+	fw.Updated = make([][]bool, numNodes)
+	for i := 0; i < numNodes; i++ {
+		fw.Updated[i] = make([]bool, numNodes)
+		fw.Dist[i][i] = 0
+		fw.Next[i][i] = i
+	}
+
+	// Keep the cheapest of any parallel edges
+	for from := 0; from < numNodes; from++ {
+		for _, edge := range neighbors[from] {
+			if edge.To < 0 || edge.To >= numNodes || edge.To == from {
+				continue
+			}
+			if edge.Weight < fw.Dist[from][edge.To] {
+				fw.Dist[from][edge.To] = edge.Weight
+				fw.Next[from][edge.To] = edge.To
+			}
+		}
+	}
+
+	return fw
+}
+
+// Done reports whether every intermediate node has been processed
+func (fw *FloydWarshallStepper) Done() bool {
+	return fw.K >= len(fw.Dist)
+}
+
+// Step relaxes every pair through intermediate node K
+// Returns true once the algorithm has finished
+func (fw *FloydWarshallStepper) Step() bool {
+	if fw.Done() {
+		return true
+	}
+
+	n := len(fw.Dist)
+	k := fw.K
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			fw.Updated[i][j] = false
+			if math.IsInf(fw.Dist[i][k], 1) || math.IsInf(fw.Dist[k][j], 1) {
+				continue
+			}
+			if d := fw.Dist[i][k] + fw.Dist[k][j]; d < fw.Dist[i][j] {
+				fw.Dist[i][j] = d
+				fw.Next[i][j] = fw.Next[i][k]
+				fw.Updated[i][j] = true
+			}
+		}
+	}
+
+	fw.K++
+	if fw.Done() {
+		for i := 0; i < n; i++ {
+			if fw.Dist[i][i] < 0 {
+				fw.NegativeCycle = true
+				break
+			}
+		}
+	}
+	return fw.Done()
+}
+
+// FloydWarshall - computes shortest paths between all pairs of nodes
+func FloydWarshall(neighbors map[int][]Edge, numNodes int) AllPairsResult {
+	fw := NewFloydWarshallStepper(neighbors, numNodes)
+	for !fw.Step() {
+	}
+	return fw.AllPairsResult
+}
+
+// JohnsonStepper runs Johnson's algorithm: one Bellman-Ford pass, then one Dijkstra per source
+type JohnsonStepper struct {
+	AllPairsResult
+	Potential []float64 // Bellman-Ford potentials h(v), nil until the first step
+	Source    int       // Next Dijkstra source, -1 before the reweighting step
+
+	neighbors  map[int][]Edge
+	reweighted map[int][]Edge
+	numNodes   int
+}
+
+// NewJohnsonStepper prepares Johnson's algorithm on the given graph
+func NewJohnsonStepper(neighbors map[int][]Edge, numNodes int) *JohnsonStepper {
+	return &JohnsonStepper{
+		AllPairsResult: newAllPairsResult(numNodes),
+		Source:         -1,
+		neighbors:      neighbors,
+		numNodes:       numNodes,
+	}
+}
+
+// Done reports whether all sources have been processed or a negative cycle was found
+func (j *JohnsonStepper) Done() bool {
+	return j.NegativeCycle || j.Source >= j.numNodes
+}
+
+// Step runs the reweighting pass on the first call and a single-source Dijkstra afterwards
+// Returns true once the algorithm has finished
+func (j *JohnsonStepper) Step() bool {
+	if j.Done() {
+		return true
+	}
+
+	if j.Source == -1 {
+		potential, ok := johnsonPotentials(j.neighbors, j.numNodes)
+		j.Potential = potential
+		if !ok {
+			j.NegativeCycle = true
+			return true
+		}
+
+		// Reweight every edge so that all weights become non-negative
+		j.reweighted = make(map[int][]Edge, j.numNodes)
+		for from := 0; from < j.numNodes; from++ {
+			for _, edge := range j.neighbors[from] {
+				if edge.To < 0 || edge.To >= j.numNodes {
+					continue
+				}
+				j.reweighted[from] = append(j.reweighted[from], Edge{
+					From:   from,
+					To:     edge.To,
+					Weight: edge.Weight + potential[from] - potential[edge.To],
+				})
+			}
+		}
+		j.Source = 0
+		return j.Done()
+	}
+
+	s := j.Source
+	dist, prev := Dijkstra(j.reweighted, s, j.numNodes)
+	for v := 0; v < j.numNodes; v++ {
+		if math.IsInf(dist[v], 1) {
+			continue
+		}
+		j.Dist[s][v] = dist[v] - j.Potential[s] + j.Potential[v]
+
+		// Walk the predecessor chain back to find the first hop out of s
+		hop := v
+		for hop != s && prev[hop] != s && prev[hop] != -1 {
+			hop = prev[hop]
+		}
+		j.Next[s][v] = hop
+	}
+
+	j.Source++
+	return j.Done()
+}
+
+// Johnson - computes shortest paths between all pairs of nodes, suited to sparse graphs
+func Johnson(neighbors map[int][]Edge, numNodes int) AllPairsResult {
+	j := NewJohnsonStepper(neighbors, numNodes)
+	for !j.Step() {
+	}
+	return j.AllPairsResult
+}
+
+// johnsonPotentials runs Bellman-Ford from a virtual source joined to every node by a zero-weight edge
+// Returns false if the graph contains a negative cycle
+func johnsonPotentials(neighbors map[int][]Edge, numNodes int) ([]float64, bool) {
+	h := make([]float64, numNodes) // The virtual source reaches every node at cost 0
+
+	for round := 0; round < numNodes; round++ {
+		changed := false
+		for from := 0; from < numNodes; from++ {
+			for _, edge := range neighbors[from] {
+				if edge.To < 0 || edge.To >= numNodes {
+					continue
+				}
+				if h[from]+edge.Weight < h[edge.To] {
+					h[edge.To] = h[from] + edge.Weight
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return h, true
+		}
+	}
+
+	// Still relaxing after |V| rounds (|V|+1 nodes counting the virtual source)
+	for from := 0; from < numNodes; from++ {
+		for _, edge := range neighbors[from] {
+			if edge.To >= 0 && edge.To < numNodes && h[from]+edge.Weight < h[edge.To] {
+				return h, false
+			}
+		}
+	}
+	return h, true
+}
@@ -1,5 +1,7 @@
 package algorithms
 
+import "fmt"
+
 // AVLNode represents a node in an AVL tree
 type AVLNode struct {
 	Value    int
@@ -7,14 +9,167 @@ type AVLNode struct {
 	Right    *AVLNode
 	Height   int
 	Parent   *AVLNode
-	Position struct {
-		X, Y int
-	}
+	Position TreePosition
 }
 
 // AVLTree represents an AVL tree
 type AVLTree struct {
-	Root *AVLNode
+	Root   *AVLNode
+	Events []AVLEvent // Steps of the most recent Insert, Delete or Search
+}
+
+// AVLEventKind classifies one step of an AVL tree operation
+type AVLEventKind int
+
+const (
+	AVLCompare  AVLEventKind = iota // The value was compared with Node on the way down
+	AVLFound                        // Node holds the value
+	AVLNotFound                     // The search left the tree without finding the value
+	AVLCreate                       // A new leaf was attached for the value
+	AVLRemove                       // Node was removed, its only child (if any) moving up
+	AVLReplace                      // The deleted node had two children; its in-order successor Node took its place
+	AVLBalance                      // Node's height and balance factor were recomputed
+	AVLRotate                       // A single rotation at Node, part of an LL, LR, RR or RL rebalancing
+)
+
+// AVLEvent is one step of an AVL tree operation, with a copy of the tree right after it
+type AVLEvent struct {
+	Kind      AVLEventKind
+	Value     int      // Value being inserted, deleted or searched for
+	Node      int      // Value of the node the step concerns
+	Height    int      // Node's new height, for AVLBalance
+	Balance   int      // Node's new balance factor, for AVLBalance
+	Case      string   // "LL", "LR", "RR" or "RL", for AVLRotate
+	Direction string   // "left" or "right", for AVLRotate
+	Tree      *AVLNode // Copy of the whole tree after this step
+}
+
+// String describes the step in words
+func (e AVLEvent) String() string {
+	switch e.Kind {
+	case AVLCompare:
+		if e.Value < e.Node {
+			return fmt.Sprintf("%d < %d: go left", e.Value, e.Node)
+		}
+		return fmt.Sprintf("%d > %d: go right", e.Value, e.Node)
+	case AVLFound:
+		return fmt.Sprintf("Found %d", e.Node)
+	case AVLNotFound:
+		return fmt.Sprintf("%d is not in the tree", e.Value)
+	case AVLCreate:
+		return fmt.Sprintf("Attach %d as a new leaf", e.Node)
+	case AVLRemove:
+		return fmt.Sprintf("Remove %d; its child, if any, moves up", e.Node)
+	case AVLReplace:
+		return fmt.Sprintf("%d has two children: its in-order successor %d takes its place", e.Value, e.Node)
+	case AVLBalance:
+		status := "balanced"
+		if e.Balance > 1 || e.Balance < -1 {
+			status = "unbalanced"
+		}
+		return fmt.Sprintf("%d: height %d, balance factor %+d (%s)", e.Node, e.Height, e.Balance, status)
+	case AVLRotate:
+		return fmt.Sprintf("%s case: rotate %s at %d", e.Case, e.Direction, e.Node)
+	}
+	return "Unknown step"
+}
+
+// record appends an event with a copy of the tree as it is now
+func (t *AVLTree) record(event AVLEvent) {
+	event.Tree = t.Root.Copy()
+	t.Events = append(t.Events, event)
+}
+
+// Copy returns a deep copy of the subtree rooted at n, or nil for a nil node
+func (n *AVLNode) Copy() *AVLNode {
+	if n == nil {
+		return nil
+	}
+	c := &AVLNode{Value: n.Value, Height: n.Height, Position: n.Position}
+	if c.Left = n.Left.Copy(); c.Left != nil {
+		c.Left.Parent = c
+	}
+	if c.Right = n.Right.Copy(); c.Right != nil {
+		c.Right.Parent = c
+	}
+	return c
+}
+
+// Balance returns the height of the left subtree minus the height of the right subtree
+func (n *AVLNode) Balance() int {
+	return getBalance(n)
+}
+
+// Keys returns the node's value
+func (n *AVLNode) Keys() []int {
+	return []int{n.Value}
+}
+
+// Children returns the left and right child, nil where one is missing
+func (n *AVLNode) Children() []TreeNode {
+	children := make([]TreeNode, 2)
+	if n.Left != nil {
+		children[0] = n.Left
+	}
+	if n.Right != nil {
+		children[1] = n.Right
+	}
+	return children
+}
+
+// Pos returns where the node is drawn
+func (n *AVLNode) Pos() *TreePosition {
+	return &n.Position
+}
+
+// Annotation shows the node's height and balance factor
+func (n *AVLNode) Annotation() string {
+	return fmt.Sprintf("h:%d bf:%+d", n.Height, n.Balance())
+}
+
+// TreeRoot returns the root, or nil for an empty tree
+func (t *AVLTree) TreeRoot() TreeNode {
+	if t.Root == nil {
+		return nil
+	}
+	return t.Root
+}
+
+// Contains reports whether the tree holds value
+func (t *AVLTree) Contains(value int) bool {
+	return t.Search(value) != nil
+}
+
+// Steps converts the events of the most recent operation into tree steps
+func (t *AVLTree) Steps() []TreeStep {
+	steps := make([]TreeStep, 0, len(t.Events))
+	for _, event := range t.Events {
+		step := TreeStep{Key: event.Node, Text: event.String()}
+		switch event.Kind {
+		case AVLCompare:
+			step.Kind = StepVisit
+		case AVLFound:
+			step.Kind = StepFound
+		case AVLNotFound:
+			step.Kind = StepMissing
+		case AVLCreate, AVLReplace:
+			step.Kind = StepCreate
+		case AVLRemove:
+			step.Kind = StepRemove
+		case AVLBalance:
+			step.Kind = StepUpdate
+			if event.Balance > 1 || event.Balance < -1 {
+				step.Kind = StepViolation
+			}
+		case AVLRotate:
+			step.Kind = StepRestructure
+		}
+		if event.Tree != nil {
+			step.Tree = event.Tree
+		}
+		steps = append(steps, step)
+	}
+	return steps
 }
 
 // NewAVLTree creates a new empty AVL tree
@@ -92,135 +247,149 @@ func leftRotate(x *AVLNode) *AVLNode {
 	return y
 }
 
-// Insert adds a new value to the AVL tree
-func (t *AVLTree) Insert(value int) {
-	t.Root = t.insertNode(t.Root, value)
-}
-
-// insertNode recursively inserts a value into the AVL tree
-func (t *AVLTree) insertNode(node *AVLNode, value int) *AVLNode {
-	// Standard BST insert
-	if node == nil {
-		return &AVLNode{
-			Value:  value,
-			Height: 1,
-		}
-	}
-
-	if value < node.Value {
-		node.Left = t.insertNode(node.Left, value)
-		node.Left.Parent = node
-	} else if value > node.Value {
-		node.Right = t.insertNode(node.Right, value)
-		node.Right.Parent = node
+// rotate performs a left or right rotation at node and links the new subtree root into the tree
+func (t *AVLTree) rotate(node *AVLNode, left bool, rotationCase string) {
+	parent := node.Parent
+	var top *AVLNode
+	direction := "right"
+	if left {
+		top = leftRotate(node)
+		direction = "left"
 	} else {
-		return node // Duplicate values not allowed
+		top = rightRotate(node)
 	}
 
-	// Update height
-	node.Height = 1 + max(getHeight(node.Left), getHeight(node.Right))
-
-	// Get balance factor
-	balance := getBalance(node)
+	switch {
+	case parent == nil:
+		t.Root = top
+	case parent.Left == node:
+		parent.Left = top
+	default:
+		parent.Right = top
+	}
+	t.record(AVLEvent{Kind: AVLRotate, Node: node.Value, Case: rotationCase, Direction: direction})
+}
 
-	// Left Left Case
-	if balance > 1 && value < node.Left.Value {
-		return rightRotate(node)
+// rebalance walks from node up to the root, updating heights and rotating every subtree that
+// leans by more than one level
+// Insertion picks the rotation case by the inserted value, deletion by the child's balance
+func (t *AVLTree) rebalance(node *AVLNode, value int, deleting bool) {
+	for node != nil {
+		parent := node.Parent // Captured first: a rotation moves node below its replacement
+
+		// Update height
+		node.Height = 1 + max(getHeight(node.Left), getHeight(node.Right))
+
+		// Get balance factor
+		balance := getBalance(node)
+		t.record(AVLEvent{Kind: AVLBalance, Value: value, Node: node.Value, Height: node.Height, Balance: balance})
+
+		switch {
+		case balance > 1 && (deleting && getBalance(node.Left) >= 0 || !deleting && value < node.Left.Value):
+			// Left Left Case
+			t.rotate(node, false, "LL")
+		case balance > 1:
+			// Left Right Case
+			t.rotate(node.Left, true, "LR")
+			t.rotate(node, false, "LR")
+		case balance < -1 && (deleting && getBalance(node.Right) <= 0 || !deleting && value > node.Right.Value):
+			// Right Right Case
+			t.rotate(node, true, "RR")
+		case balance < -1:
+			// Right Left Case
+			t.rotate(node.Right, false, "RL")
+			t.rotate(node, true, "RL")
+		}
+		node = parent
 	}
+}
 
-	// Right Right Case
-	if balance < -1 && value > node.Right.Value {
-		return leftRotate(node)
+// find walks down from the root towards value, recording each comparison
+// Returns the node holding value, or nil and the last node visited
+func (t *AVLTree) find(value int) (found, last *AVLNode) {
+	node := t.Root
+	for node != nil {
+		if value == node.Value {
+			t.record(AVLEvent{Kind: AVLFound, Value: value, Node: node.Value})
+			return node, node
+		}
+		t.record(AVLEvent{Kind: AVLCompare, Value: value, Node: node.Value})
+		last = node
+		if value < node.Value {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
 	}
+	t.record(AVLEvent{Kind: AVLNotFound, Value: value, Node: value})
+	return nil, last
+}
 
-	// Left Right Case
-	if balance > 1 && value > node.Left.Value {
-		node.Left = leftRotate(node.Left)
-		return rightRotate(node)
+// Insert adds a new value to the AVL tree
+// Events records the comparisons on the way down, the new leaf, and the balance updates and
+// rotations on the way back up; duplicate values are not inserted
+func (t *AVLTree) Insert(value int) {
+	t.Events = nil
+	found, parent := t.find(value)
+	if found != nil {
+		return // Duplicate values not allowed
 	}
 
-	// Right Left Case
-	if balance < -1 && value < node.Right.Value {
-		node.Right = rightRotate(node.Right)
-		return leftRotate(node)
+	// The search above recorded that value is missing; the new leaf replaces that event
+	t.Events = t.Events[:len(t.Events)-1]
+	node := &AVLNode{Value: value, Height: 1, Parent: parent}
+	switch {
+	case parent == nil:
+		t.Root = node
+	case value < parent.Value:
+		parent.Left = node
+	default:
+		parent.Right = node
 	}
+	t.record(AVLEvent{Kind: AVLCreate, Value: value, Node: value})
 
-	return node
+	t.rebalance(parent, value, false)
 }
 
 // Delete removes a value from the AVL tree
+// A node with two children takes the value of its in-order successor, whose node is removed
+// instead; Events records the search, the removal, and the rebalancing from its parent up
 func (t *AVLTree) Delete(value int) {
-	t.Root = t.deleteNode(t.Root, value)
-}
-
-// deleteNode recursively deletes a value from the AVL tree
-func (t *AVLTree) deleteNode(node *AVLNode, value int) *AVLNode {
-	// Standard BST delete
+	t.Events = nil
+	node, _ := t.find(value)
 	if node == nil {
-		return nil
+		return
 	}
 
-	if value < node.Value {
-		node.Left = t.deleteNode(node.Left, value)
-	} else if value > node.Value {
-		node.Right = t.deleteNode(node.Right, value)
-	} else {
-		// Node to delete found
-
-		// Node with only one child or no child
-		if node.Left == nil {
-			temp := node.Right
-			if temp != nil {
-				temp.Parent = node.Parent
-			}
-			return temp
-		} else if node.Right == nil {
-			temp := node.Left
-			if temp != nil {
-				temp.Parent = node.Parent
-			}
-			return temp
-		}
-
+	kind := AVLRemove
+	if node.Left != nil && node.Right != nil {
 		// Node with two children: Get the inorder successor (smallest in right subtree)
-		temp := t.getMinValueNode(node.Right)
-		node.Value = temp.Value
-		node.Right = t.deleteNode(node.Right, temp.Value)
+		successor := t.getMinValueNode(node.Right)
+		node.Value = successor.Value
+		node = successor
+		kind = AVLReplace
 	}
 
-	if node == nil {
-		return nil
+	// Node with only one child or no child
+	child := node.Left
+	if child == nil {
+		child = node.Right
 	}
-
-	// Update height
-	node.Height = 1 + max(getHeight(node.Left), getHeight(node.Right))
-
-	// Get balance factor
-	balance := getBalance(node)
-
-	// Left Left Case
-	if balance > 1 && getBalance(node.Left) >= 0 {
-		return rightRotate(node)
-	}
-
-	// Left Right Case
-	if balance > 1 && getBalance(node.Left) < 0 {
-		node.Left = leftRotate(node.Left)
-		return rightRotate(node)
+	if child != nil {
+		child.Parent = node.Parent
 	}
-
-	// Right Right Case
-	if balance < -1 && getBalance(node.Right) <= 0 {
-		return leftRotate(node)
-	}
-
-	// Right Left Case
-	if balance < -1 && getBalance(node.Right) > 0 {
-		node.Right = rightRotate(node.Right)
-		return leftRotate(node)
+	parent := node.Parent
+	switch {
+	case parent == nil:
+		t.Root = child
+	case parent.Left == node:
+		parent.Left = child
+	default:
+		parent.Right = child
 	}
+	t.record(AVLEvent{Kind: kind, Value: value, Node: node.Value})
 
-	return node
+	t.rebalance(parent, value, true)
 }
 
 // getMinValueNode returns the node with minimum value in the tree
@@ -233,47 +402,14 @@ func (t *AVLTree) getMinValueNode(node *AVLNode) *AVLNode {
 }
 
 // Search looks for a value in the AVL tree
+// Events records the comparisons on the way down
 func (t *AVLTree) Search(value int) *AVLNode {
-	return t.searchNode(t.Root, value)
-}
-
-// searchNode recursively searches for a value in the AVL tree
-func (t *AVLTree) searchNode(node *AVLNode, value int) *AVLNode {
-	if node == nil || node.Value == value {
-		return node
-	}
-
-	if value < node.Value {
-		return t.searchNode(node.Left, value)
-	}
-	return t.searchNode(node.Right, value)
-}
-
-// UpdatePositions updates the visual positions of all nodes in the tree
-func (t *AVLTree) UpdatePositions(startX, startY, levelHeight int) {
-	t.updateNodePositions(t.Root, startX, startY, levelHeight, 0)
+	t.Events = nil
+	node, _ := t.find(value)
+	return node
 }
 
-// updateNodePositions recursively updates node positions for visualization
-func (t *AVLTree) updateNodePositions(node *AVLNode, x, y, levelHeight, level int) {
-	if node == nil {
-		return
-	}
-
-	// Calculate horizontal spacing based on level
-	spacing := 1 << (level + 2) // 2^(level+2)
-
-	// Update current node position
-	node.Position.X = x
-	node.Position.Y = y
-
-	// Update left subtree positions
-	if node.Left != nil {
-		t.updateNodePositions(node.Left, x-spacing, y+levelHeight, levelHeight, level+1)
-	}
-
-	// Update right subtree positions
-	if node.Right != nil {
-		t.updateNodePositions(node.Right, x+spacing, y+levelHeight, levelHeight, level+1)
-	}
+// UpdatePositions lays out the tree centered on centerX, see UpdateTreePositions
+func (t *AVLTree) UpdatePositions(centerX, startY, levelHeight int) {
+	UpdateTreePositions(t.TreeRoot(), centerX, startY, levelHeight)
 }
@@ -0,0 +1,284 @@
+package algorithms
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BTreeNode represents a node in a B-tree
+type BTreeNode struct {
+	Values   []int        // Sorted keys
+	Subtrees []*BTreeNode // One more than the keys for an inner node, none for a leaf
+	Position TreePosition
+}
+
+// BTree is a multiway search tree whose nodes hold up to Order-1 keys and every node but the
+// root at least half as many, with all leaves at the same depth
+// It grows at the root: a node with too many keys splits and pushes its median up
+type BTree struct {
+	Root  *BTreeNode
+	Order int // Most children a node may have, at least 3
+	steps []TreeStep
+}
+
+// NewBTree creates a new empty B-tree of the given order
+func NewBTree(order int) *BTree {
+	if order < 3 {
+		order = 3
+	}
+	return &BTree{Order: order}
+}
+
+// Keys returns the node's keys
+func (n *BTreeNode) Keys() []int {
+	return n.Values
+}
+
+// Children returns the node's subtrees, none for a leaf
+func (n *BTreeNode) Children() []TreeNode {
+	children := make([]TreeNode, len(n.Subtrees))
+	for i, subtree := range n.Subtrees {
+		children[i] = subtree
+	}
+	return children
+}
+
+// Pos returns where the node is drawn
+func (n *BTreeNode) Pos() *TreePosition {
+	return &n.Position
+}
+
+// Annotation is empty: a B-tree node shows all it has in its keys
+func (n *BTreeNode) Annotation() string {
+	return ""
+}
+
+// Copy returns a deep copy of the subtree rooted at n, or nil for a nil node
+func (n *BTreeNode) Copy() *BTreeNode {
+	if n == nil {
+		return nil
+	}
+	c := &BTreeNode{Values: append([]int(nil), n.Values...), Position: n.Position}
+	for _, subtree := range n.Subtrees {
+		c.Subtrees = append(c.Subtrees, subtree.Copy())
+	}
+	return c
+}
+
+// leaf reports whether the node has no subtrees
+func (n *BTreeNode) leaf() bool {
+	return len(n.Subtrees) == 0
+}
+
+// firstKey returns a key identifying the node in a step, or fallback for a node without keys
+func (n *BTreeNode) firstKey(fallback int) int {
+	if len(n.Values) == 0 {
+		return fallback
+	}
+	return n.Values[0]
+}
+
+// minKeys returns the fewest keys a node other than the root may hold
+func (t *BTree) minKeys() int {
+	return (t.Order+1)/2 - 1
+}
+
+// TreeRoot returns the root, or nil for an empty tree
+func (t *BTree) TreeRoot() TreeNode {
+	if t.Root == nil {
+		return nil
+	}
+	return t.Root
+}
+
+// Steps returns the steps of the most recent operation
+func (t *BTree) Steps() []TreeStep {
+	return t.steps
+}
+
+// record appends a step with a copy of the tree as it is now
+func (t *BTree) record(kind StepKind, key int, format string, args ...interface{}) {
+	step := TreeStep{Kind: kind, Key: key, Text: fmt.Sprintf(format, args...)}
+	if t.Root != nil {
+		step.Tree = t.Root.Copy()
+	}
+	t.steps = append(t.steps, step)
+}
+
+// descend walks down from the root towards value, recording each node visited
+// Returns the nodes on the way and, for each, the index of value among its keys or of the
+// subtree taken; the last node holds value or is the leaf where it belongs
+func (t *BTree) descend(value int) (path []*BTreeNode, slots []int, found bool) {
+	for node := t.Root; node != nil; {
+		i := sort.SearchInts(node.Values, value)
+		path = append(path, node)
+		slots = append(slots, i)
+		if i < len(node.Values) && node.Values[i] == value {
+			t.record(StepFound, value, "Found %d in %v", value, node.Values)
+			return path, slots, true
+		}
+
+		var where string
+		switch {
+		case i == 0:
+			where = fmt.Sprintf("%d < %d", value, node.Values[0])
+		case i == len(node.Values):
+			where = fmt.Sprintf("%d > %d", value, node.Values[i-1])
+		default:
+			where = fmt.Sprintf("%d < %d < %d", node.Values[i-1], value, node.Values[i])
+		}
+		if node.leaf() {
+			t.record(StepVisit, node.Values[0], "%s: %d belongs at position %d of leaf %v", where, value, i+1, node.Values)
+			break
+		}
+		t.record(StepVisit, node.Values[0], "%s: go to child %d of %v", where, i+1, node.Values)
+		node = node.Subtrees[i]
+	}
+	return path, slots, false
+}
+
+// Insert adds a value to its leaf, then splits every node on the way back up that holds more
+// than Order-1 keys; duplicate values are not inserted
+func (t *BTree) Insert(value int) {
+	t.steps = nil
+	if t.Root == nil {
+		t.Root = &BTreeNode{Values: []int{value}}
+		t.record(StepCreate, value, "Create the root [%d]", value)
+		return
+	}
+
+	path, slots, found := t.descend(value)
+	if found {
+		return
+	}
+	leaf, i := path[len(path)-1], slots[len(slots)-1]
+	leaf.Values = append(leaf.Values[:i], append([]int{value}, leaf.Values[i:]...)...)
+	t.record(StepCreate, value, "Insert %d into leaf %v", value, leaf.Values)
+
+	for level := len(path) - 1; level >= 0 && len(path[level].Values) >= t.Order; level-- {
+		node := path[level]
+		full := fmt.Sprint(node.Values)
+		t.record(StepViolation, node.Values[0], "%s has %d keys, more than %d", full, len(node.Values), t.Order-1)
+
+		// The keys right of the median move to a new node, the median to the parent
+		mid := len(node.Values) / 2
+		median := node.Values[mid]
+		right := &BTreeNode{Values: append([]int(nil), node.Values[mid+1:]...)}
+		node.Values = append([]int(nil), node.Values[:mid]...)
+		if !node.leaf() {
+			right.Subtrees = append([]*BTreeNode(nil), node.Subtrees[mid+1:]...)
+			node.Subtrees = append([]*BTreeNode(nil), node.Subtrees[:mid+1]...)
+		}
+
+		if level == 0 {
+			t.Root = &BTreeNode{Values: []int{median}, Subtrees: []*BTreeNode{node, right}}
+			t.record(StepRestructure, median, "Split %s: the median %d becomes the new root", full, median)
+			continue
+		}
+		parent, j := path[level-1], slots[level-1]
+		parent.Values = append(parent.Values[:j], append([]int{median}, parent.Values[j:]...)...)
+		parent.Subtrees = append(parent.Subtrees[:j+1], append([]*BTreeNode{right}, parent.Subtrees[j+1:]...)...)
+		t.record(StepRestructure, median, "Split %s: the median %d moves up into %v", full, median, parent.Values)
+	}
+}
+
+// Delete removes a value from the tree
+// A key in an inner node is replaced by its in-order successor from a leaf; a node left with
+// too few keys then borrows one from a sibling through the parent, or merges with a sibling
+// and pulls the key between them down, which may leave the parent short in turn
+func (t *BTree) Delete(value int) {
+	t.steps = nil
+	path, slots, found := t.descend(value)
+	if !found {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+		return
+	}
+
+	node, i := path[len(path)-1], slots[len(slots)-1]
+	if node.leaf() {
+		node.Values = append(node.Values[:i], node.Values[i+1:]...)
+		if node == t.Root && len(node.Values) == 0 {
+			t.Root = nil
+		}
+		t.record(StepRemove, node.firstKey(value), "Remove %d from leaf %v", value, node.Values)
+	} else {
+		// The successor is the first key of the leftmost leaf right of value
+		slots[len(slots)-1] = i + 1
+		leaf := node.Subtrees[i+1]
+		for {
+			path = append(path, leaf)
+			slots = append(slots, 0)
+			if leaf.leaf() {
+				break
+			}
+			leaf = leaf.Subtrees[0]
+		}
+		node.Values[i] = leaf.Values[0]
+		leaf.Values = leaf.Values[1:]
+		t.record(StepCreate, node.Values[i], "%d is in an inner node: its in-order successor %d takes its place",
+			value, node.Values[i])
+	}
+
+	for level := len(path) - 1; level > 0 && len(path[level].Values) < t.minKeys(); level-- {
+		node := path[level]
+		parent, j := path[level-1], slots[level-1]
+		t.record(StepViolation, node.firstKey(value), "A node is left with %d keys, fewer than %d",
+			len(node.Values), t.minKeys())
+
+		switch {
+		case j > 0 && len(parent.Subtrees[j-1].Values) > t.minKeys():
+			// Rotate a key through the parent from the left sibling
+			left := parent.Subtrees[j-1]
+			down, up := parent.Values[j-1], left.Values[len(left.Values)-1]
+			node.Values = append([]int{down}, node.Values...)
+			parent.Values[j-1] = up
+			left.Values = left.Values[:len(left.Values)-1]
+			if !left.leaf() {
+				node.Subtrees = append([]*BTreeNode{left.Subtrees[len(left.Subtrees)-1]}, node.Subtrees...)
+				left.Subtrees = left.Subtrees[:len(left.Subtrees)-1]
+			}
+			t.record(StepRestructure, up, "Borrow from the left sibling: %d moves up and %d moves down", up, down)
+		case j < len(parent.Subtrees)-1 && len(parent.Subtrees[j+1].Values) > t.minKeys():
+			// Rotate a key through the parent from the right sibling
+			right := parent.Subtrees[j+1]
+			down, up := parent.Values[j], right.Values[0]
+			node.Values = append(node.Values, down)
+			parent.Values[j] = up
+			right.Values = right.Values[1:]
+			if !right.leaf() {
+				node.Subtrees = append(node.Subtrees, right.Subtrees[0])
+				right.Subtrees = right.Subtrees[1:]
+			}
+			t.record(StepRestructure, up, "Borrow from the right sibling: %d moves up and %d moves down", up, down)
+		default:
+			// Merge with a sibling around the key that separates them
+			k := j
+			if j > 0 {
+				k = j - 1
+			}
+			left, right := parent.Subtrees[k], parent.Subtrees[k+1]
+			separator := parent.Values[k]
+			left.Values = append(append(left.Values, separator), right.Values...)
+			left.Subtrees = append(left.Subtrees, right.Subtrees...)
+			parent.Values = append(parent.Values[:k], parent.Values[k+1:]...)
+			parent.Subtrees = append(parent.Subtrees[:k+1], parent.Subtrees[k+2:]...)
+			if parent == t.Root && len(parent.Values) == 0 {
+				t.Root = left
+				t.record(StepRestructure, separator, "Merge with a sibling, pulling %d down from the root: %v becomes the root",
+					separator, left.Values)
+				continue
+			}
+			t.record(StepRestructure, separator, "Merge with a sibling, pulling %d down: %v", separator, left.Values)
+		}
+	}
+}
+
+// Contains reports whether the tree holds value, recording the search
+func (t *BTree) Contains(value int) bool {
+	t.steps = nil
+	_, _, found := t.descend(value)
+	if !found {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+	}
+	return found
+}
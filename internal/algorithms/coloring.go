@@ -0,0 +1,269 @@
+package algorithms
+
+import "sort"
+
+// ColoringOrder selects how greedy coloring picks the next node
+type ColoringOrder int
+
+const (
+	ColoringIndex       ColoringOrder = iota // Nodes in index order
+	ColoringWelshPowell                      // Nodes by decreasing degree
+	ColoringDSatur                           // Most distinct neighbor colors first, ties by degree
+)
+
+// GreedyColoringStepper gives one node per step the smallest color none of its neighbors has
+type GreedyColoringStepper struct {
+	Colors     []int // Color of every node, -1 while uncolored
+	Order      []int // Nodes in the order they were colored
+	Saturation []int // Number of distinct colors among each node's neighbors
+	Degree     []int
+	Current    int // Node colored by the last step, -1 before the first
+	NumColors  int
+	Strategy   ColoringOrder
+	neighbors  map[int][]int
+	sequence   []int // Fixed coloring order for the index and Welsh-Powell strategies
+	done       bool
+}
+
+// NewGreedyColoringStepper prepares a greedy coloring of an undirected graph (every edge stored both ways)
+func NewGreedyColoringStepper(neighbors map[int][]int, numNodes int, strategy ColoringOrder) *GreedyColoringStepper {
+	c := &GreedyColoringStepper{
+		Colors:     make([]int, numNodes),
+		Order:      make([]int, 0, numNodes),
+		Saturation: make([]int, numNodes),
+		Degree:     make([]int, numNodes),
+		Current:    -1,
+		Strategy:   strategy,
+		neighbors:  neighbors,
+	}
+	for v := range c.Colors {
+		c.Colors[v] = -1
+		c.Degree[v] = len(distinctNeighbors(neighbors, v))
+	}
+	if strategy != ColoringDSatur {
+		c.sequence = make([]int, numNodes)
+		for v := range c.sequence {
+			c.sequence[v] = v
+		}
+		if strategy == ColoringWelshPowell {
+			sort.SliceStable(c.sequence, func(i, j int) bool {
+				return c.Degree[c.sequence[i]] > c.Degree[c.sequence[j]]
+			})
+		}
+	}
+	c.done = numNodes == 0
+	return c
+}
+
+// Done reports whether every node is colored
+func (c *GreedyColoringStepper) Done() bool {
+	return c.done
+}
+
+// Step colors the next node
+// Returns true once every node is colored
+func (c *GreedyColoringStepper) Step() bool {
+	if c.done {
+		return true
+	}
+
+	v := c.next()
+	color := smallestFreeColor(c.neighbors, c.Colors, v)
+	c.Colors[v] = color
+	c.Order = append(c.Order, v)
+	c.Current = v
+	if color+1 > c.NumColors {
+		c.NumColors = color + 1
+	}
+
+	// The neighbors that had not seen this color yet become more saturated
+	for _, u := range distinctNeighbors(c.neighbors, v) {
+		seen := false
+		for _, w := range distinctNeighbors(c.neighbors, u) {
+			if w != v && c.Colors[w] == color {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			c.Saturation[u]++
+		}
+	}
+
+	c.done = len(c.Order) == len(c.Colors)
+	return c.done
+}
+
+// next returns the node the strategy colors next
+func (c *GreedyColoringStepper) next() int {
+	if c.sequence != nil {
+		return c.sequence[len(c.Order)]
+	}
+	best := -1
+	for v, color := range c.Colors {
+		if color != -1 {
+			continue
+		}
+		if best == -1 || c.Saturation[v] > c.Saturation[best] ||
+			(c.Saturation[v] == c.Saturation[best] && c.Degree[v] > c.Degree[best]) {
+			best = v
+		}
+	}
+	return best
+}
+
+// GreedyColoring - Colors every node with the smallest color its already colored neighbors lack
+// Returns the color of every node and the number of colors used
+func GreedyColoring(neighbors map[int][]int, numNodes int, strategy ColoringOrder) ([]int, int) {
+	c := NewGreedyColoringStepper(neighbors, numNodes, strategy)
+	for !c.Step() {
+	}
+	return c.Colors, c.NumColors
+}
+
+// ColoringAction describes what the last step of the exact coloring search did
+type ColoringAction int
+
+const (
+	ColoringNone      ColoringAction = iota
+	ColoringAssign                   // Gave the current node a color
+	ColoringBacktrack                // Found no free color for the current node and undid it
+	ColoringExhausted                // Proved that K-1 colors are not enough and moved on to K
+	ColoringFound                    // Colored every node with K colors
+)
+
+// ExactColoringStepper finds the chromatic number by backtracking
+// It tries K = 1, 2, ... colors in turn; the first K that colors every node is optimal
+// A step assigns or undoes the color of one node
+type ExactColoringStepper struct {
+	Colors    []int // Color of every node in the current partial coloring, -1 while uncolored
+	Order     []int // Nodes by decreasing degree, the order the search colors them in
+	K         int   // Number of colors currently tried
+	Position  int   // Number of nodes of Order colored so far
+	Current   int   // Node the last step assigned or undid
+	Action    ColoringAction
+	Steps     int
+	neighbors map[int][]int
+	next      []int // Next color to try at each position of Order
+	done      bool
+}
+
+// NewExactColoringStepper prepares an exact coloring of an undirected graph (every edge stored both ways)
+func NewExactColoringStepper(neighbors map[int][]int, numNodes int) *ExactColoringStepper {
+	e := &ExactColoringStepper{
+		Colors:    make([]int, numNodes),
+		Order:     make([]int, numNodes),
+		K:         1,
+		Current:   -1,
+		neighbors: neighbors,
+		next:      make([]int, numNodes),
+	}
+	degree := make([]int, numNodes)
+	for v := range e.Colors {
+		e.Colors[v] = -1
+		e.Order[v] = v
+		degree[v] = len(distinctNeighbors(neighbors, v))
+	}
+	sort.SliceStable(e.Order, func(i, j int) bool {
+		return degree[e.Order[i]] > degree[e.Order[j]]
+	})
+	if numNodes == 0 {
+		e.K = 0
+		e.done = true
+	}
+	return e
+}
+
+// Done reports whether an optimal coloring was found
+func (e *ExactColoringStepper) Done() bool {
+	return e.done
+}
+
+// Step colors the next node with its next feasible color, or backtracks if there is none
+// Returns true once every node is colored
+func (e *ExactColoringStepper) Step() bool {
+	if e.done {
+		return true
+	}
+	e.Steps++
+
+	v := e.Order[e.Position]
+	e.Current = v
+	// Colors are interchangeable, so a node may open at most one new color
+	limit := 0
+	for _, u := range e.Order[:e.Position] {
+		if e.Colors[u]+1 > limit {
+			limit = e.Colors[u] + 1
+		}
+	}
+	if limit >= e.K {
+		limit = e.K - 1
+	}
+
+	for color := e.next[e.Position]; color <= limit; color++ {
+		if !neighborHasColor(e.neighbors, e.Colors, v, color) {
+			e.Colors[v] = color
+			e.next[e.Position] = color + 1
+			e.Position++
+			e.Action = ColoringAssign
+			if e.Position == len(e.Order) {
+				e.Action = ColoringFound
+				e.done = true
+			}
+			return e.done
+		}
+	}
+
+	// No color fits: undo this node and retry the previous one
+	e.Colors[v] = -1
+	e.next[e.Position] = 0
+	e.Action = ColoringBacktrack
+	if e.Position == 0 {
+		e.K++
+		e.Action = ColoringExhausted
+		return false
+	}
+	e.Position--
+	return false
+}
+
+// ChromaticNumber - Smallest number of colors that gives adjacent nodes different colors,
+// with one such coloring; exponential, meant for small graphs
+func ChromaticNumber(neighbors map[int][]int, numNodes int) (int, []int) {
+	e := NewExactColoringStepper(neighbors, numNodes)
+	for !e.Step() {
+	}
+	return e.K, e.Colors
+}
+
+// smallestFreeColor returns the smallest color none of v's neighbors has
+func smallestFreeColor(neighbors map[int][]int, colors []int, v int) int {
+	color := 0
+	for neighborHasColor(neighbors, colors, v, color) {
+		color++
+	}
+	return color
+}
+
+// neighborHasColor reports whether a neighbor of v other than v itself has the given color
+func neighborHasColor(neighbors map[int][]int, colors []int, v, color int) bool {
+	for _, u := range neighbors[v] {
+		if u != v && colors[u] == color {
+			return true
+		}
+	}
+	return false
+}
+
+// distinctNeighbors returns v's neighbors without repeats or v itself
+func distinctNeighbors(neighbors map[int][]int, v int) []int {
+	seen := map[int]bool{v: true}
+	result := []int{}
+	for _, u := range neighbors[v] {
+		if !seen[u] {
+			seen[u] = true
+			result = append(result, u)
+		}
+	}
+	return result
+}
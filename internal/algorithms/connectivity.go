@@ -0,0 +1,132 @@
+package algorithms
+
+import "sort"
+
+// BiconnectivityResult holds the undirected connectivity structure found by one DFS
+type BiconnectivityResult struct {
+	Bridges            [][2]int   // Edges whose removal disconnects their endpoints
+	ArticulationPoints []int      // Cut vertices, in index order
+	Blocks             [][][2]int // Biconnected components as edge lists; every edge is in exactly one
+	Discovery          []int      // DFS discovery time of each node, starting at 1
+	Low                []int      // Earliest discovery time reachable from the node's subtree by one back edge
+}
+
+// BlockNodes returns the nodes of every block, each list in index order
+// A cut vertex appears in every block it joins
+func (r *BiconnectivityResult) BlockNodes() [][]int {
+	nodes := make([][]int, len(r.Blocks))
+	for i, block := range r.Blocks {
+		seen := map[int]bool{}
+		for _, edge := range block {
+			for _, v := range edge {
+				if !seen[v] {
+					seen[v] = true
+					nodes[i] = append(nodes[i], v)
+				}
+			}
+		}
+		sort.Ints(nodes[i])
+	}
+	return nodes
+}
+
+// Biconnectivity finds the bridges, articulation points and biconnected components of an
+// undirected graph given with every edge stored in both directions
+// Uses Tarjan's lowpoint DFS with a stack of edges; a parallel edge to the parent counts as a back edge
+func Biconnectivity(neighbors map[int][]int, numNodes int) BiconnectivityResult {
+	r := BiconnectivityResult{
+		Discovery: make([]int, numNodes),
+		Low:       make([]int, numNodes),
+	}
+	isCut := make([]bool, numNodes)
+	edgeStack := [][2]int{}
+	time := 0
+
+	var dfs func(u, parent int)
+	dfs = func(u, parent int) {
+		time++
+		r.Discovery[u] = time
+		r.Low[u] = time
+		children := 0
+		skippedParent := false
+
+		for _, v := range neighbors[u] {
+			if v == parent && !skippedParent {
+				skippedParent = true // The tree edge we arrived by, seen from this side
+				continue
+			}
+
+			if r.Discovery[v] == 0 {
+				children++
+				edgeStack = append(edgeStack, [2]int{u, v})
+				dfs(v, u)
+				if r.Low[v] < r.Low[u] {
+					r.Low[u] = r.Low[v]
+				}
+
+				if r.Low[v] > r.Discovery[u] {
+					r.Bridges = append(r.Bridges, [2]int{u, v})
+				}
+				if r.Low[v] >= r.Discovery[u] {
+					// u separates v's subtree: pop its block
+					if parent != -1 || children > 1 {
+						isCut[u] = true
+					}
+					block := [][2]int{}
+					for {
+						edge := edgeStack[len(edgeStack)-1]
+						edgeStack = edgeStack[:len(edgeStack)-1]
+						block = append(block, edge)
+						if edge == [2]int{u, v} {
+							break
+						}
+					}
+					r.Blocks = append(r.Blocks, block)
+				}
+			} else if r.Discovery[v] < r.Discovery[u] {
+				// Back edge to an ancestor; edges to descendants were already pushed from their side
+				edgeStack = append(edgeStack, [2]int{u, v})
+				if r.Discovery[v] < r.Low[u] {
+					r.Low[u] = r.Discovery[v]
+				}
+			}
+		}
+	}
+
+	for i := 0; i < numNodes; i++ {
+		if r.Discovery[i] == 0 {
+			dfs(i, -1)
+		}
+	}
+
+	for v, cut := range isCut {
+		if cut {
+			r.ArticulationPoints = append(r.ArticulationPoints, v)
+		}
+	}
+	return r
+}
+
+// ConnectedComponents - Components of an undirected graph found by BFS, each listed in visiting order
+// Components are ordered by their smallest node
+func ConnectedComponents(neighbors map[int][]int, numNodes int) [][]int {
+	visited := make([]bool, numNodes)
+	components := [][]int{}
+	for start := 0; start < numNodes; start++ {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		component := []int{start}
+		for i := 0; i < len(component); i++ {
+			for _, v := range neighbors[component[i]] {
+				if !visited[v] {
+					visited[v] = true
+					component = append(component, v)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
@@ -0,0 +1,163 @@
+package algorithms
+
+import "sort"
+
+// FindUndirectedCycle - DFS that reports the first edge leading back to an ancestor other than
+// the node's parent; every such edge closes a cycle in an undirected graph
+// Returns the cycle with its first node repeated at the end, or nil if the graph is a forest
+func FindUndirectedCycle(neighbors map[int][]int, numNodes int) []int {
+	visited := make([]bool, numNodes)
+	parent := make([]int, numNodes)
+	var cycle []int
+
+	var dfs func(node int) bool
+	dfs = func(node int) bool {
+		visited[node] = true
+		for _, neighbor := range neighbors[node] {
+			switch {
+			case neighbor == node:
+				cycle = []int{node, node}
+				return true
+			case neighbor == parent[node]:
+				continue
+			case !visited[neighbor]:
+				parent[neighbor] = node
+				if dfs(neighbor) {
+					return true
+				}
+			default:
+				// neighbor is an ancestor: walk the tree path back up to it
+				cycle = []int{neighbor}
+				for v := node; v != neighbor; v = parent[v] {
+					cycle = append(cycle, v)
+				}
+				cycle = append(cycle, neighbor)
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < numNodes; i++ {
+		parent[i] = -1
+	}
+	for i := 0; i < numNodes; i++ {
+		if !visited[i] && dfs(i) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// ElementaryCycles - Johnson's algorithm for every elementary cycle
+// For each start s in increasing order it searches the strongly connected component of s among
+// the nodes >= s; a node that cannot reach s is blocked until one of its successors can, which
+// keeps the time per cycle found linear in the size of the graph
+// Undirected graphs report every cycle of three or more nodes once, in one orientation
+// Cycles start at their smallest node, which is repeated at the end; at most limit cycles are
+// returned (0 for no limit), and the second result reports whether more exist
+func ElementaryCycles(neighbors map[int][]int, numNodes int, directed bool, limit int) ([][]int, bool) {
+	// Sorted, duplicate-free adjacency lists, so cycles come out in a stable order and once each
+	adjacency := make([][]int, numNodes)
+	for v := 0; v < numNodes; v++ {
+		seen := map[int]bool{}
+		for _, w := range neighbors[v] {
+			if !seen[w] {
+				seen[w] = true
+				adjacency[v] = append(adjacency[v], w)
+			}
+		}
+		sort.Ints(adjacency[v])
+	}
+
+	cycles := [][]int{}
+	truncated := false
+	blocked := make([]bool, numNodes)
+	blockedBy := make([]map[int]bool, numNodes) // B lists: nodes to unblock along with each node
+	inComponent := make([]bool, numNodes)
+	stack := []int{}
+
+	var unblock func(v int)
+	unblock = func(v int) {
+		blocked[v] = false
+		for w := range blockedBy[v] {
+			delete(blockedBy[v], w)
+			if blocked[w] {
+				unblock(w)
+			}
+		}
+	}
+
+	var circuit func(v, s int) bool
+	circuit = func(v, s int) bool {
+		found := false
+		stack = append(stack, v)
+		blocked[v] = true
+		for _, w := range adjacency[v] {
+			if truncated {
+				break
+			}
+			if !inComponent[w] {
+				continue
+			}
+			if w == s {
+				found = true
+				// An undirected edge walked there and back, or a cycle seen in its reverse orientation
+				if !directed && (len(stack) < 3 || stack[1] > stack[len(stack)-1]) {
+					continue
+				}
+				if limit > 0 && len(cycles) == limit {
+					truncated = true
+					break
+				}
+				cycles = append(cycles, append(append([]int{}, stack...), s))
+			} else if !blocked[w] && circuit(w, s) {
+				found = true
+			}
+		}
+		if found {
+			unblock(v)
+		} else {
+			for _, w := range adjacency[v] {
+				if inComponent[w] {
+					blockedBy[w][v] = true
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		return found
+	}
+
+	for s := 0; s < numNodes && !truncated; s++ {
+		// Strongly connected component of s in the subgraph of nodes >= s
+		sub := make(map[int][]int, numNodes-s)
+		for v := s; v < numNodes; v++ {
+			for _, w := range adjacency[v] {
+				if w >= s {
+					sub[v] = append(sub[v], w)
+				}
+			}
+		}
+		var component []int
+		for _, scc := range Tarjan(sub, numNodes) {
+			for _, v := range scc {
+				if v == s {
+					component = scc
+				}
+			}
+		}
+		if len(component) == 1 && !directed {
+			continue
+		}
+		for v := range inComponent {
+			inComponent[v] = false
+		}
+		for _, v := range component {
+			inComponent[v] = true
+			blocked[v] = false
+			blockedBy[v] = map[int]bool{}
+		}
+		circuit(s, s)
+	}
+	return cycles, truncated
+}
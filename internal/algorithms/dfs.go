@@ -0,0 +1,201 @@
+package algorithms
+
+// EdgeClass is the role of an edge in a depth-first search forest
+type EdgeClass int
+
+const (
+	EdgeTree    EdgeClass = iota // Leads to a newly discovered node
+	EdgeBack                     // Leads to an ancestor that is still on the stack
+	EdgeForward                  // Leads to an already finished descendant (directed graphs only)
+	EdgeCross                    // Leads to a finished node in another subtree (directed graphs only)
+)
+
+// String returns a human-readable name for the edge class
+func (c EdgeClass) String() string {
+	switch c {
+	case EdgeTree:
+		return "tree"
+	case EdgeBack:
+		return "back"
+	case EdgeForward:
+		return "forward"
+	case EdgeCross:
+		return "cross"
+	}
+	return "unknown"
+}
+
+// ClassifiedEdge is an edge examined by the depth-first search
+type ClassifiedEdge struct {
+	From, To int
+	Class    EdgeClass
+}
+
+// DFSAction is the kind of event produced by one DFS step
+type DFSAction int
+
+const (
+	DFSNone     DFSAction = iota
+	DFSDiscover           // A node was discovered (Current)
+	DFSExamine            // A non-tree edge was classified (LastEdge)
+	DFSFinish             // A node was finished (Current)
+)
+
+// DFSTimesStepper runs a recursive depth-first search over the whole graph one event at a time,
+// recording discovery/finish times, parents and the class of every edge
+type DFSTimesStepper struct {
+	Discovery []int // Discovery time of each node, 0 while undiscovered
+	Finish    []int // Finish time of each node, 0 until finished
+	Parent    []int // DFS forest parent of each node, -1 for roots
+	Edges     []ClassifiedEdge
+	Stack     []int // Recursion stack, the active node is last
+	Time      int
+	Current   int // Node discovered or finished by the last step, -1 otherwise
+	Action    DFSAction
+	LastEdge  ClassifiedEdge // Edge classified by the last step (valid unless Action is DFSNone)
+	Directed  bool
+	graph     map[int][]int
+	nextEdge  []int // Index of the next neighbor to examine for each node
+	roots     []int // Nodes to start new trees from, source first
+}
+
+// NewDFSTimesStepper prepares a DFS that starts at source and then restarts from every
+// undiscovered node in index order
+// For undirected graphs (every edge stored both ways) only tree and back edges exist,
+// and each edge is classified once
+func NewDFSTimesStepper(neighbors map[int][]int, source, numNodes int, directed bool) *DFSTimesStepper {
+	d := &DFSTimesStepper{
+		Discovery: make([]int, numNodes),
+		Finish:    make([]int, numNodes),
+		Parent:    make([]int, numNodes),
+		Current:   -1,
+		Directed:  directed,
+		graph:     neighbors,
+		nextEdge:  make([]int, numNodes),
+	}
+	for i := range d.Parent {
+		d.Parent[i] = -1
+	}
+	if source >= 0 && source < numNodes {
+		d.roots = append(d.roots, source)
+	}
+	for i := 0; i < numNodes; i++ {
+		if i != source {
+			d.roots = append(d.roots, i)
+		}
+	}
+	return d
+}
+
+// Done reports whether every node has been finished
+func (d *DFSTimesStepper) Done() bool {
+	return len(d.Stack) == 0 && len(d.roots) == 0
+}
+
+// Step performs the next discovery, edge classification or finish
+// Returns true once every node has been finished
+func (d *DFSTimesStepper) Step() bool {
+	d.Current = -1
+	d.Action = DFSNone
+
+	for !d.Done() {
+		if len(d.Stack) == 0 {
+			// Start a new tree; finishStep guarantees the next root is undiscovered
+			root := d.roots[0]
+			d.roots = d.roots[1:]
+			d.discover(root)
+			break
+		}
+
+		u := d.Stack[len(d.Stack)-1]
+		if d.nextEdge[u] == len(d.graph[u]) {
+			d.Time++
+			d.Finish[u] = d.Time
+			d.Stack = d.Stack[:len(d.Stack)-1]
+			d.Current = u
+			d.Action = DFSFinish
+			break
+		}
+
+		v := d.graph[u][d.nextEdge[u]]
+		d.nextEdge[u]++
+
+		edge := ClassifiedEdge{From: u, To: v}
+		switch {
+		case d.Discovery[v] == 0:
+			edge.Class = EdgeTree
+			d.Parent[v] = u
+			d.Edges = append(d.Edges, edge)
+			d.LastEdge = edge
+			d.discover(v)
+			return d.finishStep()
+		case d.Finish[v] == 0:
+			// The reverse of the tree edge we arrived by is not a separate edge in an undirected graph
+			if !d.Directed && v == d.Parent[u] {
+				continue
+			}
+			edge.Class = EdgeBack
+		case !d.Directed:
+			continue // Already classified as a back edge from v's side
+		case d.Discovery[u] < d.Discovery[v]:
+			edge.Class = EdgeForward
+		default:
+			edge.Class = EdgeCross
+		}
+
+		d.Edges = append(d.Edges, edge)
+		d.LastEdge = edge
+		d.Action = DFSExamine
+		break
+	}
+	return d.finishStep()
+}
+
+// finishStep drops roots that were discovered inside earlier trees, so Done turns true
+// as soon as the last node is finished, and returns Done
+func (d *DFSTimesStepper) finishStep() bool {
+	for len(d.roots) > 0 && d.Discovery[d.roots[0]] != 0 {
+		d.roots = d.roots[1:]
+	}
+	return d.Done()
+}
+
+// discover marks a node as discovered and pushes it onto the recursion stack
+func (d *DFSTimesStepper) discover(v int) {
+	d.Time++
+	d.Discovery[v] = d.Time
+	d.Stack = append(d.Stack, v)
+	d.Current = v
+	d.Action = DFSDiscover
+}
+
+// ParenthesisString renders the discovery/finish events so far as nested parentheses,
+// e.g. "(A (B B) A)", where "(X" is X's discovery and "X)" its finish
+func (d *DFSTimesStepper) ParenthesisString() string {
+	events := make([]string, d.Time+1)
+	for v := range d.Discovery {
+		if t := d.Discovery[v]; t > 0 {
+			events[t] = "(" + string(rune('A'+v))
+		}
+		if t := d.Finish[v]; t > 0 {
+			events[t] = string(rune('A'+v)) + ")"
+		}
+	}
+
+	str := ""
+	for _, e := range events[1:] {
+		if str != "" {
+			str += " "
+		}
+		str += e
+	}
+	return str
+}
+
+// DFSTimes - Full recursive DFS from source returning discovery times, finish times, parents and edge classes
+func DFSTimes(neighbors map[int][]int, source, numNodes int, directed bool) ([]int, []int, []int, []ClassifiedEdge) {
+	d := NewDFSTimesStepper(neighbors, source, numNodes, directed)
+	for !d.Step() {
+	}
+	return d.Discovery, d.Finish, d.Parent, d.Edges
+}
@@ -0,0 +1,204 @@
+package algorithms
+
+// EulerKind says which Eulerian walk a graph has
+type EulerKind int
+
+const (
+	EulerNone    EulerKind = iota
+	EulerPath              // A trail using every edge once, between two different nodes
+	EulerCircuit           // A closed trail using every edge once
+)
+
+// EulerDiagnosis explains from the degrees and connectivity whether an Eulerian walk exists
+type EulerDiagnosis struct {
+	Kind       EulerKind
+	Start, End int   // Endpoints of the walk, -1 if there is none
+	Odd        []int // Undirected: nodes of odd degree
+	Surplus    []int // Directed: out-degree minus in-degree of every node
+	Components int   // Number of (weakly) connected components that contain edges
+}
+
+// DiagnoseEuler checks the degree conditions and that all edges lie in one component
+// An undirected graph needs 0 or 2 nodes of odd degree; a directed graph needs every node
+// balanced, or one node with one extra outgoing edge and one with one extra incoming edge
+func DiagnoseEuler(edges [][2]int, numNodes int, directed bool) EulerDiagnosis {
+	d := EulerDiagnosis{Kind: EulerNone, Start: -1, End: -1}
+	degree := make([]int, numNodes)
+	surplus := make([]int, numNodes)
+	parent := make([]int, numNodes)
+	for v := range parent {
+		parent[v] = v
+	}
+	var find func(v int) int
+	find = func(v int) int {
+		if parent[v] != v {
+			parent[v] = find(parent[v])
+		}
+		return parent[v]
+	}
+	for _, edge := range edges {
+		degree[edge[0]]++
+		degree[edge[1]]++
+		surplus[edge[0]]++
+		surplus[edge[1]]--
+		parent[find(edge[0])] = find(edge[1])
+	}
+
+	roots := map[int]bool{}
+	first := -1
+	for v := 0; v < numNodes; v++ {
+		if degree[v] > 0 {
+			roots[find(v)] = true
+			if first == -1 {
+				first = v
+			}
+		}
+	}
+	d.Components = len(roots)
+
+	if directed {
+		d.Surplus = surplus
+		start, end, balanced := -1, -1, true
+		for v, s := range surplus {
+			switch {
+			case s == 0:
+			case s == 1 && start == -1:
+				start = v
+			case s == -1 && end == -1:
+				end = v
+			default:
+				balanced = false
+			}
+		}
+		if !balanced || (start == -1) != (end == -1) || d.Components > 1 {
+			return d
+		}
+		if start == -1 {
+			d.Kind, d.Start, d.End = EulerCircuit, first, first
+		} else {
+			d.Kind, d.Start, d.End = EulerPath, start, end
+		}
+		return d
+	}
+
+	for v, deg := range degree {
+		if deg%2 == 1 {
+			d.Odd = append(d.Odd, v)
+		}
+	}
+	if d.Components > 1 {
+		return d
+	}
+	switch len(d.Odd) {
+	case 0:
+		d.Kind, d.Start, d.End = EulerCircuit, first, first
+	case 2:
+		d.Kind, d.Start, d.End = EulerPath, d.Odd[0], d.Odd[1]
+	}
+	return d
+}
+
+// HierholzerStepper builds an Eulerian path or circuit with Hierholzer's algorithm
+// A step either walks one unused edge from the top of the stack, or, when the top node has
+// none left, moves it onto the finished trail; sub-circuits are spliced in this way
+type HierholzerStepper struct {
+	Diagnosis  EulerDiagnosis
+	Edges      [][2]int
+	Used       []bool
+	Stack      []int // Nodes of the walk in progress
+	StackEdges []int // Edge walked to reach each stack node after the first
+	Trail      []int // Finished nodes, last node of the walk first
+	TrailEdges []int // Finished edges, in the same reversed order
+	LastEdge   int   // Edge walked or finished by the last step, -1 if none
+	Walked     bool  // Whether the last step walked an edge rather than finishing a node
+	adjacency  [][]int
+	next       []int // Position of the next edge to try in each node's adjacency
+	done       bool
+}
+
+// NewHierholzerStepper prepares an Eulerian walk over the given edges
+// Undirected edges may be walked either way; if no walk exists the stepper is done at once
+func NewHierholzerStepper(edges [][2]int, numNodes int, directed bool) *HierholzerStepper {
+	h := &HierholzerStepper{
+		Diagnosis: DiagnoseEuler(edges, numNodes, directed),
+		Edges:     edges,
+		Used:      make([]bool, len(edges)),
+		LastEdge:  -1,
+		adjacency: make([][]int, numNodes),
+		next:      make([]int, numNodes),
+	}
+	for i, edge := range edges {
+		h.adjacency[edge[0]] = append(h.adjacency[edge[0]], i)
+		if !directed && edge[0] != edge[1] {
+			h.adjacency[edge[1]] = append(h.adjacency[edge[1]], i)
+		}
+	}
+	if h.Diagnosis.Kind == EulerNone || h.Diagnosis.Start == -1 {
+		h.done = true
+		return h
+	}
+	h.Stack = []int{h.Diagnosis.Start}
+	return h
+}
+
+// Done reports whether every edge is on the trail, or no walk exists
+func (h *HierholzerStepper) Done() bool {
+	return h.done
+}
+
+// Step walks or finishes one edge
+// Returns true once the trail is complete
+func (h *HierholzerStepper) Step() bool {
+	h.LastEdge = -1
+	if h.done {
+		return true
+	}
+
+	v := h.Stack[len(h.Stack)-1]
+	for ; h.next[v] < len(h.adjacency[v]); h.next[v]++ {
+		i := h.adjacency[v][h.next[v]]
+		if h.Used[i] {
+			continue
+		}
+		h.Used[i] = true
+		to := h.Edges[i][1]
+		if to == v {
+			to = h.Edges[i][0]
+		}
+		h.Stack = append(h.Stack, to)
+		h.StackEdges = append(h.StackEdges, i)
+		h.LastEdge, h.Walked = i, true
+		return false
+	}
+
+	// v is stuck: every edge around it is used, so it comes next on the trail
+	h.Stack = h.Stack[:len(h.Stack)-1]
+	h.Trail = append(h.Trail, v)
+	h.Walked = false
+	if len(h.StackEdges) > 0 {
+		h.LastEdge = h.StackEdges[len(h.StackEdges)-1]
+		h.StackEdges = h.StackEdges[:len(h.StackEdges)-1]
+		h.TrailEdges = append(h.TrailEdges, h.LastEdge)
+	}
+	h.done = len(h.Stack) == 0
+	return h.done
+}
+
+// Path returns the finished walk from its start, or nil before it is complete
+func (h *HierholzerStepper) Path() []int {
+	if !h.done || h.Diagnosis.Kind == EulerNone {
+		return nil
+	}
+	path := append([]int{}, h.Trail...)
+	reverseInts(path)
+	return path
+}
+
+// EulerianPath - Eulerian path or circuit by Hierholzer's algorithm
+// Returns the nodes of the walk (nil if none exists) and the degree diagnosis
+func EulerianPath(edges [][2]int, numNodes int, directed bool) ([]int, EulerDiagnosis) {
+	h := NewHierholzerStepper(edges, numNodes, directed)
+	for !h.Step() {
+	}
+	return h.Path(), h.Diagnosis
+}
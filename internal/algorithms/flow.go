@@ -0,0 +1,332 @@
+package algorithms
+
+// flowEpsilon is the residual capacity below which an arc counts as saturated,
+// since capacities are floating-point edge weights
+const flowEpsilon = 1e-9
+
+// FlowArc is an arc of a flow network
+// Arcs come in pairs: arc i^1 is the reverse of arc i, and pushing flow along one
+// removes the same amount from the other
+type FlowArc struct {
+	From, To int
+	Capacity float64 // 0 for the reverse arcs added for the residual graph
+	Flow     float64 // Negative on a reverse arc while its twin carries flow
+}
+
+// Residual returns how much more flow the arc can carry
+func (a FlowArc) Residual() float64 {
+	return a.Capacity - a.Flow
+}
+
+// FlowNetwork is a directed graph with capacities and the flow currently assigned to it
+type FlowNetwork struct {
+	Arcs []FlowArc // Even indices are the graph's edges, odd indices their reverse residual arcs
+	Out  [][]int   // Indices of the arcs leaving each node
+}
+
+// NewFlowNetwork builds a network whose capacities are the edge weights
+// An undirected graph (every edge stored both ways) gets an arc with the full capacity in each direction
+func NewFlowNetwork(neighbors map[int][]Edge, numNodes int) *FlowNetwork {
+	f := &FlowNetwork{Out: make([][]int, numNodes)}
+	for from := 0; from < numNodes; from++ {
+		for _, edge := range neighbors[from] {
+			f.Out[edge.From] = append(f.Out[edge.From], len(f.Arcs))
+			f.Arcs = append(f.Arcs, FlowArc{From: edge.From, To: edge.To, Capacity: edge.Weight})
+			f.Out[edge.To] = append(f.Out[edge.To], len(f.Arcs))
+			f.Arcs = append(f.Arcs, FlowArc{From: edge.To, To: edge.From})
+		}
+	}
+	return f
+}
+
+// push sends amount along arc i, updating its reverse arc
+func (f *FlowNetwork) push(i int, amount float64) {
+	f.Arcs[i].Flow += amount
+	f.Arcs[i^1].Flow -= amount
+}
+
+// Value returns the net flow leaving source
+func (f *FlowNetwork) Value(source int) float64 {
+	total := 0.0
+	for _, i := range f.Out[source] {
+		total += f.Arcs[i].Flow
+	}
+	return total
+}
+
+// ResidualReachable marks the nodes reachable from source through arcs with residual capacity
+// Once the flow is maximum these nodes form the source side of a minimum cut
+func (f *FlowNetwork) ResidualReachable(source int) []bool {
+	reached := make([]bool, len(f.Out))
+	reached[source] = true
+	queue := []int{source}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range f.Out[u] {
+			if v := f.Arcs[i].To; !reached[v] && f.Arcs[i].Residual() > flowEpsilon {
+				reached[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	return reached
+}
+
+// CutArcs returns the graph edges (even arcs) leading from the source side to the sink side
+// Their capacities add up to the cut's capacity
+func (f *FlowNetwork) CutArcs(sourceSide []bool) []int {
+	cut := []int{}
+	for i := 0; i < len(f.Arcs); i += 2 {
+		arc := f.Arcs[i]
+		if sourceSide[arc.From] && !sourceSide[arc.To] {
+			cut = append(cut, i)
+		}
+	}
+	return cut
+}
+
+// augment pushes the bottleneck amount along a path of arc indices and returns it
+func (f *FlowNetwork) augment(path []int) float64 {
+	bottleneck := f.Arcs[path[0]].Residual()
+	for _, i := range path[1:] {
+		if r := f.Arcs[i].Residual(); r < bottleneck {
+			bottleneck = r
+		}
+	}
+	for _, i := range path {
+		f.push(i, bottleneck)
+	}
+	return bottleneck
+}
+
+// EdmondsKarpStepper runs Ford-Fulkerson with shortest (BFS) augmenting paths, one path per step
+type EdmondsKarpStepper struct {
+	Network       *FlowNetwork
+	Source, Sink  int
+	Path          []int   // Arcs of the path augmented by the last step
+	Bottleneck    float64 // Flow pushed by the last step
+	Augmentations int
+	MaxFlow       float64
+	SourceSide    []bool // Source side of the minimum cut, set once done
+	done          bool
+}
+
+// NewEdmondsKarpStepper prepares a max-flow computation from source to sink
+func NewEdmondsKarpStepper(neighbors map[int][]Edge, source, sink, numNodes int) *EdmondsKarpStepper {
+	e := &EdmondsKarpStepper{
+		Network: NewFlowNetwork(neighbors, numNodes),
+		Source:  source,
+		Sink:    sink,
+	}
+	if source == sink {
+		e.finish()
+	}
+	return e
+}
+
+// Done reports whether the flow is maximum
+func (e *EdmondsKarpStepper) Done() bool {
+	return e.done
+}
+
+// Step finds the shortest augmenting path in the residual graph and pushes its bottleneck
+// Returns true once no augmenting path is left
+func (e *EdmondsKarpStepper) Step() bool {
+	e.Path = nil
+	e.Bottleneck = 0
+	if e.done {
+		return true
+	}
+
+	f := e.Network
+	via := make([]int, len(f.Out)) // Arc used to reach each node, -1 if unreached
+	for i := range via {
+		via[i] = -1
+	}
+	queue := []int{e.Source}
+	for len(queue) > 0 && via[e.Sink] == -1 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range f.Out[u] {
+			v := f.Arcs[i].To
+			if v != e.Source && via[v] == -1 && f.Arcs[i].Residual() > flowEpsilon {
+				via[v] = i
+				queue = append(queue, v)
+			}
+		}
+	}
+
+	if via[e.Sink] == -1 {
+		e.finish()
+		return true
+	}
+
+	for v := e.Sink; v != e.Source; v = f.Arcs[via[v]].From {
+		e.Path = append([]int{via[v]}, e.Path...)
+	}
+	e.Bottleneck = f.augment(e.Path)
+	e.MaxFlow += e.Bottleneck
+	e.Augmentations++
+	return false
+}
+
+// finish records the minimum cut
+func (e *EdmondsKarpStepper) finish() {
+	e.done = true
+	e.SourceSide = e.Network.ResidualReachable(e.Source)
+}
+
+// EdmondsKarp - Maximum flow from source to sink, treating edge weights as capacities
+// Returns the flow value and the network holding the flow on every arc
+func EdmondsKarp(neighbors map[int][]Edge, source, sink, numNodes int) (float64, *FlowNetwork) {
+	e := NewEdmondsKarpStepper(neighbors, source, sink, numNodes)
+	for !e.Step() {
+	}
+	return e.MaxFlow, e.Network
+}
+
+// DinicStepper runs Dinic's algorithm: each phase builds a BFS level graph, then augments
+// along level-increasing paths until the flow in it is blocking
+// A step either builds a level graph or augments one path
+type DinicStepper struct {
+	Network       *FlowNetwork
+	Source, Sink  int
+	Level         []int // BFS level of each node in the current phase, -1 if unreachable
+	Phase         int   // Number of level graphs built
+	Path          []int // Arcs of the path augmented by the last step, nil after a level-graph step
+	Bottleneck    float64
+	Augmentations int
+	MaxFlow       float64
+	SourceSide    []bool // Source side of the minimum cut, set once done
+	next          []int  // Position in Out of the next arc to try for each node this phase
+	dead          []bool // Nodes from which no path reaches the sink this phase
+	blocked       bool   // The current level graph has no augmenting path left
+	done          bool
+}
+
+// NewDinicStepper prepares a max-flow computation from source to sink
+func NewDinicStepper(neighbors map[int][]Edge, source, sink, numNodes int) *DinicStepper {
+	d := &DinicStepper{
+		Network: NewFlowNetwork(neighbors, numNodes),
+		Source:  source,
+		Sink:    sink,
+		blocked: true,
+	}
+	if source == sink {
+		d.finish()
+	}
+	return d
+}
+
+// Done reports whether the flow is maximum
+func (d *DinicStepper) Done() bool {
+	return d.done
+}
+
+// Step builds the next level graph once the current one is blocked, otherwise augments one path in it
+// Returns true once the sink is unreachable in the residual graph
+func (d *DinicStepper) Step() bool {
+	d.Path = nil
+	d.Bottleneck = 0
+	if d.done {
+		return true
+	}
+
+	if d.blocked {
+		if !d.buildLevels() {
+			d.finish()
+			return true
+		}
+		d.Phase++
+		d.blocked = false
+		return false
+	}
+
+	path := d.findPath()
+	if path == nil {
+		// Blocking flow reached; the next step starts a new phase
+		d.blocked = true
+		return d.Step()
+	}
+	d.Path = path
+	d.Bottleneck = d.Network.augment(path)
+	d.MaxFlow += d.Bottleneck
+	d.Augmentations++
+	return false
+}
+
+// buildLevels labels nodes with their BFS distance from the source in the residual graph
+// Returns false if the sink cannot be reached
+func (d *DinicStepper) buildLevels() bool {
+	f := d.Network
+	d.Level = make([]int, len(f.Out))
+	for i := range d.Level {
+		d.Level[i] = -1
+	}
+	d.Level[d.Source] = 0
+	queue := []int{d.Source}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range f.Out[u] {
+			if v := f.Arcs[i].To; d.Level[v] == -1 && f.Arcs[i].Residual() > flowEpsilon {
+				d.Level[v] = d.Level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	d.next = make([]int, len(f.Out))
+	d.dead = make([]bool, len(f.Out))
+	return d.Level[d.Sink] != -1
+}
+
+// findPath walks level-increasing arcs with residual capacity from the source to the sink,
+// skipping for the rest of the phase any arc that leads to a dead end
+func (d *DinicStepper) findPath() []int {
+	f := d.Network
+	path := []int{}
+	u := d.Source
+	for u != d.Sink {
+		advanced := false
+		for ; d.next[u] < len(f.Out[u]); d.next[u]++ {
+			i := f.Out[u][d.next[u]]
+			v := f.Arcs[i].To
+			if !d.dead[v] && d.Level[v] == d.Level[u]+1 && f.Arcs[i].Residual() > flowEpsilon {
+				path = append(path, i)
+				u = v
+				advanced = true
+				break
+			}
+		}
+		if advanced {
+			continue
+		}
+
+		// Dead end: no path continues from u in this phase
+		if u == d.Source {
+			return nil
+		}
+		d.dead[u] = true
+		last := path[len(path)-1]
+		path = path[:len(path)-1]
+		u = f.Arcs[last].From
+		d.next[u]++
+	}
+	return path
+}
+
+// finish records the minimum cut
+func (d *DinicStepper) finish() {
+	d.done = true
+	d.SourceSide = d.Network.ResidualReachable(d.Source)
+}
+
+// Dinic - Maximum flow from source to sink using level graphs and blocking flows
+// Returns the flow value and the network holding the flow on every arc
+func Dinic(neighbors map[int][]Edge, source, sink, numNodes int) (float64, *FlowNetwork) {
+	d := NewDinicStepper(neighbors, source, sink, numNodes)
+	for !d.Step() {
+	}
+	return d.MaxFlow, d.Network
+}
@@ -3,7 +3,6 @@ package algorithms
 import (
 	"container/heap"
 	"math"
-	"sort"
 )
 
 // Edge represents a weighted edge in a graph
@@ -94,60 +93,41 @@ func Dijkstra(neighbors map[int][]Edge, source int, numNodes int) (map[int]float
 	return dist, prev
 }
 
+// PathFromPredecessors rebuilds the path ending at target from a predecessor map
+// The path starts at the node whose predecessor is -1 (the search source)
+func PathFromPredecessors(prev map[int]int, target int) []int {
+	path := []int{}
+	seen := make(map[int]bool)
+	for v := target; v != -1; {
+		if seen[v] {
+			return nil // Corrupt predecessor map
+		}
+		seen[v] = true
+		path = append(path, v)
+
+		p, exists := prev[v]
+		if !exists {
+			break
+		}
+		v = p
+	}
+
+	// Reverse into source-to-target order
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
 // AStar - A* search algorithm with heuristic
 type Position struct {
 	X, Y int
 }
 
 func AStar(neighbors map[int][]Edge, start, goal int, positions map[int]Position) ([]int, float64) {
-	openSet := &PriorityQueue{}
-	heap.Init(openSet)
-
-	gScore := make(map[int]float64)
-	fScore := make(map[int]float64)
-	cameFrom := make(map[int]int)
-
-	for i := range neighbors {
-		gScore[i] = math.Inf(1)
-		fScore[i] = math.Inf(1)
-	}
-
-	gScore[start] = 0
-	fScore[start] = heuristic(positions[start], positions[goal])
-
-	heap.Push(openSet, &PriorityQueueItem{Node: start, Priority: fScore[start]})
-
-	for openSet.Len() > 0 {
-		current := heap.Pop(openSet).(*PriorityQueueItem).Node
-
-		if current == goal {
-			// Reconstruct path
-			path := []int{}
-			for current != -1 {
-				path = append([]int{current}, path...)
-				if prev, exists := cameFrom[current]; exists {
-					current = prev
-				} else {
-					break
-				}
-			}
-			return path, gScore[goal]
-		}
-
-		for _, edge := range neighbors[current] {
-			tentativeGScore := gScore[current] + edge.Weight
-
-			if tentativeGScore < gScore[edge.To] {
-				cameFrom[edge.To] = current
-				gScore[edge.To] = tentativeGScore
-				fScore[edge.To] = gScore[edge.To] + heuristic(positions[edge.To], positions[goal])
-
-				heap.Push(openSet, &PriorityQueueItem{Node: edge.To, Priority: fScore[edge.To]})
-			}
-		}
-	}
-
-	return nil, math.Inf(1) // No path found
+	h := NewHeuristic(HeuristicEuclidean, goal, HeuristicOptions{Positions: positions, Scale: 1})
+	result := AStarSearch(neighbors, start, goal, h)
+	return result.Path, result.Cost
 }
 
 // Heuristic function for A* (Euclidean distance)
@@ -157,196 +137,84 @@ func heuristic(a, b Position) float64 {
 	return math.Sqrt(dx*dx + dy*dy)
 }
 
-// TopologicalSort - sorts vertices in topological order (for DAG)
-func TopologicalSort(neighbors map[int][]int, numNodes int) []int {
-	visited := make(map[int]bool)
-	stack := []int{}
+// TopologicalSort - sorts vertices in topological order using DFS
+// Returns the order, or nil and a witness cycle (first node repeated at the end) if the graph has a cycle
+func TopologicalSort(neighbors map[int][]int, numNodes int) ([]int, []int) {
+	const (
+		unvisited = iota
+		onStack
+		finished
+	)
+	state := make([]int, numNodes)
+	parent := make([]int, numNodes)
+	order := make([]int, 0, numNodes) // Nodes in finishing order, reversed at the end
+	var cycle []int
 
-	var dfs func(int)
-	dfs = func(node int) {
-		visited[node] = true
+	var dfs func(int) bool
+	dfs = func(node int) bool {
+		state[node] = onStack
 		for _, neighbor := range neighbors[node] {
-			if !visited[neighbor] {
-				dfs(neighbor)
+			switch state[neighbor] {
+			case unvisited:
+				parent[neighbor] = node
+				if dfs(neighbor) {
+					return true
+				}
+			case onStack:
+				// Back edge: the tree path from neighbor down to node closes a cycle
+				cycle = []int{node}
+				for v := node; v != neighbor; {
+					v = parent[v]
+					cycle = append(cycle, v)
+				}
+				reverseInts(cycle)
+				cycle = append(cycle, neighbor)
+				return true
 			}
 		}
-		stack = append([]int{node}, stack...)
+		state[node] = finished
+		order = append(order, node)
+		return false
 	}
 
 	for i := 0; i < numNodes; i++ {
-		if !visited[i] {
-			dfs(i)
+		if state[i] == unvisited && dfs(i) {
+			return nil, cycle
 		}
 	}
 
-	return stack
+	reverseInts(order)
+	return order, nil
 }
 
 // Kruskal's Algorithm - finds minimum spanning tree
+// The input slice is not modified; edges are sorted on a copy
 func Kruskal(edges []Edge, numNodes int) []Edge {
-	// Sort edges by weight
-	sort.Slice(edges, func(i, j int) bool {
-		return edges[i].Weight < edges[j].Weight
-	})
-
-	// Initialize union-find
-	parent := make([]int, numNodes)
-	rank := make([]int, numNodes)
-	for i := 0; i < numNodes; i++ {
-		parent[i] = i
-		rank[i] = 0
+	k := NewKruskalStepper(edges, numNodes)
+	for !k.Step() {
 	}
-
-	var find func(int) int
-	find = func(x int) int {
-		if parent[x] != x {
-			parent[x] = find(parent[x])
-		}
-		return parent[x]
-	}
-
-	union := func(x, y int) bool {
-		px, py := find(x), find(y)
-		if px == py {
-			return false
-		}
-		if rank[px] < rank[py] {
-			px, py = py, px
-		}
-		parent[py] = px
-		if rank[px] == rank[py] {
-			rank[px]++
-		}
-		return true
-	}
-
-	mst := []Edge{}
-	for _, edge := range edges {
-		if union(edge.From, edge.To) {
-			mst = append(mst, edge)
-			if len(mst) == numNodes-1 {
-				break
-			}
-		}
-	}
-
-	return mst
+	return k.MST
 }
 
-// Prim's Algorithm - finds minimum spanning tree
+// Prim's Algorithm - finds minimum spanning tree (a forest if the graph is disconnected) rooted at node 0
 func Prim(neighbors map[int][]Edge, numNodes int) []Edge {
-	if numNodes == 0 {
-		return []Edge{}
-	}
-
-	visited := make(map[int]bool)
-	mst := []Edge{}
-	pq := &PriorityQueue{}
-	heap.Init(pq)
-
-	// Start with node 0
-	visited[0] = true
-	for _, edge := range neighbors[0] {
-		heap.Push(pq, &PriorityQueueItem{Node: edge.To, Priority: edge.Weight})
-	}
-
-	for pq.Len() > 0 && len(mst) < numNodes-1 {
-		item := heap.Pop(pq).(*PriorityQueueItem)
-		node := item.Node
-		weight := item.Priority
-
-		if visited[node] {
-			continue
-		}
-
-		visited[node] = true
-
-		// Find the edge that led to this node with the minimum weight
-		var minEdge Edge
-		found := false
-		for visitedNode := range visited {
-			if visitedNode == node {
-				continue
-			}
-			for _, edge := range neighbors[visitedNode] {
-				if edge.To == node && edge.Weight == weight {
-					minEdge = Edge{From: visitedNode, To: node, Weight: weight}
-					found = true
-					break
-				}
-			}
-			if found {
-				break
-			}
-		}
-
-		if found {
-			mst = append(mst, minEdge)
-		}
+	return PrimFrom(neighbors, 0, numNodes)
+}
 
-		// Add new edges to priority queue
-		for _, edge := range neighbors[node] {
-			if !visited[edge.To] {
-				heap.Push(pq, &PriorityQueueItem{Node: edge.To, Priority: edge.Weight})
-			}
-		}
+// PrimFrom - Prim's algorithm grown from the given root
+func PrimFrom(neighbors map[int][]Edge, root, numNodes int) []Edge {
+	p := NewPrimStepper(neighbors, root, numNodes)
+	for !p.Step() {
 	}
-
-	return mst
+	return p.MST
 }
 
 // Tarjan's Algorithm - finds strongly connected components
 func Tarjan(neighbors map[int][]int, numNodes int) [][]int {
-	index := 0
-	stack := []int{}
-	onStack := make(map[int]bool)
-	indices := make(map[int]int)
-	lowlinks := make(map[int]int)
-	sccs := [][]int{}
-
-	var strongConnect func(int)
-	strongConnect = func(v int) {
-		indices[v] = index
-		lowlinks[v] = index
-		index++
-		stack = append(stack, v)
-		onStack[v] = true
-
-		for _, w := range neighbors[v] {
-			if _, exists := indices[w]; !exists {
-				strongConnect(w)
-				if lowlinks[w] < lowlinks[v] {
-					lowlinks[v] = lowlinks[w]
-				}
-			} else if onStack[w] {
-				if indices[w] < lowlinks[v] {
-					lowlinks[v] = indices[w]
-				}
-			}
-		}
-
-		if lowlinks[v] == indices[v] {
-			scc := []int{}
-			for {
-				w := stack[len(stack)-1]
-				stack = stack[:len(stack)-1]
-				onStack[w] = false
-				scc = append(scc, w)
-				if w == v {
-					break
-				}
-			}
-			sccs = append(sccs, scc)
-		}
+	t := NewTarjanStepper(neighbors, numNodes)
+	for !t.Step() {
 	}
-
-	for i := 0; i < numNodes; i++ {
-		if _, exists := indices[i]; !exists {
-			strongConnect(i)
-		}
-	}
-
-	return sccs
+	return t.SCCs
 }
 
 // Kosaraju's Algorithm - alternative for strongly connected components
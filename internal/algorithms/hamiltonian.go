@@ -0,0 +1,127 @@
+package algorithms
+
+// HamiltonianStepper searches for a path or cycle through every node exactly once by backtracking
+// A step extends the path by one node or removes its last node; the search gives up once it has
+// extended the path Budget times
+type HamiltonianStepper struct {
+	Path      []int // Current partial path
+	Cycle     bool  // Search for a cycle rather than a path
+	Budget    int   // Maximum number of extensions, 0 for no limit
+	Expanded  int   // Extensions so far
+	Found     bool
+	OutOfTime bool // The budget ran out before the search finished
+	Removed   int  // Node removed by the last step, -1 if the last step extended the path
+	neighbors map[int][]int
+	numNodes  int
+	onPath    []bool
+	next      []int // Position in its neighbor list of the next candidate after each path node
+	start     int   // Start node of the current attempt
+	done      bool
+}
+
+// NewHamiltonianStepper prepares the search
+// Paths are tried from every start node in turn; a cycle may start anywhere, so only node 0 is tried
+func NewHamiltonianStepper(neighbors map[int][]int, numNodes int, cycle bool, budget int) *HamiltonianStepper {
+	h := &HamiltonianStepper{
+		Cycle:     cycle,
+		Budget:    budget,
+		Removed:   -1,
+		neighbors: neighbors,
+		numNodes:  numNodes,
+		onPath:    make([]bool, numNodes),
+		next:      make([]int, numNodes),
+	}
+	h.done = numNodes == 0
+	return h
+}
+
+// Done reports whether the search found a path, ran out of budget or tried everything
+func (h *HamiltonianStepper) Done() bool {
+	return h.done
+}
+
+// Step extends the path with the next unvisited neighbor of its last node, or backtracks
+// Returns true once the search is over
+func (h *HamiltonianStepper) Step() bool {
+	h.Removed = -1
+	if h.done {
+		return true
+	}
+	if h.Budget > 0 && h.Expanded >= h.Budget {
+		h.OutOfTime = true
+		h.done = true
+		return true
+	}
+
+	if len(h.Path) == 0 {
+		if h.start == h.numNodes || (h.Cycle && h.start > 0) {
+			h.done = true // Every start failed
+			return true
+		}
+		h.push(h.start)
+		h.start++
+		return h.checkComplete()
+	}
+
+	last := h.Path[len(h.Path)-1]
+	candidates := h.neighbors[last]
+	for ; h.next[last] < len(candidates); h.next[last]++ {
+		if v := candidates[h.next[last]]; !h.onPath[v] {
+			h.next[last]++
+			h.push(v)
+			return h.checkComplete()
+		}
+	}
+
+	// No unvisited neighbor is left: backtrack
+	h.Path = h.Path[:len(h.Path)-1]
+	h.onPath[last] = false
+	h.Removed = last
+	return false
+}
+
+// push appends v to the path
+func (h *HamiltonianStepper) push(v int) {
+	h.Path = append(h.Path, v)
+	h.onPath[v] = true
+	h.next[v] = 0
+	h.Expanded++
+}
+
+// checkComplete finishes the search if the path covers every node (and closes into a cycle when
+// one is wanted); a cycle needs at least three nodes, so that an undirected one uses no edge twice
+func (h *HamiltonianStepper) checkComplete() bool {
+	if len(h.Path) < h.numNodes {
+		return false
+	}
+	if !h.Cycle {
+		h.Found = true
+		h.done = true
+		return true
+	}
+	if len(h.Path) < 3 {
+		return false
+	}
+	last := h.Path[len(h.Path)-1]
+	for _, v := range h.neighbors[last] {
+		if v == h.Path[0] {
+			h.Found = true
+			h.done = true
+			return true
+		}
+	}
+	return false
+}
+
+// HamiltonianPath - Backtracking search for a Hamiltonian path or cycle within a budget of extensions
+// Returns the nodes in order (nil if none was found) and whether the budget ran out first
+// A cycle is returned without repeating its first node
+func HamiltonianPath(neighbors map[int][]int, numNodes int, cycle bool, budget int) ([]int, bool) {
+	h := NewHamiltonianStepper(neighbors, numNodes, cycle, budget)
+	for !h.Step() {
+	}
+	if !h.Found {
+		return nil, h.OutOfTime
+	}
+	return h.Path, false
+}
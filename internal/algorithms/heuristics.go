@@ -0,0 +1,228 @@
+package algorithms
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Heuristic estimates the remaining cost from a node to a fixed goal
+type Heuristic func(node int) float64
+
+// HeuristicKind selects one of the built-in A* heuristics
+type HeuristicKind int
+
+const (
+	HeuristicEuclidean HeuristicKind = iota
+	HeuristicManhattan
+	HeuristicChebyshev
+	HeuristicZero     // Always 0, A* degenerates to Dijkstra
+	HeuristicWeighted // Euclidean multiplied by Epsilon (weighted A*)
+	HeuristicCustom   // User-defined per-node values
+)
+
+// HeuristicKinds lists every heuristic in display order
+var HeuristicKinds = []HeuristicKind{
+	HeuristicEuclidean,
+	HeuristicManhattan,
+	HeuristicChebyshev,
+	HeuristicZero,
+	HeuristicWeighted,
+	HeuristicCustom,
+}
+
+// String returns a human-readable name for the heuristic
+func (k HeuristicKind) String() string {
+	switch k {
+	case HeuristicEuclidean:
+		return "Euclidean"
+	case HeuristicManhattan:
+		return "Manhattan"
+	case HeuristicChebyshev:
+		return "Chebyshev"
+	case HeuristicZero:
+		return "Zero"
+	case HeuristicWeighted:
+		return "Weighted"
+	case HeuristicCustom:
+		return "Custom"
+	}
+	return "Unknown"
+}
+
+// HeuristicOptions holds the inputs the built-in heuristics draw from
+type HeuristicOptions struct {
+	Positions map[int]Position
+	Scale     float64         // Converts pixel distances into edge-weight units
+	Epsilon   float64         // Inflation factor for HeuristicWeighted
+	Custom    map[int]float64 // Per-node values for HeuristicCustom (missing nodes count as 0)
+}
+
+// NewHeuristic builds a heuristic of the given kind towards goal
+func NewHeuristic(kind HeuristicKind, goal int, opts HeuristicOptions) Heuristic {
+	target := opts.Positions[goal]
+	distance := func(node int, metric func(dx, dy float64) float64) float64 {
+		p := opts.Positions[node]
+		return opts.Scale * metric(math.Abs(float64(p.X-target.X)), math.Abs(float64(p.Y-target.Y)))
+	}
+
+	switch kind {
+	case HeuristicManhattan:
+		return func(node int) float64 {
+			return distance(node, func(dx, dy float64) float64 { return dx + dy })
+		}
+	case HeuristicChebyshev:
+		return func(node int) float64 {
+			return distance(node, math.Max)
+		}
+	case HeuristicZero:
+		return func(int) float64 { return 0 }
+	case HeuristicWeighted:
+		return func(node int) float64 {
+			return opts.Epsilon * opts.Scale * heuristic(opts.Positions[node], target)
+		}
+	case HeuristicCustom:
+		return func(node int) float64 { return opts.Custom[node] }
+	}
+
+	return func(node int) float64 {
+		return opts.Scale * heuristic(opts.Positions[node], target)
+	}
+}
+
+// AdmissibleScale returns the largest factor that keeps scaled Euclidean distance
+// below every edge weight, which makes the Euclidean heuristic admissible and consistent
+func AdmissibleScale(neighbors map[int][]Edge, positions map[int]Position) float64 {
+	scale := math.Inf(1)
+	for from, edges := range neighbors {
+		for _, edge := range edges {
+			length := heuristic(positions[from], positions[edge.To])
+			if length > 0 && edge.Weight/length < scale {
+				scale = edge.Weight / length
+			}
+		}
+	}
+	if math.IsInf(scale, 1) || scale < 0 {
+		return 1
+	}
+	return scale
+}
+
+// AStarResult holds the outcome of an A* search
+type AStarResult struct {
+	Path     []int
+	Cost     float64
+	Expanded []int // Nodes in the order they were expanded, including re-expansions
+}
+
+// AStarSearch - A* search from start to goal with a pluggable heuristic
+func AStarSearch(neighbors map[int][]Edge, start, goal int, h Heuristic) AStarResult {
+	result := AStarResult{Cost: math.Inf(1)}
+
+	gScore := map[int]float64{start: 0}
+	cameFrom := make(map[int]int)
+
+	openSet := &PriorityQueue{}
+	heap.Init(openSet)
+	heap.Push(openSet, &PriorityQueueItem{Node: start, Priority: h(start)})
+
+	for openSet.Len() > 0 {
+		item := heap.Pop(openSet).(*PriorityQueueItem)
+		current := item.Node
+
+		// Skip queue entries superseded by a cheaper path
+		if item.Priority > gScore[current]+h(current)+1e-9 {
+			continue
+		}
+		result.Expanded = append(result.Expanded, current)
+
+		if current == goal {
+			result.Path = []int{current}
+			for {
+				prev, exists := cameFrom[current]
+				if !exists {
+					break
+				}
+				result.Path = append([]int{prev}, result.Path...)
+				current = prev
+			}
+			result.Cost = gScore[goal]
+			return result
+		}
+
+		for _, edge := range neighbors[current] {
+			tentativeGScore := gScore[current] + edge.Weight
+			if old, seen := gScore[edge.To]; !seen || tentativeGScore < old {
+				cameFrom[edge.To] = current
+				gScore[edge.To] = tentativeGScore
+				heap.Push(openSet, &PriorityQueueItem{Node: edge.To, Priority: tentativeGScore + h(edge.To)})
+			}
+		}
+	}
+
+	return result // No path found
+}
+
+// CheckHeuristic reports whether h is admissible (never overestimates the true
+// cost to goal) and consistent (h(u) <= w(u,v) + h(v) for every edge)
+func CheckHeuristic(neighbors map[int][]Edge, goal, numNodes int, h Heuristic) (admissible, consistent bool) {
+	const eps = 1e-9
+
+	// True remaining costs are shortest distances to goal, i.e. Dijkstra on the reversed graph
+	reversed := make(map[int][]Edge, numNodes)
+	for from, edges := range neighbors {
+		for _, edge := range edges {
+			reversed[edge.To] = append(reversed[edge.To], Edge{From: edge.To, To: from, Weight: edge.Weight})
+		}
+	}
+	toGoal, _ := Dijkstra(reversed, goal, numNodes)
+
+	admissible = true
+	for v := 0; v < numNodes; v++ {
+		if !math.IsInf(toGoal[v], 1) && h(v) > toGoal[v]+eps {
+			admissible = false
+			break
+		}
+	}
+
+	consistent = math.Abs(h(goal)) <= eps
+	for from, edges := range neighbors {
+		for _, edge := range edges {
+			if h(from) > edge.Weight+h(edge.To)+eps {
+				consistent = false
+			}
+		}
+	}
+
+	return admissible, consistent
+}
+
+// HeuristicStats summarizes one heuristic's behavior on a search
+type HeuristicStats struct {
+	Kind       HeuristicKind
+	Expanded   int
+	Cost       float64
+	Admissible bool
+	Consistent bool
+}
+
+// CompareHeuristics runs A* with every built-in heuristic on the same start and goal
+// The custom heuristic is only included when custom values are present
+func CompareHeuristics(neighbors map[int][]Edge, start, goal, numNodes int, opts HeuristicOptions) []HeuristicStats {
+	stats := []HeuristicStats{}
+	for _, kind := range HeuristicKinds {
+		if kind == HeuristicCustom && len(opts.Custom) == 0 {
+			continue
+		}
+		h := NewHeuristic(kind, goal, opts)
+		result := AStarSearch(neighbors, start, goal, h)
+		admissible, consistent := CheckHeuristic(neighbors, goal, numNodes, h)
+		stats = append(stats, HeuristicStats{
+			Kind:       kind,
+			Expanded:   len(result.Expanded),
+			Cost:       result.Cost,
+			Admissible: admissible,
+			Consistent: consistent,
+		})
+	}
+	return stats
+}
@@ -0,0 +1,198 @@
+package algorithms
+
+import (
+	"math"
+	"sort"
+)
+
+// WeightedPath is a path through the graph with its total edge weight
+type WeightedPath struct {
+	Nodes []int
+	Cost  float64
+}
+
+// lightestEdges returns, for every node, the lightest edge to each of its neighbors
+// Parallel edges would otherwise make the same node sequence appear twice
+func lightestEdges(neighbors map[int][]Edge, numNodes int) []map[int]float64 {
+	weights := make([]map[int]float64, numNodes)
+	for v := range weights {
+		weights[v] = map[int]float64{}
+		for _, edge := range neighbors[v] {
+			if w, ok := weights[v][edge.To]; !ok || edge.Weight < w {
+				weights[v][edge.To] = edge.Weight
+			}
+		}
+	}
+	return weights
+}
+
+// pathCost returns the total weight of the path along the lightest edges
+func pathCost(weights []map[int]float64, path []int) float64 {
+	cost := 0.0
+	for i := 0; i+1 < len(path); i++ {
+		cost += weights[path[i]][path[i+1]]
+	}
+	return cost
+}
+
+// shortestPathAvoiding runs Dijkstra from source to target without the blocked nodes and edges
+// Returns nil if target cannot be reached
+func shortestPathAvoiding(weights []map[int]float64, source, target int, blockedNodes []bool, blockedEdges map[[2]int]bool) []int {
+	neighbors := make(map[int][]Edge, len(weights))
+	for v, out := range weights {
+		if blockedNodes[v] {
+			continue
+		}
+		for w, weight := range out {
+			if !blockedNodes[w] && !blockedEdges[[2]int{v, w}] {
+				neighbors[v] = append(neighbors[v], Edge{From: v, To: w, Weight: weight})
+			}
+		}
+		// Map order is random; sorted lists keep ties between equal paths deterministic
+		sort.Slice(neighbors[v], func(i, j int) bool { return neighbors[v][i].To < neighbors[v][j].To })
+	}
+
+	dist, prev := Dijkstra(neighbors, source, len(weights))
+	if math.IsInf(dist[target], 1) {
+		return nil
+	}
+	path := []int{}
+	for v := target; v != -1; v = prev[v] {
+		path = append(path, v)
+	}
+	reverseInts(path)
+	return path
+}
+
+// samePrefix reports whether path starts with prefix
+func samePrefix(path, prefix []int) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i, v := range prefix {
+		if path[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// pathKey returns a map key identifying the node sequence
+func pathKey(path []int) string {
+	key := make([]rune, len(path))
+	for i, v := range path {
+		key[i] = rune('A' + v)
+	}
+	return string(key)
+}
+
+// YenKShortestPaths - Yen's algorithm for the k cheapest loopless paths from source to target
+// Each new path leaves an earlier one at some spur node: the spur path is the shortest path
+// from there that avoids the nodes before it and the edges every found path with the same
+// root takes next. The cheapest candidate becomes the next path
+// Returns the paths in order of cost; fewer than k if the graph has no more
+func YenKShortestPaths(neighbors map[int][]Edge, source, target, numNodes, k int) []WeightedPath {
+	if k < 1 || source < 0 || target < 0 || source >= numNodes || target >= numNodes {
+		return nil
+	}
+	weights := lightestEdges(neighbors, numNodes)
+	first := shortestPathAvoiding(weights, source, target, make([]bool, numNodes), nil)
+	if first == nil {
+		return nil
+	}
+
+	found := []WeightedPath{{Nodes: first, Cost: pathCost(weights, first)}}
+	seen := map[string]bool{pathKey(first): true}
+	candidates := []WeightedPath{}
+	for len(found) < k {
+		last := found[len(found)-1].Nodes
+		for i := 0; i+1 < len(last); i++ {
+			root := last[:i+1]
+			blockedEdges := map[[2]int]bool{}
+			for _, p := range found {
+				if samePrefix(p.Nodes, root) && len(p.Nodes) > i+1 {
+					blockedEdges[[2]int{p.Nodes[i], p.Nodes[i+1]}] = true
+				}
+			}
+			blockedNodes := make([]bool, numNodes)
+			for _, v := range root[:i] {
+				blockedNodes[v] = true
+			}
+
+			spur := shortestPathAvoiding(weights, last[i], target, blockedNodes, blockedEdges)
+			if spur == nil {
+				continue
+			}
+			path := append(append([]int{}, root[:i]...), spur...)
+			if key := pathKey(path); !seen[key] {
+				seen[key] = true
+				candidates = append(candidates, WeightedPath{Nodes: path, Cost: pathCost(weights, path)})
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		// The cheapest candidate, preferring fewer edges and then the alphabetically first
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i], candidates[j]
+			if a.Cost != b.Cost {
+				return a.Cost < b.Cost
+			}
+			if len(a.Nodes) != len(b.Nodes) {
+				return len(a.Nodes) < len(b.Nodes)
+			}
+			return pathKey(a.Nodes) < pathKey(b.Nodes)
+		})
+		found = append(found, candidates[0])
+		candidates = candidates[1:]
+	}
+	return found
+}
+
+// AllSimplePaths - DFS over every path from source to target that repeats no node
+// Paths are returned in the order the DFS finds them, visiting neighbors by index; at most limit
+// paths are returned (0 for no limit), and the second result reports whether more exist
+func AllSimplePaths(neighbors map[int][]Edge, source, target, numNodes, limit int) ([]WeightedPath, bool) {
+	if source < 0 || target < 0 || source >= numNodes || target >= numNodes {
+		return nil, false
+	}
+	weights := lightestEdges(neighbors, numNodes)
+	adjacency := make([][]int, numNodes)
+	for v, out := range weights {
+		for w := range out {
+			adjacency[v] = append(adjacency[v], w)
+		}
+		sort.Ints(adjacency[v])
+	}
+
+	paths := []WeightedPath{}
+	truncated := false
+	onPath := make([]bool, numNodes)
+	path := []int{}
+	var dfs func(v int, cost float64)
+	dfs = func(v int, cost float64) {
+		path = append(path, v)
+		onPath[v] = true
+		if v == target {
+			if limit > 0 && len(paths) == limit {
+				truncated = true
+			} else {
+				paths = append(paths, WeightedPath{Nodes: append([]int{}, path...), Cost: cost})
+			}
+		} else {
+			for _, w := range adjacency[v] {
+				if truncated {
+					break
+				}
+				if !onPath[w] {
+					dfs(w, cost+weights[v][w])
+				}
+			}
+		}
+		onPath[v] = false
+		path = path[:len(path)-1]
+	}
+	dfs(source, 0)
+	return paths, truncated
+}
@@ -0,0 +1,268 @@
+package algorithms
+
+import "math"
+
+// Bipartite - BFS 2-coloring of an undirected graph (every edge stored both ways)
+// Returns the side (0 or 1) of every node, or nil and an odd cycle (first node repeated at the end)
+// if the graph is not bipartite
+func Bipartite(neighbors map[int][]int, numNodes int) ([]int, []int) {
+	side := make([]int, numNodes)
+	parent := make([]int, numNodes)
+	depth := make([]int, numNodes)
+	for i := range side {
+		side[i] = -1
+	}
+
+	for start := 0; start < numNodes; start++ {
+		if side[start] != -1 {
+			continue
+		}
+		side[start] = 0
+		parent[start] = -1
+		queue := []int{start}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range neighbors[u] {
+				if side[v] == -1 {
+					side[v] = 1 - side[u]
+					parent[v] = u
+					depth[v] = depth[u] + 1
+					queue = append(queue, v)
+				} else if side[v] == side[u] {
+					return nil, oddCycle(parent, depth, u, v)
+				}
+			}
+		}
+	}
+	return side, nil
+}
+
+// oddCycle closes the BFS tree paths from u and v to their common ancestor with the edge v-u
+// u and v have the same color, so their depths have the same parity and the cycle is odd
+func oddCycle(parent, depth []int, u, v int) []int {
+	fromU, fromV := []int{u}, []int{v}
+	for u != v {
+		if depth[u] >= depth[v] {
+			u = parent[u]
+			fromU = append(fromU, u)
+		} else {
+			v = parent[v]
+			fromV = append(fromV, v)
+		}
+	}
+	// fromU ends at the common ancestor; follow fromV back down without repeating it
+	cycle := fromU
+	for i := len(fromV) - 2; i >= 0; i-- {
+		cycle = append(cycle, fromV[i])
+	}
+	return append(cycle, cycle[0])
+}
+
+// HopcroftKarp - Maximum matching of a bipartite graph with the given sides (as returned by Bipartite)
+// Each phase finds a maximal set of vertex-disjoint shortest augmenting paths
+// Returns the partner of every node, -1 for unmatched nodes, and the number of phases
+func HopcroftKarp(neighbors map[int][]int, side []int) ([]int, int) {
+	n := len(side)
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	dist := make([]int, n)
+	const unreached = math.MaxInt
+
+	// bfs layers the free left nodes at 0 and alternates unmatched/matched edges;
+	// returns whether some free right node is reachable
+	bfs := func() bool {
+		queue := []int{}
+		for u := 0; u < n; u++ {
+			if side[u] != 0 {
+				continue
+			}
+			if match[u] == -1 {
+				dist[u] = 0
+				queue = append(queue, u)
+			} else {
+				dist[u] = unreached
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range neighbors[u] {
+				partner := match[v]
+				if partner == -1 {
+					found = true
+				} else if dist[partner] == unreached {
+					dist[partner] = dist[u] + 1
+					queue = append(queue, partner)
+				}
+			}
+		}
+		return found
+	}
+
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		for _, v := range neighbors[u] {
+			partner := match[v]
+			if partner == -1 || (dist[partner] == dist[u]+1 && dfs(partner)) {
+				match[u] = v
+				match[v] = u
+				return true
+			}
+		}
+		dist[u] = unreached // Dead end for the rest of this phase
+		return false
+	}
+
+	phases := 0
+	for bfs() {
+		phases++
+		for u := 0; u < n; u++ {
+			if side[u] == 0 && match[u] == -1 {
+				dfs(u)
+			}
+		}
+	}
+	return match, phases
+}
+
+// Hungarian - Minimum-cost assignment between the two sides of a weighted bipartite graph
+// Among the matchings with the most edges it finds one of least total weight; pairs without an
+// edge are never matched
+// Returns the partner of every node (-1 if unmatched) and the total weight of the matched edges
+func Hungarian(neighbors map[int][]Edge, side []int) ([]int, float64) {
+	left, right := []int{}, []int{}
+	for v, s := range side {
+		if s == 0 {
+			left = append(left, v)
+		} else if s == 1 {
+			right = append(right, v)
+		}
+	}
+	match := make([]int, len(side))
+	for i := range match {
+		match[i] = -1
+	}
+
+	// Square cost matrix; a missing edge costs more than every real edge together,
+	// so the optimum uses as many real edges as possible
+	size := len(left)
+	if len(right) > size {
+		size = len(right)
+	}
+	if size == 0 {
+		return match, 0
+	}
+	column := make(map[int]int, len(right))
+	for j, v := range right {
+		column[v] = j
+	}
+	total := 0.0
+	hasEdge := make([][]bool, size)
+	cost := make([][]float64, size)
+	for i := range cost {
+		cost[i] = make([]float64, size)
+		hasEdge[i] = make([]bool, size)
+	}
+	for i, u := range left {
+		for _, edge := range neighbors[u] {
+			j, ok := column[edge.To]
+			if !ok {
+				continue
+			}
+			if !hasEdge[i][j] || edge.Weight < cost[i][j] {
+				cost[i][j] = edge.Weight
+			}
+			hasEdge[i][j] = true
+			total += math.Abs(edge.Weight)
+		}
+	}
+	missing := total + 1
+	for i := range cost {
+		for j := range cost[i] {
+			if i < len(left) && j < len(right) && !hasEdge[i][j] {
+				cost[i][j] = missing
+			}
+		}
+	}
+
+	assignment := hungarianSquare(cost)
+	weight := 0.0
+	for i, j := range assignment {
+		if i < len(left) && j < len(right) && hasEdge[i][j] {
+			match[left[i]] = right[j]
+			match[right[j]] = left[i]
+			weight += cost[i][j]
+		}
+	}
+	return match, weight
+}
+
+// hungarianSquare solves the assignment problem on a square cost matrix with row and column
+// potentials in O(n^3), returning the column assigned to every row
+func hungarianSquare(cost [][]float64) []int {
+	n := len(cost)
+	// 1-indexed with a virtual column 0, following the classic formulation
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	rowOf := make([]int, n+1) // Row assigned to each column, 0 if none
+	way := make([]int, n+1)
+
+	for row := 1; row <= n; row++ {
+		rowOf[0] = row
+		col := 0
+		minSlack := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minSlack {
+			minSlack[j] = math.Inf(1)
+		}
+		for {
+			used[col] = true
+			i := rowOf[col]
+			delta := math.Inf(1)
+			next := 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if slack := cost[i-1][j-1] - u[i] - v[j]; slack < minSlack[j] {
+					minSlack[j] = slack
+					way[j] = col
+				}
+				if minSlack[j] < delta {
+					delta = minSlack[j]
+					next = j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[rowOf[j]] += delta
+					v[j] -= delta
+				} else {
+					minSlack[j] -= delta
+				}
+			}
+			col = next
+			if rowOf[col] == 0 {
+				break
+			}
+		}
+		// Flip the alternating path back to the virtual column
+		for col != 0 {
+			prev := way[col]
+			rowOf[col] = rowOf[prev]
+			col = prev
+		}
+	}
+
+	assignment := make([]int, n)
+	for j := 1; j <= n; j++ {
+		if rowOf[j] > 0 {
+			assignment[rowOf[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
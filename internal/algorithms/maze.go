@@ -0,0 +1,162 @@
+package algorithms
+
+import "math/rand"
+
+// MazeKind selects a maze generation algorithm
+type MazeKind int
+
+const (
+	MazeBacktracker MazeKind = iota // Randomized depth-first search
+	MazePrim                        // Randomized Prim: grows the maze from a random frontier passage
+	MazeKruskal                     // Randomized Kruskal: opens passages in random order unless they close a loop
+)
+
+// MazeKinds lists every maze generator, in menu order
+var MazeKinds = []MazeKind{MazeBacktracker, MazePrim, MazeKruskal}
+
+// String returns a human-readable name for the maze generator
+func (k MazeKind) String() string {
+	switch k {
+	case MazePrim:
+		return "Prim's"
+	case MazeKruskal:
+		return "Kruskal's"
+	}
+	return "Recursive Backtracker"
+}
+
+// mazeRooms describes the rooms of a cols x rows maze grid
+// Rooms sit at odd coordinates; the cell between two neighboring rooms is their passage
+type mazeRooms struct {
+	cols, rooms, across int // Grid width, number of rooms, rooms per row
+	walls               []bool
+}
+
+// cell returns the grid index of a room
+func (m *mazeRooms) cell(room int) int {
+	return (2*(room/m.across)+1)*m.cols + 2*(room%m.across) + 1
+}
+
+// neighbors returns the rooms next to room in the four directions
+func (m *mazeRooms) neighbors(room int) []int {
+	rooms := []int{}
+	x, y := room%m.across, room/m.across
+	if x > 0 {
+		rooms = append(rooms, room-1)
+	}
+	if x+1 < m.across {
+		rooms = append(rooms, room+1)
+	}
+	if y > 0 {
+		rooms = append(rooms, room-m.across)
+	}
+	if room+m.across < m.rooms {
+		rooms = append(rooms, room+m.across)
+	}
+	return rooms
+}
+
+// carve opens room b and the passage between rooms a and b
+func (m *mazeRooms) carve(a, b int) {
+	m.walls[(m.cell(a)+m.cell(b))/2] = false
+	m.walls[m.cell(b)] = false
+}
+
+// GenerateMaze carves a perfect maze, with exactly one path between any two open cells, into a
+// cols x rows grid and returns whether each cell is a wall, row by row
+// The outer border stays solid; an even width or height leaves an extra wall column or row
+func GenerateMaze(kind MazeKind, cols, rows int, r *rand.Rand) []bool {
+	walls := make([]bool, cols*rows)
+	for i := range walls {
+		walls[i] = true
+	}
+	across, down := (cols-1)/2, (rows-1)/2
+	if across < 1 || down < 1 {
+		return walls
+	}
+	m := &mazeRooms{cols: cols, rooms: across * down, across: across, walls: walls}
+
+	switch kind {
+	case MazePrim:
+		primMaze(m, r)
+	case MazeKruskal:
+		kruskalMaze(m, r)
+	default:
+		backtrackerMaze(m, r)
+	}
+	return walls
+}
+
+// backtrackerMaze walks to a random unvisited neighbor, backing up when there is none
+func backtrackerMaze(m *mazeRooms, r *rand.Rand) {
+	visited := make([]bool, m.rooms)
+	visited[0] = true
+	m.walls[m.cell(0)] = false
+	stack := []int{0}
+	for len(stack) > 0 {
+		room := stack[len(stack)-1]
+		unvisited := []int{}
+		for _, next := range m.neighbors(room) {
+			if !visited[next] {
+				unvisited = append(unvisited, next)
+			}
+		}
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := unvisited[r.Intn(len(unvisited))]
+		visited[next] = true
+		m.carve(room, next)
+		stack = append(stack, next)
+	}
+}
+
+// primMaze opens a random passage from the maze to a room outside it until every room is inside
+func primMaze(m *mazeRooms, r *rand.Rand) {
+	inMaze := make([]bool, m.rooms)
+	frontier := [][2]int{}
+	add := func(room int) {
+		inMaze[room] = true
+		m.walls[m.cell(room)] = false
+		for _, next := range m.neighbors(room) {
+			if !inMaze[next] {
+				frontier = append(frontier, [2]int{room, next})
+			}
+		}
+	}
+
+	add(r.Intn(m.rooms))
+	for len(frontier) > 0 {
+		i := r.Intn(len(frontier))
+		passage := frontier[i]
+		frontier[i] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+		if inMaze[passage[1]] {
+			continue
+		}
+		m.carve(passage[0], passage[1])
+		add(passage[1])
+	}
+}
+
+// kruskalMaze opens the passages in random order, skipping those between rooms already connected
+func kruskalMaze(m *mazeRooms, r *rand.Rand) {
+	passages := [][2]int{}
+	for room := 0; room < m.rooms; room++ {
+		m.walls[m.cell(room)] = false
+		for _, next := range m.neighbors(room) {
+			if room < next {
+				passages = append(passages, [2]int{room, next})
+			}
+		}
+	}
+	r.Shuffle(len(passages), func(i, j int) { passages[i], passages[j] = passages[j], passages[i] })
+
+	sets := NewDisjointSet(m.rooms)
+	for _, passage := range passages {
+		if sets.Union(passage[0], passage[1]) {
+			m.carve(passage[0], passage[1])
+		}
+	}
+}
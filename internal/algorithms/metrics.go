@@ -0,0 +1,350 @@
+package algorithms
+
+import "math"
+
+// GraphStats summarizes the shape of a graph
+// Distances count edges and follow edge directions; the other statistics ignore them
+type GraphStats struct {
+	Nodes, Edges int
+	Density      float64 // Edges as a fraction of the most the graph could have
+	DegreeCounts []int   // DegreeCounts[d] is the number of nodes of degree d
+	Diameter     int     // Largest distance between two nodes that reach each other
+	Radius       int     // Smallest eccentricity of a node that reaches every other, -1 if none does
+	Girth        int     // Length of the shortest cycle, 0 if there is none
+	Clustering   float64 // Average local clustering coefficient
+	Components   int     // (Weakly) connected components
+	Connected    bool    // Every node reaches every other; otherwise Diameter only spans pairs that do
+}
+
+// Centrality selects a per-node importance score
+type Centrality int
+
+const (
+	CentralityDegree      Centrality = iota // Number of incident edges
+	CentralityCloseness                     // Inverse average distance to the nodes a node reaches
+	CentralityBetweenness                   // Number of shortest paths between other nodes that pass through
+	CentralityPageRank                      // Stationary probability of a random surfer
+)
+
+// Centralities lists every centrality, in menu order
+var Centralities = []Centrality{CentralityDegree, CentralityCloseness, CentralityBetweenness, CentralityPageRank}
+
+// String returns a human-readable name for the centrality
+func (c Centrality) String() string {
+	switch c {
+	case CentralityCloseness:
+		return "Closeness"
+	case CentralityBetweenness:
+		return "Betweenness"
+	case CentralityPageRank:
+		return "PageRank"
+	}
+	return "Degree"
+}
+
+// PageRankDamping is the probability that the random surfer follows an edge rather than jumping
+const PageRankDamping = 0.85
+
+// hopDistances returns the BFS distance in edges from source to every node, -1 if unreachable
+func hopDistances(neighbors map[int][]int, numNodes, source int) []int {
+	dist := make([]int, numNodes)
+	for v := range dist {
+		dist[v] = -1
+	}
+	dist[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range neighbors[v] {
+			if dist[w] == -1 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return dist
+}
+
+// symmetricAdjacency returns duplicate-free neighbor sets with every edge in both directions
+func symmetricAdjacency(neighbors map[int][]int, numNodes int) []map[int]bool {
+	adjacent := make([]map[int]bool, numNodes)
+	for v := range adjacent {
+		adjacent[v] = map[int]bool{}
+	}
+	for v := 0; v < numNodes; v++ {
+		for _, w := range neighbors[v] {
+			if w != v {
+				adjacent[v][w] = true
+				adjacent[w][v] = true
+			}
+		}
+	}
+	return adjacent
+}
+
+// ComputeGraphStats measures a graph with numEdges edges given by its adjacency lists
+// An undirected graph lists each edge in both directions but counts it once in numEdges
+func ComputeGraphStats(neighbors map[int][]int, numNodes, numEdges int, directed bool) GraphStats {
+	stats := GraphStats{Nodes: numNodes, Edges: numEdges, Connected: true}
+	if numNodes == 0 {
+		return stats
+	}
+	if numNodes > 1 {
+		pairs := float64(numNodes * (numNodes - 1))
+		if directed {
+			stats.Density = float64(numEdges) / pairs
+		} else {
+			stats.Density = 2 * float64(numEdges) / pairs
+		}
+	}
+
+	// Degree distribution, counting in- and out-edges of directed graphs
+	degree := DegreeCentrality(neighbors, numNodes, directed)
+	for _, d := range degree {
+		for int(d) >= len(stats.DegreeCounts) {
+			stats.DegreeCounts = append(stats.DegreeCounts, 0)
+		}
+		stats.DegreeCounts[int(d)]++
+	}
+
+	// Eccentricities and girth from a BFS from every node
+	stats.Radius = -1
+	for s := 0; s < numNodes; s++ {
+		dist := hopDistances(neighbors, numNodes, s)
+		eccentricity, reachesAll := 0, true
+		for _, d := range dist {
+			if d == -1 {
+				reachesAll = false
+			} else if d > eccentricity {
+				eccentricity = d
+			}
+		}
+		if eccentricity > stats.Diameter {
+			stats.Diameter = eccentricity
+		}
+		if !reachesAll {
+			stats.Connected = false
+		} else if stats.Radius == -1 || eccentricity < stats.Radius {
+			stats.Radius = eccentricity
+		}
+		if girth := shortestCycleThrough(neighbors, numNodes, s, directed); girth > 0 && (stats.Girth == 0 || girth < stats.Girth) {
+			stats.Girth = girth
+		}
+	}
+
+	// Clustering and components ignore directions
+	adjacent := symmetricAdjacency(neighbors, numNodes)
+	total := 0.0
+	for v := 0; v < numNodes; v++ {
+		k := len(adjacent[v])
+		if k < 2 {
+			continue
+		}
+		links := 0
+		for a := range adjacent[v] {
+			for b := range adjacent[v] {
+				if a < b && adjacent[a][b] {
+					links++
+				}
+			}
+		}
+		total += float64(links) / float64(k*(k-1)/2)
+	}
+	stats.Clustering = total / float64(numNodes)
+
+	seen := make([]bool, numNodes)
+	for s := 0; s < numNodes; s++ {
+		if seen[s] {
+			continue
+		}
+		stats.Components++
+		seen[s] = true
+		stack := []int{s}
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for w := range adjacent[v] {
+				if !seen[w] {
+					seen[w] = true
+					stack = append(stack, w)
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// shortestCycleThrough returns the length of the shortest cycle a BFS from s closes, 0 if none
+// Directed: an edge back into s; undirected: an edge to a reached node other than the BFS parent
+// The minimum over every s is the girth
+func shortestCycleThrough(neighbors map[int][]int, numNodes, s int, directed bool) int {
+	dist := make([]int, numNodes)
+	parent := make([]int, numNodes)
+	for v := range dist {
+		dist[v], parent[v] = -1, -1
+	}
+	dist[s] = 0
+	best := 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range neighbors[v] {
+			length := 0
+			switch {
+			case w == v:
+				length = 1
+			case dist[w] == -1:
+				dist[w], parent[w] = dist[v]+1, v
+				queue = append(queue, w)
+			case directed && w == s:
+				length = dist[v] + 1
+			case !directed && w != parent[v]:
+				length = dist[v] + dist[w] + 1
+			}
+			if length > 0 && (best == 0 || length < best) {
+				best = length
+			}
+		}
+	}
+	return best
+}
+
+// DegreeCentrality returns the number of edges at every node, in and out for directed graphs
+func DegreeCentrality(neighbors map[int][]int, numNodes int, directed bool) []float64 {
+	degree := make([]float64, numNodes)
+	for v := 0; v < numNodes; v++ {
+		degree[v] += float64(len(neighbors[v]))
+		if directed {
+			for _, w := range neighbors[v] {
+				degree[w]++
+			}
+		}
+	}
+	return degree
+}
+
+// ClosenessCentrality returns, for every node, the number of nodes it reaches divided by their
+// total distance, scaled by the fraction of the graph it reaches (Wasserman-Faust), so nodes in
+// small components do not score highly
+func ClosenessCentrality(neighbors map[int][]int, numNodes int) []float64 {
+	closeness := make([]float64, numNodes)
+	if numNodes < 2 {
+		return closeness
+	}
+	for s := 0; s < numNodes; s++ {
+		reached, total := 0, 0
+		for _, d := range hopDistances(neighbors, numNodes, s) {
+			if d > 0 {
+				reached++
+				total += d
+			}
+		}
+		if total > 0 {
+			closeness[s] = float64(reached) / float64(total) * float64(reached) / float64(numNodes-1)
+		}
+	}
+	return closeness
+}
+
+// BetweennessCentrality - Brandes' algorithm: one BFS per source counts the shortest paths to
+// every node, then dependencies are accumulated back from the farthest nodes
+// Returns the number of shortest paths between other pairs through each node, each pair split
+// evenly among its shortest paths; undirected pairs are counted once
+func BetweennessCentrality(neighbors map[int][]int, numNodes int, directed bool) []float64 {
+	betweenness := make([]float64, numNodes)
+	for s := 0; s < numNodes; s++ {
+		dist := make([]int, numNodes)
+		paths := make([]float64, numNodes)
+		predecessors := make([][]int, numNodes)
+		for v := range dist {
+			dist[v] = -1
+		}
+		dist[s], paths[s] = 0, 1
+		order := []int{}
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			order = append(order, v)
+			for _, w := range neighbors[v] {
+				if dist[w] == -1 {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+				if dist[w] == dist[v]+1 {
+					paths[w] += paths[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		dependency := make([]float64, numNodes)
+		for i := len(order) - 1; i > 0; i-- {
+			w := order[i]
+			for _, v := range predecessors[w] {
+				dependency[v] += paths[v] / paths[w] * (1 + dependency[w])
+			}
+			betweenness[w] += dependency[w]
+		}
+	}
+	if !directed {
+		for v := range betweenness {
+			betweenness[v] /= 2
+		}
+	}
+	return betweenness
+}
+
+// PageRank - Power iteration of the random surfer who follows a random out-edge with probability
+// PageRankDamping and otherwise jumps to a random node; nodes without out-edges jump always
+// The scores sum to 1
+func PageRank(neighbors map[int][]int, numNodes int) []float64 {
+	if numNodes == 0 {
+		return nil
+	}
+	n := float64(numNodes)
+	rank := make([]float64, numNodes)
+	for v := range rank {
+		rank[v] = 1 / n
+	}
+	for iteration := 0; iteration < 100; iteration++ {
+		next := make([]float64, numNodes)
+		dangling := 0.0
+		for v := 0; v < numNodes; v++ {
+			out := neighbors[v]
+			if len(out) == 0 {
+				dangling += rank[v]
+				continue
+			}
+			share := rank[v] / float64(len(out))
+			for _, w := range out {
+				next[w] += PageRankDamping * share
+			}
+		}
+		change := 0.0
+		for v := range next {
+			next[v] += (1-PageRankDamping)/n + PageRankDamping*dangling/n
+			change += math.Abs(next[v] - rank[v])
+		}
+		rank = next
+		if change < 1e-10 {
+			break
+		}
+	}
+	return rank
+}
+
+// CentralityScores returns the chosen centrality of every node
+func CentralityScores(c Centrality, neighbors map[int][]int, numNodes int, directed bool) []float64 {
+	switch c {
+	case CentralityCloseness:
+		return ClosenessCentrality(neighbors, numNodes)
+	case CentralityBetweenness:
+		return BetweennessCentrality(neighbors, numNodes, directed)
+	case CentralityPageRank:
+		return PageRank(neighbors, numNodes)
+	}
+	return DegreeCentrality(neighbors, numNodes, directed)
+}
@@ -0,0 +1,519 @@
+package algorithms
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// DisjointSet is a union-find structure with union by rank and path compression
+type DisjointSet struct {
+	parent []int
+	rank   []int
+}
+
+// NewDisjointSet creates n singleton sets
+func NewDisjointSet(n int) *DisjointSet {
+	ds := &DisjointSet{parent: make([]int, n), rank: make([]int, n)}
+	for i := range ds.parent {
+		ds.parent[i] = i
+	}
+	return ds
+}
+
+// Find returns the representative of x's set
+func (ds *DisjointSet) Find(x int) int {
+	for ds.parent[x] != x {
+		ds.parent[x] = ds.parent[ds.parent[x]] // Path halving
+		x = ds.parent[x]
+	}
+	return x
+}
+
+// Union merges the sets of x and y
+// Returns false if they were already in the same set
+func (ds *DisjointSet) Union(x, y int) bool {
+	px, py := ds.Find(x), ds.Find(y)
+	if px == py {
+		return false
+	}
+	if ds.rank[px] < ds.rank[py] {
+		px, py = py, px
+	}
+	ds.parent[py] = px
+	if ds.rank[px] == ds.rank[py] {
+		ds.rank[px]++
+	}
+	return true
+}
+
+// Size returns the number of elements
+func (ds *DisjointSet) Size() int {
+	return len(ds.parent)
+}
+
+// Components returns the sets with more than one element, each sorted,
+// ordered by their smallest element
+func (ds *DisjointSet) Components() [][]int {
+	byRoot := make(map[int][]int)
+	roots := []int{}
+	for v := range ds.parent {
+		root := ds.Find(v)
+		if _, seen := byRoot[root]; !seen {
+			roots = append(roots, root)
+		}
+		byRoot[root] = append(byRoot[root], v)
+	}
+
+	components := [][]int{}
+	for _, root := range roots {
+		if len(byRoot[root]) > 1 {
+			components = append(components, byRoot[root])
+		}
+	}
+	return components
+}
+
+// EdgeDecision records what an MST algorithm decided about an edge
+type EdgeDecision int
+
+const (
+	EdgePending  EdgeDecision = iota // Not considered yet
+	EdgeAccepted                     // Added to the spanning tree
+	EdgeRejected                     // Would close a cycle
+)
+
+// String returns a human-readable name for the decision
+func (d EdgeDecision) String() string {
+	switch d {
+	case EdgePending:
+		return "pending"
+	case EdgeAccepted:
+		return "accepted"
+	case EdgeRejected:
+		return "rejected (cycle)"
+	}
+	return "unknown"
+}
+
+// KruskalStepper runs Kruskal's algorithm one edge at a time
+type KruskalStepper struct {
+	Sorted     []Edge         // Copy of the input edges sorted by weight
+	Decisions  []EdgeDecision // Decision for each edge in Sorted
+	Index      int            // Next edge in Sorted to consider
+	MST        []Edge
+	Sets       *DisjointSet
+	Operations int // Sort comparisons plus union attempts
+	numNodes   int
+}
+
+// NewKruskalStepper sorts a copy of the edges; the caller's slice is left untouched
+func NewKruskalStepper(edges []Edge, numNodes int) *KruskalStepper {
+	sorted := make([]Edge, len(edges))
+	copy(sorted, edges)
+	comparisons := 0
+	sort.SliceStable(sorted, func(i, j int) bool {
+		comparisons++
+		return sorted[i].Weight < sorted[j].Weight
+	})
+
+	return &KruskalStepper{
+		Sorted:     sorted,
+		Decisions:  make([]EdgeDecision, len(sorted)),
+		MST:        []Edge{},
+		Sets:       NewDisjointSet(numNodes),
+		Operations: comparisons,
+		numNodes:   numNodes,
+	}
+}
+
+// Done reports whether the spanning tree is complete or every edge has been considered
+func (k *KruskalStepper) Done() bool {
+	return k.Index >= len(k.Sorted) || len(k.MST) >= k.numNodes-1
+}
+
+// Step considers the next cheapest edge, accepting it if it joins two different components
+// Returns true when the algorithm has finished
+func (k *KruskalStepper) Step() bool {
+	if k.Done() {
+		return true
+	}
+
+	edge := k.Sorted[k.Index]
+	k.Operations++
+	if k.Sets.Union(edge.From, edge.To) {
+		k.Decisions[k.Index] = EdgeAccepted
+		k.MST = append(k.MST, edge)
+	} else {
+		k.Decisions[k.Index] = EdgeRejected
+	}
+	k.Index++
+
+	return k.Done()
+}
+
+// edgeHeap is a min-heap of edges ordered by weight
+type edgeHeap []Edge
+
+func (h edgeHeap) Len() int            { return len(h) }
+func (h edgeHeap) Less(i, j int) bool  { return h[i].Weight < h[j].Weight }
+func (h edgeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *edgeHeap) Push(x interface{}) { *h = append(*h, x.(Edge)) }
+
+func (h *edgeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	edge := old[n-1]
+	*h = old[:n-1]
+	return edge
+}
+
+// PrimStepper runs Prim's algorithm one node at a time
+// The heap holds whole edges, so every tree edge is exactly the edge that was popped
+// When the tree cannot grow any further, a new tree is started from the lowest unreached node,
+// producing a minimum spanning forest for disconnected graphs
+type PrimStepper struct {
+	Root       int
+	TreeOf     []int // Index of the tree each node belongs to, -1 while outside every tree
+	Trees      int   // Number of trees started so far
+	MST        []Edge
+	Current    int // Node added by the most recent step
+	LastEdge   Edge
+	Operations int // Heap pushes and pops
+	graph      map[int][]Edge
+	frontier   *edgeHeap
+	added      int
+}
+
+// NewPrimStepper prepares Prim's algorithm with the given root already in the tree
+func NewPrimStepper(neighbors map[int][]Edge, root, numNodes int) *PrimStepper {
+	p := &PrimStepper{
+		Root:     root,
+		TreeOf:   make([]int, numNodes),
+		MST:      []Edge{},
+		Current:  -1,
+		graph:    neighbors,
+		frontier: &edgeHeap{},
+	}
+	for i := range p.TreeOf {
+		p.TreeOf[i] = -1
+	}
+	if root >= 0 && root < numNodes {
+		p.startTree(root)
+	}
+	return p
+}
+
+// Done reports whether every node belongs to a tree
+func (p *PrimStepper) Done() bool {
+	return p.added == len(p.TreeOf)
+}
+
+// Step adds the next node: the far end of the lightest edge crossing the cut,
+// or the root of a new tree if no edge crosses it
+// Returns true once every node belongs to a tree
+func (p *PrimStepper) Step() bool {
+	if p.Done() {
+		return true
+	}
+
+	if edge, ok := p.Peek(); ok {
+		heap.Pop(p.frontier)
+		p.Operations++
+		p.MST = append(p.MST, edge)
+		p.LastEdge = edge
+		p.add(edge.To, p.TreeOf[edge.From])
+		return p.Done()
+	}
+
+	// The current tree is complete; continue the forest from the lowest unreached node
+	for v, tree := range p.TreeOf {
+		if tree == -1 {
+			p.startTree(v)
+			break
+		}
+	}
+	return p.Done()
+}
+
+// Peek returns the lightest edge crossing the cut, which the next step will add
+// Returns false if no edge crosses the cut
+func (p *PrimStepper) Peek() (Edge, bool) {
+	for p.frontier.Len() > 0 {
+		edge := (*p.frontier)[0]
+		if p.TreeOf[edge.To] == -1 {
+			return edge, true
+		}
+		heap.Pop(p.frontier) // Both ends are in the tree now
+		p.Operations++
+	}
+	return Edge{}, false
+}
+
+// CutEdges returns every edge from a tree node to a node outside all trees
+func (p *PrimStepper) CutEdges() []Edge {
+	cut := []Edge{}
+	for v, tree := range p.TreeOf {
+		if tree == -1 {
+			continue
+		}
+		for _, edge := range p.graph[v] {
+			if edge.To >= 0 && edge.To < len(p.TreeOf) && p.TreeOf[edge.To] == -1 {
+				cut = append(cut, edge)
+			}
+		}
+	}
+	return cut
+}
+
+// startTree begins a new tree at v
+func (p *PrimStepper) startTree(v int) {
+	p.Trees++
+	p.LastEdge = Edge{From: -1, To: v}
+	p.add(v, p.Trees-1)
+}
+
+// add puts v into the given tree and pushes its edges to nodes outside every tree
+func (p *PrimStepper) add(v, tree int) {
+	p.TreeOf[v] = tree
+	p.Current = v
+	p.added++
+	for _, edge := range p.graph[v] {
+		if edge.To >= 0 && edge.To < len(p.TreeOf) && p.TreeOf[edge.To] == -1 {
+			heap.Push(p.frontier, edge)
+			p.Operations++
+		}
+	}
+}
+
+// BoruvkaStepper runs Borůvka's algorithm one phase at a time
+// In every phase each component picks its cheapest outgoing edge and all of them are added together
+type BoruvkaStepper struct {
+	Edges      []Edge // Copy of the input edges
+	Sets       *DisjointSet
+	MST        []Edge
+	Phase      int   // Number of completed phases
+	Chosen     []int // Indices into Edges picked by the most recent phase
+	Operations int   // Edge inspections
+	done       bool
+}
+
+// NewBoruvkaStepper prepares Borůvka's algorithm; the caller's slice is left untouched
+func NewBoruvkaStepper(edges []Edge, numNodes int) *BoruvkaStepper {
+	copied := make([]Edge, len(edges))
+	copy(copied, edges)
+	return &BoruvkaStepper{
+		Edges: copied,
+		Sets:  NewDisjointSet(numNodes),
+		MST:   []Edge{},
+		done:  numNodes <= 1,
+	}
+}
+
+// Done reports whether no component has an outgoing edge left
+func (b *BoruvkaStepper) Done() bool {
+	return b.done
+}
+
+// Step runs one phase
+// Returns true when no further edge joins two components
+func (b *BoruvkaStepper) Step() bool {
+	if b.done {
+		return true
+	}
+
+	// Cheapest outgoing edge per component; ties go to the lower index so the choice is consistent
+	cheapest := make(map[int]int)
+	lighter := func(i, j int) bool {
+		if b.Edges[i].Weight != b.Edges[j].Weight {
+			return b.Edges[i].Weight < b.Edges[j].Weight
+		}
+		return i < j
+	}
+	for i, edge := range b.Edges {
+		b.Operations++
+		ru, rv := b.Sets.Find(edge.From), b.Sets.Find(edge.To)
+		if ru == rv {
+			continue
+		}
+		for _, root := range []int{ru, rv} {
+			if best, ok := cheapest[root]; !ok || lighter(i, best) {
+				cheapest[root] = i
+			}
+		}
+	}
+
+	if len(cheapest) == 0 {
+		b.done = true
+		b.Chosen = nil
+		return true
+	}
+
+	// Add the chosen edges in index order; an edge picked by both of its components is added once
+	b.Chosen = b.Chosen[:0]
+	picked := make(map[int]bool)
+	for _, i := range cheapest {
+		picked[i] = true
+	}
+	for i := range b.Edges {
+		if picked[i] && b.Sets.Union(b.Edges[i].From, b.Edges[i].To) {
+			b.MST = append(b.MST, b.Edges[i])
+			b.Chosen = append(b.Chosen, i)
+		}
+	}
+	b.Phase++
+
+	return b.done
+}
+
+// Boruvka - Borůvka's minimum spanning tree (forest) algorithm
+func Boruvka(edges []Edge, numNodes int) []Edge {
+	b := NewBoruvkaStepper(edges, numNodes)
+	for !b.Step() {
+	}
+	return b.MST
+}
+
+// ReverseDeleteStepper runs the reverse-delete algorithm one edge at a time:
+// edges are considered from heaviest to lightest and deleted if the graph stays connected without them
+type ReverseDeleteStepper struct {
+	Sorted     []Edge         // Copy of the input edges sorted by decreasing weight
+	Decisions  []EdgeDecision // EdgeAccepted for kept edges, EdgeRejected for deleted ones (they lay on a cycle)
+	Index      int            // Next edge in Sorted to consider
+	Operations int            // Edge traversals during connectivity checks
+	numNodes   int
+}
+
+// NewReverseDeleteStepper sorts a copy of the edges; the caller's slice is left untouched
+func NewReverseDeleteStepper(edges []Edge, numNodes int) *ReverseDeleteStepper {
+	sorted := make([]Edge, len(edges))
+	copy(sorted, edges)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Weight > sorted[j].Weight
+	})
+
+	return &ReverseDeleteStepper{
+		Sorted:    sorted,
+		Decisions: make([]EdgeDecision, len(sorted)),
+		numNodes:  numNodes,
+	}
+}
+
+// Done reports whether every edge has been considered
+func (r *ReverseDeleteStepper) Done() bool {
+	return r.Index >= len(r.Sorted)
+}
+
+// Step considers the heaviest remaining edge
+// Returns true when every edge has been considered
+func (r *ReverseDeleteStepper) Step() bool {
+	if r.Done() {
+		return true
+	}
+
+	edge := r.Sorted[r.Index]
+	if r.connectedWithout(r.Index, edge.From, edge.To) {
+		r.Decisions[r.Index] = EdgeRejected
+	} else {
+		r.Decisions[r.Index] = EdgeAccepted
+	}
+	r.Index++
+
+	return r.Done()
+}
+
+// connectedWithout reports whether from and to are connected by the edges that have not been deleted,
+// ignoring the edge at index skip
+func (r *ReverseDeleteStepper) connectedWithout(skip, from, to int) bool {
+	adjacency := make(map[int][]int)
+	for i, edge := range r.Sorted {
+		if i == skip || r.Decisions[i] == EdgeRejected {
+			continue
+		}
+		adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+		adjacency[edge.To] = append(adjacency[edge.To], edge.From)
+	}
+
+	visited := map[int]bool{from: true}
+	queue := []int{from}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if v == to {
+			return true
+		}
+		for _, nb := range adjacency[v] {
+			r.Operations++
+			if !visited[nb] {
+				visited[nb] = true
+				queue = append(queue, nb)
+			}
+		}
+	}
+	return false
+}
+
+// MST returns the edges kept so far together with the ones not considered yet,
+// which is the spanning tree (forest) once the algorithm is done
+func (r *ReverseDeleteStepper) MST() []Edge {
+	kept := []Edge{}
+	for i, edge := range r.Sorted {
+		if r.Decisions[i] != EdgeRejected {
+			kept = append(kept, edge)
+		}
+	}
+	return kept
+}
+
+// ReverseDelete - Reverse-delete minimum spanning tree (forest) algorithm
+func ReverseDelete(edges []Edge, numNodes int) []Edge {
+	r := NewReverseDeleteStepper(edges, numNodes)
+	for !r.Step() {
+	}
+	return r.MST()
+}
+
+// MSTStats summarizes one MST algorithm's result on a graph
+type MSTStats struct {
+	Algorithm  string
+	Edges      []Edge
+	Weight     float64
+	Operations int
+	Counted    string // What Operations counts for this algorithm
+}
+
+// CompareMST runs every MST algorithm on the same undirected edge list
+// Prim is grown from root; every result is a minimum spanning forest, but the edge sets can differ on ties
+func CompareMST(edges []Edge, numNodes, root int) []MSTStats {
+	neighbors := make(map[int][]Edge, numNodes)
+	for _, edge := range edges {
+		neighbors[edge.From] = append(neighbors[edge.From], edge)
+		neighbors[edge.To] = append(neighbors[edge.To], Edge{From: edge.To, To: edge.From, Weight: edge.Weight})
+	}
+
+	kruskal := NewKruskalStepper(edges, numNodes)
+	for !kruskal.Step() {
+	}
+	prim := NewPrimStepper(neighbors, root, numNodes)
+	for !prim.Step() {
+	}
+	boruvka := NewBoruvkaStepper(edges, numNodes)
+	for !boruvka.Step() {
+	}
+	reverse := NewReverseDeleteStepper(edges, numNodes)
+	for !reverse.Step() {
+	}
+
+	stats := func(name string, mst []Edge, operations int, counted string) MSTStats {
+		total := 0.0
+		for _, edge := range mst {
+			total += edge.Weight
+		}
+		return MSTStats{Algorithm: name, Edges: mst, Weight: total, Operations: operations, Counted: counted}
+	}
+	return []MSTStats{
+		stats("Kruskal", kruskal.MST, kruskal.Operations, "comparisons + unions"),
+		stats("Prim", prim.MST, prim.Operations, "heap operations"),
+		stats("Boruvka", boruvka.MST, boruvka.Operations, "edge inspections"),
+		stats("Reverse-delete", reverse.MST(), reverse.Operations, "edge traversals"),
+	}
+}
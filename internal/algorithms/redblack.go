@@ -0,0 +1,321 @@
+package algorithms
+
+import "fmt"
+
+// RBNode represents a node in a red-black tree
+type RBNode struct {
+	Value    int
+	Red      bool
+	Left     *RBNode
+	Right    *RBNode
+	Parent   *RBNode
+	Position TreePosition
+}
+
+// RedBlackTree is a binary search tree balanced by coloring its nodes: the root is black, a red
+// node has no red child, and every path down from a node passes the same number of black nodes
+// The longest path is thus at most twice the shortest
+type RedBlackTree struct {
+	Root  *RBNode
+	steps []TreeStep
+}
+
+// NewRedBlackTree creates a new empty red-black tree
+func NewRedBlackTree() *RedBlackTree {
+	return &RedBlackTree{}
+}
+
+// Keys returns the node's value
+func (n *RBNode) Keys() []int {
+	return []int{n.Value}
+}
+
+// Children returns the left and right child, nil where one is missing
+func (n *RBNode) Children() []TreeNode {
+	children := make([]TreeNode, 2)
+	if n.Left != nil {
+		children[0] = n.Left
+	}
+	if n.Right != nil {
+		children[1] = n.Right
+	}
+	return children
+}
+
+// Pos returns where the node is drawn
+func (n *RBNode) Pos() *TreePosition {
+	return &n.Position
+}
+
+// Annotation names the node's color
+func (n *RBNode) Annotation() string {
+	if n.Red {
+		return "red"
+	}
+	return "black"
+}
+
+// Copy returns a deep copy of the subtree rooted at n, or nil for a nil node
+func (n *RBNode) Copy() *RBNode {
+	if n == nil {
+		return nil
+	}
+	c := &RBNode{Value: n.Value, Red: n.Red, Position: n.Position}
+	if c.Left = n.Left.Copy(); c.Left != nil {
+		c.Left.Parent = c
+	}
+	if c.Right = n.Right.Copy(); c.Right != nil {
+		c.Right.Parent = c
+	}
+	return c
+}
+
+// child returns the left or the right child
+func (n *RBNode) child(left bool) *RBNode {
+	if left {
+		return n.Left
+	}
+	return n.Right
+}
+
+// isRed reports whether a node is red; missing children count as black
+func isRed(n *RBNode) bool {
+	return n != nil && n.Red
+}
+
+// TreeRoot returns the root, or nil for an empty tree
+func (t *RedBlackTree) TreeRoot() TreeNode {
+	if t.Root == nil {
+		return nil
+	}
+	return t.Root
+}
+
+// Steps returns the steps of the most recent operation
+func (t *RedBlackTree) Steps() []TreeStep {
+	return t.steps
+}
+
+// record appends a step with a copy of the tree as it is now
+func (t *RedBlackTree) record(kind StepKind, key int, format string, args ...interface{}) {
+	step := TreeStep{Kind: kind, Key: key, Text: fmt.Sprintf(format, args...)}
+	if t.Root != nil {
+		step.Tree = t.Root.Copy()
+	}
+	t.steps = append(t.steps, step)
+}
+
+// replace puts node where old is under old's parent
+func (t *RedBlackTree) replace(old, node *RBNode) {
+	if node != nil {
+		node.Parent = old.Parent
+	}
+	switch {
+	case old.Parent == nil:
+		t.Root = node
+	case old.Parent.Left == old:
+		old.Parent.Left = node
+	default:
+		old.Parent.Right = node
+	}
+}
+
+// rotate lifts the right child of n into its place for a left rotation, the left child for a
+// right one
+func (t *RedBlackTree) rotate(n *RBNode, left bool) {
+	var top *RBNode
+	if left {
+		top = n.Right
+		n.Right = top.Left
+		if top.Left != nil {
+			top.Left.Parent = n
+		}
+		t.replace(n, top)
+		top.Left = n
+	} else {
+		top = n.Left
+		n.Left = top.Right
+		if top.Right != nil {
+			top.Right.Parent = n
+		}
+		t.replace(n, top)
+		top.Right = n
+	}
+	n.Parent = top
+}
+
+// find walks down from the root towards value, recording each comparison
+// Returns the node holding value, or nil and the last node visited
+func (t *RedBlackTree) find(value int) (found, last *RBNode) {
+	node := t.Root
+	for node != nil {
+		if value == node.Value {
+			t.record(StepFound, value, "Found %d", value)
+			return node, node
+		}
+		t.record(StepVisit, node.Value, "%s", compareText(value, node.Value))
+		last = node
+		node = node.child(value < node.Value)
+	}
+	return nil, last
+}
+
+// Insert adds a value as a red leaf, then recolors and rotates until no red node has a red
+// parent; duplicate values are not inserted
+func (t *RedBlackTree) Insert(value int) {
+	t.steps = nil
+	found, parent := t.find(value)
+	if found != nil {
+		return
+	}
+
+	node := &RBNode{Value: value, Red: true, Parent: parent}
+	switch {
+	case parent == nil:
+		t.Root = node
+	case value < parent.Value:
+		parent.Left = node
+	default:
+		parent.Right = node
+	}
+	t.record(StepCreate, value, "Attach %d as a red leaf", value)
+
+	for isRed(node.Parent) {
+		// A red parent is never the root, so the grandparent exists
+		parent := node.Parent
+		grandparent := parent.Parent
+		t.record(StepViolation, node.Value, "%d and its parent %d are both red", node.Value, parent.Value)
+
+		left := parent == grandparent.Left
+		uncle := grandparent.child(!left)
+		if isRed(uncle) {
+			parent.Red, uncle.Red, grandparent.Red = false, false, true
+			t.record(StepUpdate, grandparent.Value, "Uncle %d is red: color %d and %d black and %d red",
+				uncle.Value, parent.Value, uncle.Value, grandparent.Value)
+			node = grandparent
+			continue
+		}
+
+		// A black uncle: an inner child first turns into an outer one
+		if node == parent.child(!left) {
+			t.rotate(parent, left)
+			t.record(StepRestructure, node.Value, "Uncle is black and %d is an inner child: rotate %s at %d",
+				node.Value, sideName(left), parent.Value)
+			node, parent = parent, node
+		}
+		parent.Red, grandparent.Red = false, true
+		t.rotate(grandparent, !left)
+		t.record(StepRestructure, parent.Value, "Uncle is black: color %d black and %d red, then rotate %s at %d",
+			parent.Value, grandparent.Value, sideName(!left), grandparent.Value)
+	}
+
+	if t.Root.Red {
+		t.Root.Red = false
+		t.record(StepUpdate, t.Root.Value, "Color the root %d black", t.Root.Value)
+	}
+}
+
+// Delete removes a value from the tree
+// A node with two children takes the value of its in-order successor, whose node is removed
+// instead; removing a black node leaves one path short of a black node, which is repaired by
+// recoloring and rotating from there up
+func (t *RedBlackTree) Delete(value int) {
+	t.steps = nil
+	node, _ := t.find(value)
+	if node == nil {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+		return
+	}
+
+	if node.Left != nil && node.Right != nil {
+		successor := node.Right
+		for successor.Left != nil {
+			successor = successor.Left
+		}
+		node.Value = successor.Value
+		node = successor
+	}
+
+	// node now has at most one child, which moves up into its place
+	child := node.Left
+	if child == nil {
+		child = node.Right
+	}
+	parent := node.Parent
+	t.replace(node, child)
+	if node.Value != value {
+		t.record(StepCreate, node.Value, "%d has two children: its in-order successor %d takes its place", value, node.Value)
+	} else {
+		t.record(StepRemove, value, "Remove %d; its child, if any, moves up", value)
+	}
+
+	switch {
+	case node.Red:
+		return // Every path keeps its black nodes
+	case isRed(child):
+		child.Red = false
+		t.record(StepUpdate, child.Value, "Color %d black to replace the removed black node", child.Value)
+		return
+	case parent != nil:
+		t.record(StepViolation, parent.Value, "Paths through the %s of %d are a black node short",
+			sideName(parent.Left == child), parent.Value)
+	}
+	t.deleteFixup(child, parent)
+}
+
+// deleteFixup repairs the paths through x, which are one black node short
+// x may be a missing child, so its parent is passed along
+func (t *RedBlackTree) deleteFixup(x, parent *RBNode) {
+	for x != t.Root && !isRed(x) {
+		// The sibling exists: its side has at least one black node more than x's
+		left := x == parent.Left
+		sibling := parent.child(!left)
+
+		if isRed(sibling) {
+			sibling.Red, parent.Red = false, true
+			t.rotate(parent, left)
+			t.record(StepRestructure, sibling.Value, "Sibling %d is red: swap its color with %d and rotate %s at %d",
+				sibling.Value, parent.Value, sideName(left), parent.Value)
+			sibling = parent.child(!left)
+		}
+
+		if !isRed(sibling.Left) && !isRed(sibling.Right) {
+			sibling.Red = true
+			t.record(StepUpdate, sibling.Value, "Sibling %d has no red child: color it red, moving the shortage up to %d",
+				sibling.Value, parent.Value)
+			x, parent = parent, parent.Parent
+			continue
+		}
+
+		if !isRed(sibling.child(!left)) {
+			inner := sibling.child(left)
+			inner.Red, sibling.Red = false, true
+			t.rotate(sibling, !left)
+			t.record(StepRestructure, inner.Value, "Sibling %d has a red inner child %d: swap their colors and rotate %s at %d",
+				sibling.Value, inner.Value, sideName(!left), sibling.Value)
+			sibling = inner
+		}
+
+		sibling.Red, parent.Red = parent.Red, false
+		sibling.child(!left).Red = false
+		t.rotate(parent, left)
+		t.record(StepRestructure, sibling.Value, "Sibling %d has a red outer child: recolor and rotate %s at %d",
+			sibling.Value, sideName(left), parent.Value)
+		x = t.Root
+	}
+
+	if x != nil && x.Red {
+		x.Red = false
+		t.record(StepUpdate, x.Value, "Color %d black", x.Value)
+	}
+}
+
+// Contains reports whether the tree holds value, recording the search
+func (t *RedBlackTree) Contains(value int) bool {
+	t.steps = nil
+	node, _ := t.find(value)
+	if node == nil {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+	}
+	return node != nil
+}
@@ -0,0 +1,151 @@
+package algorithms
+
+// TarjanAction is the kind of event produced by one step of Tarjan's algorithm
+type TarjanAction int
+
+const (
+	TarjanNone   TarjanAction = iota
+	TarjanVisit               // A node was given its index and pushed (Current)
+	TarjanEdge                // An edge to a node on the stack was examined (LastEdge)
+	TarjanReturn              // A node finished and passed its lowlink to its caller (Current)
+	TarjanEmit                // A node finished as the root of a component, which was popped (Current)
+)
+
+// TarjanStepper runs Tarjan's strongly connected components algorithm iteratively,
+// one visit, edge or return per step, exposing the index and lowlink of every node
+type TarjanStepper struct {
+	Index     []int  // Visit order of each node, -1 while unvisited
+	Lowlink   []int  // Smallest index reachable through the node's DFS subtree and one stack edge
+	OnStack   []bool // Whether each node is on the component stack
+	Stack     []int  // Component stack, top last
+	CallStack []int  // Simulated recursion stack, the active node is last
+	SCCs      [][]int
+	Component []int // Position in SCCs of each node's component, -1 until emitted
+	Current   int   // Node visited, returned from or emitted by the last step, -1 otherwise
+	Action    TarjanAction
+	LastEdge  [2]int // Edge examined by the last step (valid when Action is TarjanEdge)
+	Counter   int    // Next index to hand out
+	graph     map[int][]int
+	nextEdge  []int // Index of the next neighbor to examine for each node
+	nextRoot  int
+}
+
+// NewTarjanStepper prepares Tarjan's algorithm, starting new searches from undiscovered nodes in index order
+func NewTarjanStepper(neighbors map[int][]int, numNodes int) *TarjanStepper {
+	t := &TarjanStepper{
+		Index:     make([]int, numNodes),
+		Lowlink:   make([]int, numNodes),
+		OnStack:   make([]bool, numNodes),
+		SCCs:      [][]int{},
+		Component: make([]int, numNodes),
+		Current:   -1,
+		graph:     neighbors,
+		nextEdge:  make([]int, numNodes),
+	}
+	for i := 0; i < numNodes; i++ {
+		t.Index[i] = -1
+		t.Component[i] = -1
+	}
+	return t
+}
+
+// Done reports whether every node has been assigned to a component
+func (t *TarjanStepper) Done() bool {
+	return len(t.CallStack) == 0 && t.nextRoot == len(t.Index)
+}
+
+// Step performs the next visit, stack-edge examination or return
+// Edges to nodes in already emitted components are skipped without a step of their own
+// Returns true once every component has been emitted
+func (t *TarjanStepper) Step() bool {
+	t.Current = -1
+	t.Action = TarjanNone
+
+	for !t.Done() {
+		if len(t.CallStack) == 0 {
+			root := t.nextRoot
+			t.nextRoot++
+			if t.Index[root] == -1 {
+				t.visit(root)
+				break
+			}
+			continue
+		}
+
+		v := t.CallStack[len(t.CallStack)-1]
+		if t.nextEdge[v] < len(t.graph[v]) {
+			w := t.graph[v][t.nextEdge[v]]
+			t.nextEdge[v]++
+			if t.Index[w] == -1 {
+				t.visit(w)
+				break
+			}
+			if !t.OnStack[w] {
+				continue // w belongs to a finished component
+			}
+			if t.Index[w] < t.Lowlink[v] {
+				t.Lowlink[v] = t.Index[w]
+			}
+			t.LastEdge = [2]int{v, w}
+			t.Action = TarjanEdge
+			break
+		}
+
+		// Every edge of v has been examined: return from v
+		t.CallStack = t.CallStack[:len(t.CallStack)-1]
+		if len(t.CallStack) > 0 {
+			caller := t.CallStack[len(t.CallStack)-1]
+			if t.Lowlink[v] < t.Lowlink[caller] {
+				t.Lowlink[caller] = t.Lowlink[v]
+			}
+		}
+		t.Current = v
+		t.Action = TarjanReturn
+		if t.Lowlink[v] == t.Index[v] {
+			t.emit(v)
+		}
+		break
+	}
+	t.skipVisitedRoots()
+	return t.Done()
+}
+
+// skipVisitedRoots moves past roots discovered by earlier searches, so Done turns true
+// as soon as the last component is emitted
+func (t *TarjanStepper) skipVisitedRoots() {
+	if len(t.CallStack) > 0 {
+		return
+	}
+	for t.nextRoot < len(t.Index) && t.Index[t.nextRoot] != -1 {
+		t.nextRoot++
+	}
+}
+
+// visit gives v the next index and pushes it on both stacks
+func (t *TarjanStepper) visit(v int) {
+	t.Index[v] = t.Counter
+	t.Lowlink[v] = t.Counter
+	t.Counter++
+	t.Stack = append(t.Stack, v)
+	t.OnStack[v] = true
+	t.CallStack = append(t.CallStack, v)
+	t.Current = v
+	t.Action = TarjanVisit
+}
+
+// emit pops the component rooted at v off the stack
+func (t *TarjanStepper) emit(v int) {
+	scc := []int{}
+	for {
+		w := t.Stack[len(t.Stack)-1]
+		t.Stack = t.Stack[:len(t.Stack)-1]
+		t.OnStack[w] = false
+		t.Component[w] = len(t.SCCs)
+		scc = append(scc, w)
+		if w == v {
+			break
+		}
+	}
+	t.SCCs = append(t.SCCs, scc)
+	t.Action = TarjanEmit
+}
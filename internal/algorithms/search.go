@@ -0,0 +1,524 @@
+package algorithms
+
+import (
+	"container/heap"
+	"math"
+)
+
+// BidirectionalBFSStepper runs BFS from a source and a target at the same time,
+// one node expansion per step, until the two frontiers meet
+type BidirectionalBFSStepper struct {
+	Source, Target int
+	Dist           [2]map[int]int // Dist[0] from the source, Dist[1] from the target
+	Parent         [2]map[int]int
+	Frontier       [2][]int // Current level of each side, expanded front to back
+	Next           [2][]int // Nodes discovered for the following level of each side
+	Side           int      // Side whose frontier is being expanded (0 forward, 1 backward)
+	Current        int      // Node expanded by the most recent step, -1 if none
+	Meeting        int      // Node where the best connection was found, -1 if none
+	adjacency      [2]map[int][]int
+	best           int
+	done           bool
+}
+
+// NewBidirectionalBFSStepper prepares a bidirectional search from source to target
+// The backward search follows edges in reverse, so directed graphs are supported
+func NewBidirectionalBFSStepper(neighbors map[int][]int, source, target int) *BidirectionalBFSStepper {
+	reversed := make(map[int][]int, len(neighbors))
+	for from, tos := range neighbors {
+		for _, to := range tos {
+			reversed[to] = append(reversed[to], from)
+		}
+	}
+
+	b := &BidirectionalBFSStepper{
+		Source:    source,
+		Target:    target,
+		Dist:      [2]map[int]int{{source: 0}, {target: 0}},
+		Parent:    [2]map[int]int{{}, {}},
+		Frontier:  [2][]int{{source}, {target}},
+		Current:   -1,
+		Meeting:   -1,
+		adjacency: [2]map[int][]int{neighbors, reversed},
+		best:      math.MaxInt,
+	}
+	if source == target {
+		b.Meeting = source
+		b.done = true
+	}
+	return b
+}
+
+// Done reports whether the search has finished
+func (b *BidirectionalBFSStepper) Done() bool {
+	return b.done
+}
+
+// Step expands one node of the active frontier
+// Returns true when the frontiers have met or one side has run out of nodes
+func (b *BidirectionalBFSStepper) Step() bool {
+	b.Current = -1
+	if b.done {
+		return true
+	}
+
+	side, other := b.Side, 1-b.Side
+	node := b.Frontier[side][0]
+	b.Frontier[side] = b.Frontier[side][1:]
+	b.Current = node
+
+	for _, nb := range b.adjacency[side][node] {
+		if _, seen := b.Dist[side][nb]; seen {
+			continue
+		}
+		b.Dist[side][nb] = b.Dist[side][node] + 1
+		b.Parent[side][nb] = node
+		b.Next[side] = append(b.Next[side], nb)
+
+		if d, seen := b.Dist[other][nb]; seen && b.Dist[side][nb]+d < b.best {
+			b.best = b.Dist[side][nb] + d
+			b.Meeting = nb
+		}
+	}
+
+	// Only stop at a level boundary, so every connection of equal length is considered
+	if len(b.Frontier[side]) == 0 {
+		if b.Meeting != -1 {
+			b.done = true
+			return true
+		}
+		b.Frontier[side], b.Next[side] = b.Next[side], nil
+		if len(b.Frontier[side]) == 0 {
+			b.done = true // This side is exhausted, so the target is unreachable
+			return true
+		}
+		// Continue with whichever side has the smaller frontier
+		if len(b.Frontier[other]) <= len(b.Frontier[side]) {
+			b.Side = other
+		}
+	}
+
+	return false
+}
+
+// Path returns the source-to-target path through the meeting node, or nil if none was found
+func (b *BidirectionalBFSStepper) Path() []int {
+	if b.Meeting == -1 {
+		return nil
+	}
+
+	path := []int{}
+	for v := b.Meeting; ; {
+		path = append([]int{v}, path...)
+		p, ok := b.Parent[0][v]
+		if !ok {
+			break
+		}
+		v = p
+	}
+	for v := b.Meeting; ; {
+		p, ok := b.Parent[1][v]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+		v = p
+	}
+	return path
+}
+
+// BidirectionalBFS - Shortest unweighted path from source to target found by searching from both ends
+func BidirectionalBFS(neighbors map[int][]int, source, target int) []int {
+	b := NewBidirectionalBFSStepper(neighbors, source, target)
+	for !b.Step() {
+	}
+	return b.Path()
+}
+
+// DepthFrame is a node waiting on the depth-limited search stack
+type DepthFrame struct {
+	Node  int
+	Depth int
+}
+
+// DepthLimitedStepper runs a depth-first search that does not go below Limit, one expansion per step
+// A node is searched again when it is reached by a shallower route, so nothing within the limit is missed
+type DepthLimitedStepper struct {
+	Limit   int
+	Target  int // Node to stop at, -1 to explore everything within the limit
+	Stack   []DepthFrame
+	Depth   map[int]int // Shallowest depth each node has been reached at
+	Parent  map[int]int
+	Order   []int // Nodes in the order they were expanded
+	Current int   // Node expanded by the most recent step, -1 if none
+	Cutoff  bool  // Set when the limit stopped the search from following an edge
+	Found   bool
+	graph   map[int][]int
+}
+
+// NewDepthLimitedStepper prepares a depth-limited search from source
+func NewDepthLimitedStepper(neighbors map[int][]int, source, target, limit int) *DepthLimitedStepper {
+	return &DepthLimitedStepper{
+		Limit:   limit,
+		Target:  target,
+		Stack:   []DepthFrame{{Node: source, Depth: 0}},
+		Depth:   map[int]int{source: 0},
+		Parent:  map[int]int{},
+		Current: -1,
+		graph:   neighbors,
+	}
+}
+
+// Done reports whether the search has finished
+func (d *DepthLimitedStepper) Done() bool {
+	return d.Found || len(d.Stack) == 0
+}
+
+// Step pops and expands one node from the stack
+// Returns true when the target is found or the stack is empty
+func (d *DepthLimitedStepper) Step() bool {
+	d.Current = -1
+	for !d.Done() {
+		frame := d.Stack[len(d.Stack)-1]
+		d.Stack = d.Stack[:len(d.Stack)-1]
+
+		// Skip frames superseded by a shallower route to the same node
+		if frame.Depth != d.Depth[frame.Node] {
+			continue
+		}
+
+		d.Current = frame.Node
+		d.Order = append(d.Order, frame.Node)
+		if frame.Node == d.Target {
+			d.Found = true
+			break
+		}
+
+		for _, nb := range d.graph[frame.Node] {
+			if old, seen := d.Depth[nb]; seen && old <= frame.Depth+1 {
+				continue
+			}
+			if frame.Depth == d.Limit {
+				d.Cutoff = true
+				continue
+			}
+			d.Depth[nb] = frame.Depth + 1
+			d.Parent[nb] = frame.Node
+			d.Stack = append(d.Stack, DepthFrame{Node: nb, Depth: frame.Depth + 1})
+		}
+		break
+	}
+	return d.Done()
+}
+
+// Path returns the path from the source to the target, or nil if the target was not found
+func (d *DepthLimitedStepper) Path() []int {
+	if !d.Found {
+		return nil
+	}
+	return PathFromPredecessors(d.Parent, d.Target)
+}
+
+// DepthLimitedSearch - DFS from source that stops at the given depth
+// Returns the path to target (nil if not found within the limit) and whether the limit cut the search short
+func DepthLimitedSearch(neighbors map[int][]int, source, target, limit int) ([]int, bool) {
+	d := NewDepthLimitedStepper(neighbors, source, target, limit)
+	for !d.Step() {
+	}
+	return d.Path(), d.Cutoff
+}
+
+// IDDFSStepper runs depth-limited searches with limits 0, 1, 2, ... until the target is found
+// or a search finishes without being cut off
+type IDDFSStepper struct {
+	*DepthLimitedStepper
+	Iterations [][]int // Expansion order of every completed iteration
+	MaxDepth   int     // No simple path is longer than this, so deeper limits are pointless
+	source     int
+	done       bool
+}
+
+// NewIDDFSStepper prepares an iterative deepening search from source
+func NewIDDFSStepper(neighbors map[int][]int, source, target, numNodes int) *IDDFSStepper {
+	return &IDDFSStepper{
+		DepthLimitedStepper: NewDepthLimitedStepper(neighbors, source, target, 0),
+		MaxDepth:            numNodes - 1,
+		source:              source,
+	}
+}
+
+// Done reports whether the search has finished
+func (it *IDDFSStepper) Done() bool {
+	return it.done
+}
+
+// Step expands one node, or starts the next iteration once the current one is exhausted
+// Returns true when the target is found or every reachable node has been searched
+func (it *IDDFSStepper) Step() bool {
+	if it.done {
+		it.Current = -1
+		return true
+	}
+
+	dls := it.DepthLimitedStepper
+	if dls.Done() {
+		it.Iterations = append(it.Iterations, dls.Order)
+		if dls.Found || !dls.Cutoff || dls.Limit >= it.MaxDepth {
+			it.done = true
+			dls.Current = -1
+			return true
+		}
+		it.DepthLimitedStepper = NewDepthLimitedStepper(dls.graph, it.source, dls.Target, dls.Limit+1)
+		return false
+	}
+
+	dls.Step()
+	if dls.Found {
+		// Finish straight away rather than spending a step on the bookkeeping
+		it.Iterations = append(it.Iterations, dls.Order)
+		it.done = true
+	}
+	return it.done
+}
+
+// IDDFS - Iterative deepening DFS from source to target
+// Returns the path (nil if unreachable) and the depth limit of the final iteration
+func IDDFS(neighbors map[int][]int, source, target, numNodes int) ([]int, int) {
+	it := NewIDDFSStepper(neighbors, source, target, numNodes)
+	for !it.Step() {
+	}
+	return it.Path(), it.Limit
+}
+
+// UCSStepper runs uniform-cost search, expanding the cheapest frontier node each step
+type UCSStepper struct {
+	Target   int // Node to stop at, -1 to expand every reachable node
+	Dist     map[int]float64
+	Parent   map[int]int
+	Expanded map[int]bool
+	Order    []int // Nodes in the order they were expanded
+	Current  int   // Node expanded by the most recent step, -1 if none
+	Found    bool
+	frontier *PriorityQueue
+	graph    map[int][]Edge
+}
+
+// NewUCSStepper prepares a uniform-cost search from source
+func NewUCSStepper(neighbors map[int][]Edge, source, target int) *UCSStepper {
+	u := &UCSStepper{
+		Target:   target,
+		Dist:     map[int]float64{source: 0},
+		Parent:   map[int]int{},
+		Expanded: map[int]bool{},
+		Current:  -1,
+		frontier: &PriorityQueue{},
+		graph:    neighbors,
+	}
+	heap.Push(u.frontier, &PriorityQueueItem{Node: source, Priority: 0})
+	return u
+}
+
+// Done reports whether the search has finished
+func (u *UCSStepper) Done() bool {
+	return u.Found || u.frontier.Len() == 0
+}
+
+// Frontier returns the nodes waiting to be expanded and their current path costs
+func (u *UCSStepper) Frontier() map[int]float64 {
+	frontier := make(map[int]float64)
+	for _, item := range *u.frontier {
+		if !u.Expanded[item.Node] {
+			frontier[item.Node] = u.Dist[item.Node]
+		}
+	}
+	return frontier
+}
+
+// Step expands the cheapest node on the frontier
+// Returns true when the target is expanded or the frontier is empty
+func (u *UCSStepper) Step() bool {
+	u.Current = -1
+	for !u.Done() {
+		item := heap.Pop(u.frontier).(*PriorityQueueItem)
+		if u.Expanded[item.Node] {
+			continue // Stale entry, the node was expanded at a lower cost
+		}
+
+		u.Current = item.Node
+		u.Expanded[item.Node] = true
+		u.Order = append(u.Order, item.Node)
+		if item.Node == u.Target {
+			u.Found = true
+			break
+		}
+
+		for _, edge := range u.graph[item.Node] {
+			cost := u.Dist[item.Node] + edge.Weight
+			if old, seen := u.Dist[edge.To]; !u.Expanded[edge.To] && (!seen || cost < old) {
+				u.Dist[edge.To] = cost
+				u.Parent[edge.To] = item.Node
+				heap.Push(u.frontier, &PriorityQueueItem{Node: edge.To, Priority: cost})
+			}
+		}
+		break
+	}
+	return u.Done()
+}
+
+// Path returns the cheapest path to the target and its cost, or nil and +Inf if it was not found
+func (u *UCSStepper) Path() ([]int, float64) {
+	if !u.Found {
+		return nil, math.Inf(1)
+	}
+	return PathFromPredecessors(u.Parent, u.Target), u.Dist[u.Target]
+}
+
+// UniformCostSearch - Cheapest path from source to target by expanding nodes in order of path cost
+func UniformCostSearch(neighbors map[int][]Edge, source, target int) ([]int, float64) {
+	u := NewUCSStepper(neighbors, source, target)
+	for !u.Step() {
+	}
+	return u.Path()
+}
+
+// FrontierStepper runs BFS, DFS, Dijkstra or A* from source to target, one expansion per step
+// The searches differ only in which frontier node is expanded next: the oldest (BFS), the newest
+// (DFS), or the one with the lowest path cost (Dijkstra) plus heuristic estimate (A*)
+type FrontierStepper struct {
+	Kind     TraversalMode // ModeBFS, ModeDFS, ModeDijkstra or ModeAStar
+	Target   int
+	Dist     map[int]float64 // Cost of the path to every reached node along Parent
+	Parent   map[int]int
+	Expanded map[int]bool
+	Order    []int // Nodes in the order they were expanded
+	Current  int   // Node expanded by the most recent step, -1 if none
+	Found    bool
+	list     []int          // BFS queue or DFS stack
+	frontier *PriorityQueue // Dijkstra and A* frontier ordered by cost plus estimate
+	h        Heuristic
+	graph    map[int][]Edge
+}
+
+// NewFrontierStepper prepares a search of the given kind from source to target
+// h is only used by A*; nil means no estimate
+func NewFrontierStepper(kind TraversalMode, neighbors map[int][]Edge, source, target int, h Heuristic) *FrontierStepper {
+	if h == nil || kind != ModeAStar {
+		h = func(int) float64 { return 0 }
+	}
+	f := &FrontierStepper{
+		Kind:     kind,
+		Target:   target,
+		Dist:     map[int]float64{source: 0},
+		Parent:   map[int]int{},
+		Expanded: map[int]bool{},
+		Current:  -1,
+		frontier: &PriorityQueue{},
+		h:        h,
+		graph:    neighbors,
+	}
+	if kind == ModeBFS || kind == ModeDFS {
+		f.list = []int{source}
+	} else {
+		heap.Push(f.frontier, &PriorityQueueItem{Node: source, Priority: h(source)})
+	}
+	return f
+}
+
+// Done reports whether the search has finished
+func (f *FrontierStepper) Done() bool {
+	return f.Found || (len(f.list) == 0 && f.frontier.Len() == 0)
+}
+
+// Frontier returns the nodes waiting to be expanded
+func (f *FrontierStepper) Frontier() map[int]bool {
+	frontier := make(map[int]bool)
+	for _, node := range f.list {
+		if !f.Expanded[node] {
+			frontier[node] = true
+		}
+	}
+	for _, item := range *f.frontier {
+		if item.Priority <= f.Dist[item.Node]+f.h(item.Node)+1e-9 {
+			frontier[item.Node] = true
+		}
+	}
+	return frontier
+}
+
+// next removes the next node from the frontier
+// The second result is false for stale entries: a node DFS already expanded, or a queued
+// cost that a cheaper path to the node has since superseded
+func (f *FrontierStepper) next() (int, bool) {
+	switch f.Kind {
+	case ModeBFS:
+		node := f.list[0]
+		f.list = f.list[1:]
+		return node, true
+	case ModeDFS:
+		node := f.list[len(f.list)-1]
+		f.list = f.list[:len(f.list)-1]
+		return node, !f.Expanded[node]
+	}
+	item := heap.Pop(f.frontier).(*PriorityQueueItem)
+	return item.Node, item.Priority <= f.Dist[item.Node]+f.h(item.Node)+1e-9
+}
+
+// Step expands the next node on the frontier
+// Returns true when the target is expanded or the frontier is empty
+func (f *FrontierStepper) Step() bool {
+	f.Current = -1
+	for !f.Done() {
+		node, fresh := f.next()
+		if !fresh {
+			continue
+		}
+
+		f.Current = node
+		f.Expanded[node] = true
+		f.Order = append(f.Order, node)
+		if node == f.Target {
+			f.Found = true
+			break
+		}
+
+		edges := f.graph[node]
+		for i := range edges {
+			edge := edges[i]
+			if f.Kind == ModeDFS {
+				// The stack pops the last push first, so push in reverse to follow the list order
+				edge = edges[len(edges)-1-i]
+			}
+			cost := f.Dist[node] + edge.Weight
+			old, seen := f.Dist[edge.To]
+			switch f.Kind {
+			case ModeBFS:
+				if !seen {
+					f.Dist[edge.To], f.Parent[edge.To] = cost, node
+					f.list = append(f.list, edge.To)
+				}
+			case ModeDFS:
+				// A later push overwrites the parent; it is also the entry popped first
+				if !f.Expanded[edge.To] {
+					f.Dist[edge.To], f.Parent[edge.To] = cost, node
+					f.list = append(f.list, edge.To)
+				}
+			default:
+				if !seen || cost < old {
+					f.Dist[edge.To], f.Parent[edge.To] = cost, node
+					heap.Push(f.frontier, &PriorityQueueItem{Node: edge.To, Priority: cost + f.h(edge.To)})
+				}
+			}
+		}
+		break
+	}
+	return f.Done()
+}
+
+// Path returns the path the search found to the target and its cost, or nil and +Inf if it was not found
+// Only Dijkstra and A* with a consistent heuristic guarantee the cheapest path
+func (f *FrontierStepper) Path() ([]int, float64) {
+	if !f.Found {
+		return nil, math.Inf(1)
+	}
+	return PathFromPredecessors(f.Parent, f.Target), f.Dist[f.Target]
+}
@@ -0,0 +1,187 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// TreePosition is where a tree node is drawn
+type TreePosition struct {
+	X, Y int
+}
+
+// TreeNode is a search tree node as the tree layout and drawer see it
+type TreeNode interface {
+	Keys() []int          // The node's keys: one for binary trees, up to order-1 in a B-tree
+	Children() []TreeNode // Left and right child for binary trees, with nil for a missing one
+	Pos() *TreePosition   // Where the node is drawn, filled in by the layout
+	Annotation() string   // Short note drawn under the node, such as its height or color
+}
+
+// StepKind says what a step of a search tree operation did to the node it concerns
+type StepKind int
+
+const (
+	StepVisit       StepKind = iota // The key was compared with the node on the way down
+	StepFound                       // The node holds the key
+	StepMissing                     // The key is not in the tree
+	StepCreate                      // The node was created, or took over the key of a removed node
+	StepRemove                      // The node was removed
+	StepUpdate                      // The node's height, balance or color was updated
+	StepViolation                   // The node breaks the tree's balance rule until it is repaired
+	StepRestructure                 // A rotation, splay, split or merge at the node
+)
+
+// TreeStep is one step of a search tree operation, with a copy of the tree right after it
+type TreeStep struct {
+	Kind StepKind
+	Key  int      // A key of the node the step concerns
+	Text string   // Description of the step
+	Tree TreeNode // Copy of the whole tree after the step, nil once it is empty
+}
+
+// SearchTree is a set of distinct integer keys kept in a search tree whose operations can be
+// replayed step by step
+type SearchTree interface {
+	Insert(key int)
+	Delete(key int)
+	Contains(key int) bool
+	TreeRoot() TreeNode // The root, or nil for an empty tree
+	Steps() []TreeStep  // Steps of the most recent Insert, Delete or Contains
+}
+
+// TreeKind selects a kind of search tree
+type TreeKind int
+
+const (
+	TreeAVL      TreeKind = iota // Height-balanced binary tree
+	TreeRedBlack                 // Binary tree balanced by node colors
+	TreeBTree                    // Multiway tree with all leaves at the same depth
+	TreeSplay                    // Self-adjusting binary tree that moves accessed keys to the root
+	TreeTreap                    // Binary search tree by key, heap by random priority
+)
+
+// TreeKinds lists every kind of search tree, in menu order
+var TreeKinds = []TreeKind{TreeAVL, TreeRedBlack, TreeBTree, TreeSplay, TreeTreap}
+
+// String returns a human-readable name for the kind of tree
+func (k TreeKind) String() string {
+	switch k {
+	case TreeRedBlack:
+		return "Red-Black Tree"
+	case TreeBTree:
+		return "B-Tree"
+	case TreeSplay:
+		return "Splay Tree"
+	case TreeTreap:
+		return "Treap"
+	}
+	return "AVL Tree"
+}
+
+// NewSearchTree creates an empty tree of the given kind
+// order is the most children a B-tree node may have; treaps draw their priorities from a fixed
+// seed, so the same input sequence always builds the same treap
+func NewSearchTree(kind TreeKind, order int) SearchTree {
+	switch kind {
+	case TreeRedBlack:
+		return NewRedBlackTree()
+	case TreeBTree:
+		return NewBTree(order)
+	case TreeSplay:
+		return NewSplayTree()
+	case TreeTreap:
+		return NewTreap(rand.New(rand.NewSource(1)))
+	}
+	return NewAVLTree()
+}
+
+// TreeOp is one operation of an input sequence for a search tree
+type TreeOp struct {
+	Action string // "insert", "delete" or "search"
+	Key    int
+}
+
+// Apply runs the operation on a tree
+func (op TreeOp) Apply(t SearchTree) {
+	switch op.Action {
+	case "insert":
+		t.Insert(op.Key)
+	case "delete":
+		t.Delete(op.Key)
+	case "search":
+		t.Contains(op.Key) // Splay trees reshape on a search too
+	}
+}
+
+// TreeComparison is the shape one kind of tree ends up with after an input sequence
+type TreeComparison struct {
+	Kind   TreeKind
+	Height int
+	Nodes  int
+	Steps  int // Steps taken by all the operations together
+}
+
+// CompareTrees runs the same operations on every kind of tree
+func CompareTrees(ops []TreeOp, order int) []TreeComparison {
+	results := make([]TreeComparison, 0, len(TreeKinds))
+	for _, kind := range TreeKinds {
+		t := NewSearchTree(kind, order)
+		steps := 0
+		for _, op := range ops {
+			op.Apply(t)
+			steps += len(t.Steps())
+		}
+		results = append(results, TreeComparison{
+			Kind:   kind,
+			Height: TreeHeight(t.TreeRoot()),
+			Nodes:  TreeSize(t.TreeRoot()),
+			Steps:  steps,
+		})
+	}
+	return results
+}
+
+// TreeHeight returns the number of levels of a tree, 0 when it is empty
+func TreeHeight(node TreeNode) int {
+	if node == nil {
+		return 0
+	}
+	height := 0
+	for _, child := range node.Children() {
+		if child != nil {
+			height = max(height, TreeHeight(child))
+		}
+	}
+	return height + 1
+}
+
+// TreeSize returns the number of nodes of a tree
+func TreeSize(node TreeNode) int {
+	if node == nil {
+		return 0
+	}
+	size := 1
+	for _, child := range node.Children() {
+		if child != nil {
+			size += TreeSize(child)
+		}
+	}
+	return size
+}
+
+// compareText describes going down past a node on the way to value
+func compareText(value, key int) string {
+	if value < key {
+		return fmt.Sprintf("%d < %d: go left", value, key)
+	}
+	return fmt.Sprintf("%d > %d: go right", value, key)
+}
+
+// sideName names the direction of a rotation or child
+func sideName(left bool) string {
+	if left {
+		return "left"
+	}
+	return "right"
+}
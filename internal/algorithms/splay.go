@@ -0,0 +1,243 @@
+package algorithms
+
+import "fmt"
+
+// SplayNode represents a node in a splay tree
+type SplayNode struct {
+	Value    int
+	Left     *SplayNode
+	Right    *SplayNode
+	Parent   *SplayNode
+	Position TreePosition
+}
+
+// SplayTree is a binary search tree without balance information that moves every key it
+// touches to the root by rotations in pairs, so recently used keys are quick to reach again
+// A single operation can take linear time, but any sequence of m operations takes O(m log n)
+type SplayTree struct {
+	Root  *SplayNode
+	steps []TreeStep
+}
+
+// NewSplayTree creates a new empty splay tree
+func NewSplayTree() *SplayTree {
+	return &SplayTree{}
+}
+
+// Keys returns the node's value
+func (n *SplayNode) Keys() []int {
+	return []int{n.Value}
+}
+
+// Children returns the left and right child, nil where one is missing
+func (n *SplayNode) Children() []TreeNode {
+	children := make([]TreeNode, 2)
+	if n.Left != nil {
+		children[0] = n.Left
+	}
+	if n.Right != nil {
+		children[1] = n.Right
+	}
+	return children
+}
+
+// Pos returns where the node is drawn
+func (n *SplayNode) Pos() *TreePosition {
+	return &n.Position
+}
+
+// Annotation is empty: splay tree nodes keep no balance information
+func (n *SplayNode) Annotation() string {
+	return ""
+}
+
+// Copy returns a deep copy of the subtree rooted at n, or nil for a nil node
+func (n *SplayNode) Copy() *SplayNode {
+	if n == nil {
+		return nil
+	}
+	c := &SplayNode{Value: n.Value, Position: n.Position}
+	if c.Left = n.Left.Copy(); c.Left != nil {
+		c.Left.Parent = c
+	}
+	if c.Right = n.Right.Copy(); c.Right != nil {
+		c.Right.Parent = c
+	}
+	return c
+}
+
+// TreeRoot returns the root, or nil for an empty tree
+func (t *SplayTree) TreeRoot() TreeNode {
+	if t.Root == nil {
+		return nil
+	}
+	return t.Root
+}
+
+// Steps returns the steps of the most recent operation
+func (t *SplayTree) Steps() []TreeStep {
+	return t.steps
+}
+
+// record appends a step with a copy of the tree as it is now
+func (t *SplayTree) record(kind StepKind, key int, format string, args ...interface{}) {
+	step := TreeStep{Kind: kind, Key: key, Text: fmt.Sprintf(format, args...)}
+	if t.Root != nil {
+		step.Tree = t.Root.Copy()
+	}
+	t.steps = append(t.steps, step)
+}
+
+// rotateUp lifts x above its parent
+func (t *SplayTree) rotateUp(x *SplayNode) {
+	parent := x.Parent
+	grandparent := parent.Parent
+	if x == parent.Left {
+		parent.Left = x.Right
+		if x.Right != nil {
+			x.Right.Parent = parent
+		}
+		x.Right = parent
+	} else {
+		parent.Right = x.Left
+		if x.Left != nil {
+			x.Left.Parent = parent
+		}
+		x.Left = parent
+	}
+	parent.Parent = x
+	x.Parent = grandparent
+
+	switch {
+	case grandparent == nil:
+		t.Root = x
+	case grandparent.Left == parent:
+		grandparent.Left = x
+	default:
+		grandparent.Right = x
+	}
+}
+
+// splay moves x up until its parent is top, or to the root when top is nil
+func (t *SplayTree) splay(x, top *SplayNode) {
+	for x.Parent != top {
+		parent := x.Parent
+		grandparent := parent.Parent
+		switch {
+		case grandparent == top:
+			t.rotateUp(x)
+			t.record(StepRestructure, x.Value, "Zig: rotate %d above its parent %d", x.Value, parent.Value)
+		case (x == parent.Left) == (parent == grandparent.Left):
+			// Both links lean the same way: the parent goes up first
+			t.rotateUp(parent)
+			t.rotateUp(x)
+			t.record(StepRestructure, x.Value, "Zig-zig: rotate %d above %d, then %d above %d",
+				parent.Value, grandparent.Value, x.Value, parent.Value)
+		default:
+			t.rotateUp(x)
+			t.rotateUp(x)
+			t.record(StepRestructure, x.Value, "Zig-zag: rotate %d above %d, then above %d",
+				x.Value, parent.Value, grandparent.Value)
+		}
+	}
+}
+
+// find walks down from the root towards value, recording each comparison
+// Returns the node holding value, or nil and the last node visited
+func (t *SplayTree) find(value int) (found, last *SplayNode) {
+	node := t.Root
+	for node != nil {
+		if value == node.Value {
+			t.record(StepFound, value, "Found %d", value)
+			return node, node
+		}
+		t.record(StepVisit, node.Value, "%s", compareText(value, node.Value))
+		last = node
+		if value < node.Value {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return nil, last
+}
+
+// Insert attaches a value as a leaf and splays it to the root
+// A value already in the tree is splayed to the root instead
+func (t *SplayTree) Insert(value int) {
+	t.steps = nil
+	found, parent := t.find(value)
+	if found != nil {
+		t.splay(found, nil)
+		return
+	}
+
+	node := &SplayNode{Value: value, Parent: parent}
+	switch {
+	case parent == nil:
+		t.Root = node
+	case value < parent.Value:
+		parent.Left = node
+	default:
+		parent.Right = node
+	}
+	t.record(StepCreate, value, "Attach %d as a new leaf", value)
+	t.splay(node, nil)
+}
+
+// Delete splays a value to the root and removes it
+// The largest key of its left subtree is then splayed up to just below it, where it has no
+// right child, and takes over the right subtree
+func (t *SplayTree) Delete(value int) {
+	t.steps = nil
+	node, last := t.find(value)
+	if node == nil {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+		if last != nil {
+			t.splay(last, nil)
+		}
+		return
+	}
+	t.splay(node, nil)
+
+	if node.Left == nil {
+		t.Root = node.Right
+		if t.Root != nil {
+			t.Root.Parent = nil
+		}
+		t.record(StepRemove, value, "Remove the root %d; its right subtree becomes the tree", value)
+		return
+	}
+
+	largest := node.Left
+	for largest.Right != nil {
+		largest = largest.Right
+	}
+	t.record(StepVisit, largest.Value, "The largest key left of %d is %d: splay it up to just below the root",
+		value, largest.Value)
+	t.splay(largest, node)
+
+	largest.Right = node.Right
+	if largest.Right != nil {
+		largest.Right.Parent = largest
+	}
+	largest.Parent = nil
+	t.Root = largest
+	t.record(StepRemove, value, "Remove the root %d; %d takes its place with its right subtree", value, largest.Value)
+}
+
+// Contains reports whether the tree holds value, splaying it to the root if so and the last
+// node visited otherwise
+func (t *SplayTree) Contains(value int) bool {
+	t.steps = nil
+	node, last := t.find(value)
+	if node == nil {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+		if last != nil {
+			t.splay(last, nil)
+		}
+		return false
+	}
+	t.splay(node, nil)
+	return true
+}
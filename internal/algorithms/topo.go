@@ -0,0 +1,128 @@
+package algorithms
+
+// KahnStepper runs Kahn's topological sort, outputting one node with in-degree zero per step
+// and lowering the in-degree of its successors
+type KahnStepper struct {
+	InDegree    []int // Remaining in-degree of every node not yet output
+	Queue       []int // Nodes with in-degree zero waiting to be output, front first
+	Order       []int
+	Output      []bool // Whether each node has been output
+	Current     int    // Node output by the last step, -1 otherwise
+	Decremented []int  // Successors of Current whose in-degree the last step lowered
+	Cycle       []int  // Witness cycle (first node repeated at the end) once the sort is stuck, nil otherwise
+	graph       map[int][]int
+	preds       [][]int
+}
+
+// NewKahnStepper counts in-degrees and queues the nodes without incoming edges in index order
+func NewKahnStepper(neighbors map[int][]int, numNodes int) *KahnStepper {
+	k := &KahnStepper{
+		InDegree: make([]int, numNodes),
+		Order:    make([]int, 0, numNodes),
+		Output:   make([]bool, numNodes),
+		Current:  -1,
+		graph:    neighbors,
+		preds:    make([][]int, numNodes),
+	}
+	for from := 0; from < numNodes; from++ {
+		for _, to := range neighbors[from] {
+			k.InDegree[to]++
+			k.preds[to] = append(k.preds[to], from)
+		}
+	}
+	for v := 0; v < numNodes; v++ {
+		if k.InDegree[v] == 0 {
+			k.Queue = append(k.Queue, v)
+		}
+	}
+	k.checkStuck()
+	return k
+}
+
+// Done reports whether the sort has finished, either with a full order or stuck on a cycle
+func (k *KahnStepper) Done() bool {
+	return len(k.Queue) == 0
+}
+
+// Step outputs the node at the front of the queue and lowers the in-degree of its successors
+// Returns true once every node is output or the remaining nodes all lie on or behind a cycle
+func (k *KahnStepper) Step() bool {
+	k.Current = -1
+	k.Decremented = nil
+	if k.Done() {
+		return true
+	}
+
+	node := k.Queue[0]
+	k.Queue = k.Queue[1:]
+	k.Order = append(k.Order, node)
+	k.Output[node] = true
+	k.Current = node
+
+	for _, next := range k.graph[node] {
+		k.InDegree[next]--
+		k.Decremented = append(k.Decremented, next)
+		if k.InDegree[next] == 0 {
+			k.Queue = append(k.Queue, next)
+		}
+	}
+
+	k.checkStuck()
+	return k.Done()
+}
+
+// checkStuck records a witness cycle when the queue is empty but nodes remain
+// Every remaining node still has a remaining predecessor, so walking backwards
+// through remaining predecessors must eventually revisit a node
+func (k *KahnStepper) checkStuck() {
+	if len(k.Queue) > 0 || len(k.Order) == len(k.InDegree) {
+		return
+	}
+
+	start := -1
+	for v := range k.Output {
+		if !k.Output[v] {
+			start = v
+			break
+		}
+	}
+
+	position := map[int]int{}
+	walk := []int{}
+	for v := start; ; {
+		if i, seen := position[v]; seen {
+			// walk[i:] runs backwards along the cycle
+			cycle := append([]int{}, walk[i:]...)
+			reverseInts(cycle)
+			k.Cycle = append(cycle, cycle[0])
+			return
+		}
+		position[v] = len(walk)
+		walk = append(walk, v)
+		for _, p := range k.preds[v] {
+			if !k.Output[p] {
+				v = p
+				break
+			}
+		}
+	}
+}
+
+// Kahn - Topological order by repeatedly removing nodes with in-degree zero
+// Returns the order, or nil and a witness cycle (first node repeated at the end) if the graph has a cycle
+func Kahn(neighbors map[int][]int, numNodes int) ([]int, []int) {
+	k := NewKahnStepper(neighbors, numNodes)
+	for !k.Step() {
+	}
+	if k.Cycle != nil {
+		return nil, k.Cycle
+	}
+	return k.Order, nil
+}
+
+// reverseInts reverses a slice in place
+func reverseInts(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -15,8 +15,148 @@ const (
 	ModePrim
 	ModeTarjan
 	ModeKosaraju
+	ModeFloydWarshall
+	ModeJohnson
+	ModeBidirectional
+	ModeIDDFS
+	ModeDepthLimited
+	ModeUCS
+	ModeDFSTimes // Recursive DFS with discovery/finish times and edge classification
+	ModeBoruvka
+	ModeReverseDelete
+	ModeMSTCompare // Runs every MST algorithm and compares the results
+	ModeKahn
+	ModeConnected   // Connected components of the undirected graph
+	ModeBridges     // Bridges and articulation points
+	ModeBiconnected // Biconnected components (blocks)
+	ModeEdmondsKarp
+	ModeDinic
+	ModeBipartite    // Bipartiteness check by BFS 2-coloring
+	ModeHopcroftKarp // Maximum bipartite matching
+	ModeHungarian    // Minimum-cost assignment between the two sides
+	ModeGreedyColoring
+	ModeWelshPowell
+	ModeDSatur
+	ModeExactColoring // Backtracking search for the chromatic number
+	ModeEuler         // Hierholzer's Eulerian path or circuit
+	ModeHamiltonianPath
+	ModeHamiltonianCycle
+	ModeNearestNeighborTSP
+	ModeTwoOpt   // 2-opt improvement of the nearest-neighbor tour
+	ModeMSTTour  // MST-based 2-approximation
+	ModeHeldKarp // Exact TSP by dynamic programming
+	ModeTSPCompare
+	ModeCycleDetect      // Finds one witness cycle
+	ModeCycleEnumeration // Johnson's algorithm for every elementary cycle
+	ModeMetrics          // Graph statistics and node centrality
+	ModeKShortestPaths   // Yen's k cheapest loopless paths
+	ModeAllSimplePaths
+	ModeMaze // Grid pathfinding on a painted or generated cell map
 )
 
+// String returns a human-readable name for the mode
+func (m TraversalMode) String() string {
+	switch m {
+	case ModeIdle:
+		return "Idle"
+	case ModeBFS:
+		return "BFS"
+	case ModeDFS:
+		return "DFS"
+	case ModeAVL:
+		return "AVL Tree"
+	case ModeDijkstra:
+		return "Dijkstra"
+	case ModeAStar:
+		return "A*"
+	case ModeTopological:
+		return "Topological Sort"
+	case ModeKruskal:
+		return "Kruskal MST"
+	case ModePrim:
+		return "Prim MST"
+	case ModeTarjan:
+		return "Tarjan SCC"
+	case ModeKosaraju:
+		return "Kosaraju SCC"
+	case ModeFloydWarshall:
+		return "Floyd-Warshall"
+	case ModeJohnson:
+		return "Johnson"
+	case ModeBidirectional:
+		return "Bidirectional BFS"
+	case ModeIDDFS:
+		return "Iterative Deepening DFS"
+	case ModeDepthLimited:
+		return "Depth-Limited DFS"
+	case ModeUCS:
+		return "Uniform-Cost Search"
+	case ModeDFSTimes:
+		return "DFS (edge classification)"
+	case ModeBoruvka:
+		return "Boruvka MST"
+	case ModeReverseDelete:
+		return "Reverse-Delete MST"
+	case ModeMSTCompare:
+		return "MST Comparison"
+	case ModeKahn:
+		return "Kahn Topological Sort"
+	case ModeConnected:
+		return "Connected Components"
+	case ModeBridges:
+		return "Bridges & Cut Vertices"
+	case ModeBiconnected:
+		return "Biconnected Components"
+	case ModeEdmondsKarp:
+		return "Edmonds-Karp Max Flow"
+	case ModeDinic:
+		return "Dinic Max Flow"
+	case ModeBipartite:
+		return "Bipartite Check"
+	case ModeHopcroftKarp:
+		return "Hopcroft-Karp Matching"
+	case ModeHungarian:
+		return "Hungarian Assignment"
+	case ModeGreedyColoring:
+		return "Greedy Coloring"
+	case ModeWelshPowell:
+		return "Welsh-Powell Coloring"
+	case ModeDSatur:
+		return "DSatur Coloring"
+	case ModeExactColoring:
+		return "Exact Coloring"
+	case ModeEuler:
+		return "Eulerian Path/Circuit"
+	case ModeHamiltonianPath:
+		return "Hamiltonian Path"
+	case ModeHamiltonianCycle:
+		return "Hamiltonian Cycle"
+	case ModeNearestNeighborTSP:
+		return "Nearest Neighbor Tour"
+	case ModeTwoOpt:
+		return "2-opt Tour"
+	case ModeMSTTour:
+		return "MST 2-Approximation Tour"
+	case ModeHeldKarp:
+		return "Held-Karp Optimal Tour"
+	case ModeTSPCompare:
+		return "Tour Comparison"
+	case ModeCycleDetect:
+		return "Cycle Detection"
+	case ModeCycleEnumeration:
+		return "Elementary Cycles"
+	case ModeMetrics:
+		return "Graph Metrics"
+	case ModeKShortestPaths:
+		return "K Shortest Paths (Yen)"
+	case ModeAllSimplePaths:
+		return "All Simple Paths"
+	case ModeMaze:
+		return "Maze"
+	}
+	return "Unknown"
+}
+
 // BFSStep performs one step of the BFS algorithm
 // It takes the current queue, visited map, and node list
 // Returns the updated queue, a newly visited node (if any), and whether the algorithm is done
@@ -44,6 +184,26 @@ func BFSStep(queue []int, visited map[int]bool, neighbors map[int][]int) ([]int,
 	return queue, n, false
 }
 
+// BFSTreeStep performs one step of BFS like BFSStep and also builds the BFS tree
+// level and parent are filled in for every node the first time it is discovered;
+// the caller seeds level with the start node at level 0
+func BFSTreeStep(queue []int, visited map[int]bool, neighbors map[int][]int, level, parent map[int]int) ([]int, int, bool) {
+	queue, n, done := BFSStep(queue, visited, neighbors)
+	if n == -1 {
+		return queue, n, done
+	}
+
+	// FIFO order means the first discovery of a node is along a shortest path
+	for _, nb := range neighbors[n] {
+		if _, seen := level[nb]; !seen {
+			level[nb] = level[n] + 1
+			parent[nb] = n
+		}
+	}
+
+	return queue, n, done
+}
+
 // DFSStep performs one step of the DFS algorithm
 // It takes the current stack, visited map, and node list
 // Returns the updated stack, a newly visited node (if any), and whether the algorithm is done
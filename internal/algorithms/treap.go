@@ -0,0 +1,220 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// TreapNode represents a node in a treap
+type TreapNode struct {
+	Value    int
+	Priority int // Random, larger than the priorities of the node's children
+	Left     *TreapNode
+	Right    *TreapNode
+	Parent   *TreapNode
+	Position TreePosition
+}
+
+// Treap is a binary search tree by value that is at the same time a heap by a random priority
+// drawn for every value, which makes its shape that of a tree built by inserting the values in
+// random order: O(log n) deep on average, whatever the input order
+type Treap struct {
+	Root  *TreapNode
+	rand  *rand.Rand
+	steps []TreeStep
+}
+
+// NewTreap creates a new empty treap drawing priorities from r
+func NewTreap(r *rand.Rand) *Treap {
+	return &Treap{rand: r}
+}
+
+// Keys returns the node's value
+func (n *TreapNode) Keys() []int {
+	return []int{n.Value}
+}
+
+// Children returns the left and right child, nil where one is missing
+func (n *TreapNode) Children() []TreeNode {
+	children := make([]TreeNode, 2)
+	if n.Left != nil {
+		children[0] = n.Left
+	}
+	if n.Right != nil {
+		children[1] = n.Right
+	}
+	return children
+}
+
+// Pos returns where the node is drawn
+func (n *TreapNode) Pos() *TreePosition {
+	return &n.Position
+}
+
+// Annotation shows the node's priority
+func (n *TreapNode) Annotation() string {
+	return fmt.Sprintf("p:%d", n.Priority)
+}
+
+// Copy returns a deep copy of the subtree rooted at n, or nil for a nil node
+func (n *TreapNode) Copy() *TreapNode {
+	if n == nil {
+		return nil
+	}
+	c := &TreapNode{Value: n.Value, Priority: n.Priority, Position: n.Position}
+	if c.Left = n.Left.Copy(); c.Left != nil {
+		c.Left.Parent = c
+	}
+	if c.Right = n.Right.Copy(); c.Right != nil {
+		c.Right.Parent = c
+	}
+	return c
+}
+
+// TreeRoot returns the root, or nil for an empty tree
+func (t *Treap) TreeRoot() TreeNode {
+	if t.Root == nil {
+		return nil
+	}
+	return t.Root
+}
+
+// Steps returns the steps of the most recent operation
+func (t *Treap) Steps() []TreeStep {
+	return t.steps
+}
+
+// record appends a step with a copy of the tree as it is now
+func (t *Treap) record(kind StepKind, key int, format string, args ...interface{}) {
+	step := TreeStep{Kind: kind, Key: key, Text: fmt.Sprintf(format, args...)}
+	if t.Root != nil {
+		step.Tree = t.Root.Copy()
+	}
+	t.steps = append(t.steps, step)
+}
+
+// rotateUp lifts x above its parent
+func (t *Treap) rotateUp(x *TreapNode) {
+	parent := x.Parent
+	grandparent := parent.Parent
+	if x == parent.Left {
+		parent.Left = x.Right
+		if x.Right != nil {
+			x.Right.Parent = parent
+		}
+		x.Right = parent
+	} else {
+		parent.Right = x.Left
+		if x.Left != nil {
+			x.Left.Parent = parent
+		}
+		x.Left = parent
+	}
+	parent.Parent = x
+	x.Parent = grandparent
+
+	switch {
+	case grandparent == nil:
+		t.Root = x
+	case grandparent.Left == parent:
+		grandparent.Left = x
+	default:
+		grandparent.Right = x
+	}
+}
+
+// find walks down from the root towards value, recording each comparison
+// Returns the node holding value, or nil and the last node visited
+func (t *Treap) find(value int) (found, last *TreapNode) {
+	node := t.Root
+	for node != nil {
+		if value == node.Value {
+			t.record(StepFound, value, "Found %d", value)
+			return node, node
+		}
+		t.record(StepVisit, node.Value, "%s", compareText(value, node.Value))
+		last = node
+		if value < node.Value {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return nil, last
+}
+
+// Insert attaches a value as a leaf with a random priority, then rotates it up while its
+// priority beats its parent's; duplicate values are not inserted
+func (t *Treap) Insert(value int) {
+	t.steps = nil
+	found, parent := t.find(value)
+	if found != nil {
+		return
+	}
+
+	node := &TreapNode{Value: value, Priority: t.rand.Intn(99) + 1, Parent: parent}
+	switch {
+	case parent == nil:
+		t.Root = node
+	case value < parent.Value:
+		parent.Left = node
+	default:
+		parent.Right = node
+	}
+	t.record(StepCreate, value, "Attach %d as a leaf with priority %d", value, node.Priority)
+
+	for node.Parent != nil && node.Priority > node.Parent.Priority {
+		parent := node.Parent
+		t.rotateUp(node)
+		t.record(StepRestructure, value, "Priority %d beats %d's %d: rotate %d up",
+			node.Priority, parent.Value, parent.Priority, value)
+	}
+}
+
+// Delete rotates a value down, always lifting its child with the higher priority, until it
+// has at most one child, then removes it
+func (t *Treap) Delete(value int) {
+	t.steps = nil
+	node, _ := t.find(value)
+	if node == nil {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+		return
+	}
+
+	for node.Left != nil && node.Right != nil {
+		child := node.Left
+		if node.Right.Priority > child.Priority {
+			child = node.Right
+		}
+		t.rotateUp(child)
+		t.record(StepRestructure, child.Value, "%d has two children: rotate up %d, whose priority %d is higher",
+			value, child.Value, child.Priority)
+	}
+
+	child := node.Left
+	if child == nil {
+		child = node.Right
+	}
+	if child != nil {
+		child.Parent = node.Parent
+	}
+	switch {
+	case node.Parent == nil:
+		t.Root = child
+	case node.Parent.Left == node:
+		node.Parent.Left = child
+	default:
+		node.Parent.Right = child
+	}
+	t.record(StepRemove, value, "Remove %d; its child, if any, moves up", value)
+}
+
+// Contains reports whether the tree holds value, recording the search
+func (t *Treap) Contains(value int) bool {
+	t.steps = nil
+	node, _ := t.find(value)
+	if node == nil {
+		t.record(StepMissing, value, "%d is not in the tree", value)
+	}
+	return node != nil
+}
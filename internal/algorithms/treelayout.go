@@ -0,0 +1,152 @@
+package algorithms
+
+// Sizes the tree layout works with, in pixels
+const (
+	TreeNodeWidth = 60 // A round node with its ring, wide enough for the annotation below it
+	TreeKeyWidth  = 30 // A key's cell in a B-tree node
+	TreeNodeGap   = 10 // Least space between two nodes on the same level
+)
+
+// treeContour holds the leftmost and rightmost extent of every level of a subtree, relative to
+// the subtree's root; level 0 is the root itself
+type treeContour struct {
+	left, right []int
+}
+
+// nodeWidth returns how wide a node is drawn
+func nodeWidth(node TreeNode) int {
+	if _, ok := node.(*BTreeNode); ok {
+		return TreeKeyWidth * max(len(node.Keys()), 1)
+	}
+	return TreeNodeWidth
+}
+
+// merge widens the contour to take in another one whose root is offset to the right
+func (c *treeContour) merge(other treeContour, offset, firstLevel int) {
+	for level := range other.left {
+		left, right := offset+other.left[level], offset+other.right[level]
+		if at := firstLevel + level; at < len(c.left) {
+			c.left[at] = min(c.left[at], left)
+			c.right[at] = max(c.right[at], right)
+		} else {
+			c.left = append(c.left, left)
+			c.right = append(c.right, right)
+		}
+	}
+}
+
+// UpdateTreePositions lays out a tree with the Reingold-Tilford algorithm and centers it on
+// centerX, with the root at startY and every level levelHeight lower
+// Each subtree is laid out on its own, then siblings are pushed together until their contours
+// are TreeNodeGap apart on the level where they come closest, and every parent is centered
+// over its children; no two nodes overlap, whatever their widths
+func UpdateTreePositions(root TreeNode, centerX, startY, levelHeight int) {
+	if root == nil {
+		return
+	}
+
+	contour := layoutSubtree(root)
+	left, right := 0, 0
+	for level := range contour.left {
+		left = min(left, contour.left[level])
+		right = max(right, contour.right[level])
+	}
+	placeSubtree(root, centerX-(left+right)/2, startY, levelHeight)
+}
+
+// layoutSubtree lays out the subtree rooted at node and returns its contour
+// Each child's position relative to its parent is left in its X coordinate for placeSubtree
+func layoutSubtree(node TreeNode) treeContour {
+	width := nodeWidth(node)
+	contour := treeContour{left: []int{-width / 2}, right: []int{width - width/2}}
+
+	children := node.Children()
+	present := []TreeNode{}
+	contours := []treeContour{}
+	for _, child := range children {
+		if child != nil {
+			present = append(present, child)
+			contours = append(contours, layoutSubtree(child))
+		}
+	}
+	if len(present) == 0 {
+		return contour
+	}
+
+	offsets := spreadSubtrees(contours)
+	if len(children) == 2 && len(present) == 1 {
+		// A lone child of a binary node keeps to its side, where a sibling as wide would put it
+		offsets[0] = (nodeWidth(present[0]) + TreeNodeGap) / 2
+		if children[0] != nil {
+			offsets[0] = -offsets[0]
+		}
+	}
+	for i, child := range present {
+		child.Pos().X = offsets[i]
+		contour.merge(contours[i], offsets[i], 1)
+	}
+	return contour
+}
+
+// spreadSubtrees places sibling subtrees side by side and returns where each root goes,
+// relative to a parent centered over the outermost ones
+// Packing from the left crowds small subtrees between large ones to the left, and packing from
+// the right to the right; the average of both spreads them evenly and still keeps them apart
+// It is rounded down, never towards zero, so that no pair loses a pixel of TreeNodeGap
+func spreadSubtrees(contours []treeContour) []int {
+	fromLeft := packSubtrees(contours, false)
+	fromRight := packSubtrees(contours, true)
+	offsets := make([]int, len(contours))
+	for i := range offsets {
+		offsets[i] = (fromLeft[i] + fromRight[i]) >> 1
+	}
+
+	middle := (offsets[0] + offsets[len(offsets)-1]) / 2
+	for i := range offsets {
+		offsets[i] -= middle
+	}
+	return offsets
+}
+
+// packSubtrees places sibling subtrees one after another from the left, or from the right when
+// reversed, each as close to those already placed as their contours allow
+func packSubtrees(contours []treeContour, reversed bool) []int {
+	offsets := make([]int, len(contours))
+	var placed treeContour // Extent of the subtrees placed so far
+	for k := range contours {
+		i := k
+		if reversed {
+			i = len(contours) - 1 - k
+		}
+		c := contours[i]
+
+		if k > 0 {
+			levels := min(len(c.left), len(placed.left))
+			for level := 0; level < levels; level++ {
+				var offset int
+				if reversed {
+					offset = placed.left[level] - TreeNodeGap - c.right[level]
+				} else {
+					offset = placed.right[level] + TreeNodeGap - c.left[level]
+				}
+				if level == 0 || (reversed && offset < offsets[i]) || (!reversed && offset > offsets[i]) {
+					offsets[i] = offset
+				}
+			}
+		}
+		placed.merge(c, offsets[i], 0)
+	}
+	return offsets
+}
+
+// placeSubtree turns the relative positions left by layoutSubtree into screen positions
+func placeSubtree(node TreeNode, x, y, levelHeight int) {
+	pos := node.Pos()
+	pos.X = x
+	pos.Y = y
+	for _, child := range node.Children() {
+		if child != nil {
+			placeSubtree(child, x+child.Pos().X, y+levelHeight, levelHeight)
+		}
+	}
+}
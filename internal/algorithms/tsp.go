@@ -0,0 +1,329 @@
+package algorithms
+
+import "math"
+
+// HeldKarpMaxNodes is the largest graph Held-Karp is run on; its table has 2^n * n entries
+const HeldKarpMaxNodes = 15
+
+// TSPMetric selects how far apart two nodes are for the travelling salesman algorithms
+type TSPMetric int
+
+const (
+	TSPStraightLine TSPMetric = iota // Euclidean distance between node positions, in pixels
+	TSPEdgeWeights                   // Shortest-path distance along weighted edges
+)
+
+// String returns a human-readable name for the metric
+func (m TSPMetric) String() string {
+	if m == TSPEdgeWeights {
+		return "edge weights"
+	}
+	return "straight-line distance"
+}
+
+// TSPDistances returns the complete distance matrix for the chosen metric
+// With edge weights, pairs in different components are +Inf apart
+func TSPDistances(metric TSPMetric, neighbors map[int][]Edge, positions map[int]Position, numNodes int) [][]float64 {
+	if metric == TSPEdgeWeights {
+		return FloydWarshall(neighbors, numNodes).Dist
+	}
+	dist := make([][]float64, numNodes)
+	for i := range dist {
+		dist[i] = make([]float64, numNodes)
+		for j := range dist[i] {
+			dist[i][j] = heuristic(positions[i], positions[j])
+		}
+	}
+	return dist
+}
+
+// SymmetricDistances reports whether every pair of nodes is as far apart in both directions,
+// which edge weights on a directed graph need not be
+func SymmetricDistances(dist [][]float64) bool {
+	for i := range dist {
+		for j := 0; j < i; j++ {
+			if dist[i][j] != dist[j][i] && math.Abs(dist[i][j]-dist[j][i]) > 1e-9 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TourLength returns the length of the closed tour, returning to its first node
+func TourLength(dist [][]float64, tour []int) float64 {
+	total := 0.0
+	for i, v := range tour {
+		total += dist[v][tour[(i+1)%len(tour)]]
+	}
+	return total
+}
+
+// NearestNeighborTour - Greedy tour that always moves to the closest unvisited node
+func NearestNeighborTour(dist [][]float64, start int) []int {
+	n := len(dist)
+	if n == 0 {
+		return nil
+	}
+	visited := make([]bool, n)
+	tour := []int{start}
+	visited[start] = true
+	for len(tour) < n {
+		last := tour[len(tour)-1]
+		next := -1
+		for v := 0; v < n; v++ {
+			if !visited[v] && (next == -1 || dist[last][v] < dist[last][next]) {
+				next = v
+			}
+		}
+		visited[next] = true
+		tour = append(tour, next)
+	}
+	return tour
+}
+
+// MSTTour - 2-approximation: a preorder walk of a minimum spanning tree of the complete graph
+// Skipping repeated nodes never lengthens the doubled tree walk when the distances obey the
+// triangle inequality, so the tour is at most twice the MST weight, and so twice the optimum
+// The bound needs symmetric distances; see SymmetricDistances
+// Returns the tour and the tree edges
+func MSTTour(dist [][]float64, root int) ([]int, [][2]int) {
+	n := len(dist)
+	if n == 0 {
+		return nil, nil
+	}
+
+	// Prim on the dense matrix
+	inTree := make([]bool, n)
+	best := make([]float64, n)
+	parent := make([]int, n)
+	for v := range best {
+		best[v] = math.Inf(1)
+		parent[v] = -1
+	}
+	best[root] = 0
+	children := make([][]int, n)
+	tree := [][2]int{}
+	for range dist {
+		u := -1
+		for v := 0; v < n; v++ {
+			if !inTree[v] && (u == -1 || best[v] < best[u]) {
+				u = v
+			}
+		}
+		inTree[u] = true
+		if parent[u] != -1 {
+			children[parent[u]] = append(children[parent[u]], u)
+			tree = append(tree, [2]int{parent[u], u})
+		}
+		for v := 0; v < n; v++ {
+			if !inTree[v] && dist[u][v] < best[v] {
+				best[v] = dist[u][v]
+				parent[v] = u
+			}
+		}
+	}
+
+	tour := []int{}
+	var walk func(v int)
+	walk = func(v int) {
+		tour = append(tour, v)
+		for _, c := range children[v] {
+			walk(c)
+		}
+	}
+	walk(root)
+	// Where distances are infinite, Prim starts a new tree at each node it cannot reach; those
+	// trees are walked after the root's, in index order
+	for v := 0; v < n; v++ {
+		if parent[v] == -1 && v != root {
+			walk(v)
+		}
+	}
+	return tour, tree
+}
+
+// TwoOptStepper improves a tour by 2-opt moves: remove two edges and reconnect the two paths
+// the other way, which reverses the tour between them
+// Each step applies the move that shortens the tour most; the first node stays in place
+// With asymmetric distances the reversed stretch changes length too, and that is counted
+type TwoOptStepper struct {
+	Tour         []int
+	Dist         [][]float64
+	Length       float64
+	Improvements int
+	Removed      [2][2]int // Edges removed by the last move
+	Added        [2][2]int // Edges that replaced them
+	Gain         float64   // How much the last move shortened the tour
+	done         bool
+}
+
+// NewTwoOptStepper prepares 2-opt improvement of the given tour, which is copied
+func NewTwoOptStepper(dist [][]float64, tour []int) *TwoOptStepper {
+	t := &TwoOptStepper{
+		Tour:   append([]int{}, tour...),
+		Dist:   dist,
+		Length: TourLength(dist, tour),
+	}
+	t.done = len(tour) < 4 // Smaller tours have no two non-adjacent edges
+	return t
+}
+
+// Done reports whether no 2-opt move shortens the tour
+func (t *TwoOptStepper) Done() bool {
+	return t.done
+}
+
+// Step applies the best improving move
+// Returns true once the tour is 2-optimal
+func (t *TwoOptStepper) Step() bool {
+	t.Gain = 0
+	if t.done {
+		return true
+	}
+
+	n := len(t.Tour)
+	bestI, bestJ, bestGain := -1, -1, 1e-9
+	for i := 1; i < n-1; i++ {
+		// Length of the stretch from Tour[i] to Tour[j], walked forwards and backwards
+		forward, backward := 0.0, 0.0
+		for j := i + 1; j < n; j++ {
+			forward += t.Dist[t.Tour[j-1]][t.Tour[j]]
+			backward += t.Dist[t.Tour[j]][t.Tour[j-1]]
+			a, b := t.Tour[i-1], t.Tour[i]
+			c, d := t.Tour[j], t.Tour[(j+1)%n]
+			// When a == d the move turns the whole tour around, which only helps with asymmetric distances
+			gain := t.Dist[a][b] + t.Dist[c][d] + forward - t.Dist[a][c] - t.Dist[b][d] - backward
+			if gain > bestGain {
+				bestI, bestJ, bestGain = i, j, gain
+			}
+		}
+	}
+	if bestI == -1 {
+		t.done = true
+		return true
+	}
+
+	a, b := t.Tour[bestI-1], t.Tour[bestI]
+	c, d := t.Tour[bestJ], t.Tour[(bestJ+1)%n]
+	t.Removed = [2][2]int{{a, b}, {c, d}}
+	t.Added = [2][2]int{{a, c}, {b, d}}
+	reverseInts(t.Tour[bestI : bestJ+1])
+	t.Gain = bestGain
+	t.Length = TourLength(t.Dist, t.Tour)
+	t.Improvements++
+	return false
+}
+
+// TwoOpt - Improves a tour with 2-opt moves until none helps
+func TwoOpt(dist [][]float64, tour []int) []int {
+	t := NewTwoOptStepper(dist, tour)
+	for !t.Step() {
+	}
+	return t.Tour
+}
+
+// HeldKarp - Optimal tour by dynamic programming over subsets, starting at start
+// best[S][v] is the shortest path from start through exactly the nodes of S, ending at v
+// Returns nil if the graph has more than HeldKarpMaxNodes nodes or no finite tour
+func HeldKarp(dist [][]float64, start int) ([]int, float64) {
+	n := len(dist)
+	if n == 0 || n > HeldKarpMaxNodes {
+		return nil, math.Inf(1)
+	}
+	if n == 1 {
+		return []int{start}, 0
+	}
+
+	full := 1<<n - 1
+	best := make([][]float64, 1<<n)
+	prev := make([][]int8, 1<<n)
+	for mask := range best {
+		best[mask] = make([]float64, n)
+		prev[mask] = make([]int8, n)
+		for v := range best[mask] {
+			best[mask][v] = math.Inf(1)
+		}
+	}
+	best[1<<start][start] = 0
+
+	for mask := 1 << start; mask <= full; mask++ {
+		if mask&(1<<start) == 0 {
+			continue
+		}
+		for v := 0; v < n; v++ {
+			cost := best[mask][v]
+			if math.IsInf(cost, 1) {
+				continue
+			}
+			for w := 0; w < n; w++ {
+				if mask&(1<<w) != 0 {
+					continue
+				}
+				next := mask | 1<<w
+				if c := cost + dist[v][w]; c < best[next][w] {
+					best[next][w] = c
+					prev[next][w] = int8(v)
+				}
+			}
+		}
+	}
+
+	length, last := math.Inf(1), -1
+	for v := 0; v < n; v++ {
+		if v == start {
+			continue
+		}
+		if c := best[full][v] + dist[v][start]; c < length {
+			length, last = c, v
+		}
+	}
+	if last == -1 {
+		return nil, length
+	}
+
+	tour := make([]int, 0, n)
+	for mask, v := full, last; v != start; {
+		tour = append(tour, v)
+		p := int(prev[mask][v])
+		mask &^= 1 << v
+		v = p
+	}
+	tour = append(tour, start)
+	reverseInts(tour)
+	return tour, length
+}
+
+// TSPStats summarizes one travelling salesman algorithm's tour
+type TSPStats struct {
+	Algorithm string
+	Tour      []int // nil if the algorithm was not run
+	Length    float64
+}
+
+// CompareTSP runs every tour algorithm from start; Held-Karp only on small enough graphs
+// The MST weight is returned as well, since no tour can be shorter than it
+// With asymmetric distances the MST tour and bound do not hold: the MST 2-approximation is
+// left out and the bound is NaN
+func CompareTSP(dist [][]float64, start int) ([]TSPStats, float64) {
+	nearest := NearestNeighborTour(dist, start)
+	improved := TwoOpt(dist, nearest)
+	stats := []TSPStats{
+		{Algorithm: "Nearest neighbor", Tour: nearest, Length: TourLength(dist, nearest)},
+		{Algorithm: "Nearest neighbor + 2-opt", Tour: improved, Length: TourLength(dist, improved)},
+	}
+
+	mstWeight := math.NaN()
+	if SymmetricDistances(dist) {
+		mstTour, tree := MSTTour(dist, start)
+		mstWeight = 0
+		for _, edge := range tree {
+			mstWeight += dist[edge[0]][edge[1]]
+		}
+		stats = append(stats, TSPStats{Algorithm: "MST 2-approximation", Tour: mstTour, Length: TourLength(dist, mstTour)})
+	}
+
+	optimal := TSPStats{Algorithm: "Held-Karp (optimal)"}
+	optimal.Tour, optimal.Length = HeldKarp(dist, start)
+	return append(stats, optimal), mstWeight
+}
@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"bfsdfs/internal/algorithms"
+)
+
+// Condensation builds the component graph of g: one node per strongly connected component,
+// placed at the centroid of its members, and a directed edge wherever an edge of g runs
+// between two different components
+// The result is always a DAG; parallel edges are merged, keeping the lightest weight
+// Returns the condensation and the component index of every node of g
+func (g *Graph) Condensation(sccs [][]int) (Graph, []int) {
+	componentOf := make([]int, len(g.Nodes))
+	for i := range componentOf {
+		componentOf[i] = -1
+	}
+
+	dag := Graph{Directed: true}
+	for c, scc := range sccs {
+		x, y := 0, 0
+		for _, v := range scc {
+			componentOf[v] = c
+			x += g.Nodes[v].X
+			y += g.Nodes[v].Y
+		}
+		if len(scc) > 0 {
+			x /= len(scc)
+			y /= len(scc)
+		}
+		dag.Nodes = append(dag.Nodes, Node{X: x, Y: y, Neighbors: []int{}, Weights: []float64{}})
+	}
+
+	// Index of each merged edge in dag.Edges, so lighter parallel edges can replace heavier ones
+	merged := map[[2]int]int{}
+	for from, node := range g.Nodes {
+		for j, to := range node.Neighbors {
+			a, b := componentOf[from], componentOf[to]
+			if a == -1 || b == -1 || a == b {
+				continue
+			}
+			weight := 1.0
+			if j < len(node.Weights) {
+				weight = node.Weights[j]
+			}
+
+			key := [2]int{a, b}
+			if i, ok := merged[key]; ok {
+				if weight < dag.WeightedEdges[i].Weight {
+					dag.WeightedEdges[i].Weight = weight
+				}
+				continue
+			}
+			merged[key] = len(dag.Edges)
+			dag.Edges = append(dag.Edges, key)
+			dag.WeightedEdges = append(dag.WeightedEdges, algorithms.Edge{From: a, To: b, Weight: weight})
+		}
+	}
+
+	for _, edge := range dag.WeightedEdges {
+		dag.Nodes[edge.From].Neighbors = append(dag.Nodes[edge.From].Neighbors, edge.To)
+		dag.Nodes[edge.From].Weights = append(dag.Nodes[edge.From].Weights, edge.Weight)
+	}
+
+	return dag, componentOf
+}
@@ -24,6 +24,7 @@ type Graph struct {
 	Nodes         []Node
 	Edges         [][2]int
 	WeightedEdges []algorithms.Edge // For weighted algorithms
+	Directed      bool              // Edges only run from Edges[i][0] to Edges[i][1]
 }
 
 // NewRandomGraph creates a new graph with n nodes and random edges
@@ -181,3 +182,56 @@ func (g *Graph) GetUnweightedNeighbors() map[int][]int {
 	}
 	return neighbors
 }
+
+// EdgeWeight returns the weight of the edge from one node to another
+// The second return value is false if no such edge exists
+func (g *Graph) EdgeWeight(from, to int) (float64, bool) {
+	if from < 0 || from >= len(g.Nodes) {
+		return 0, false
+	}
+	node := g.Nodes[from]
+	for j, neighbor := range node.Neighbors {
+		if neighbor == to {
+			if j < len(node.Weights) {
+				return node.Weights[j], true
+			}
+			return 1.0, true // Default weight, matching GetWeightedNeighbors
+		}
+	}
+	return 0, false
+}
+
+// SetDirected switches the graph between directed and undirected edges
+// Each edge keeps the direction it was drawn in (Edges[i][0] to Edges[i][1]) and its weight
+func (g *Graph) SetDirected(directed bool) {
+	if g.Directed == directed {
+		return
+	}
+
+	type adjacency struct {
+		neighbors []int
+		weights   []float64
+	}
+	rebuilt := make([]adjacency, len(g.Nodes))
+	add := func(from, to int, weight float64) {
+		rebuilt[from].neighbors = append(rebuilt[from].neighbors, to)
+		rebuilt[from].weights = append(rebuilt[from].weights, weight)
+	}
+	for _, edge := range g.Edges {
+		a, b := edge[0], edge[1]
+		weight, ok := g.EdgeWeight(a, b)
+		if !ok {
+			weight, _ = g.EdgeWeight(b, a)
+		}
+		add(a, b, weight)
+		if !directed {
+			add(b, a, weight)
+		}
+	}
+
+	for i := range g.Nodes {
+		g.Nodes[i].Neighbors = append([]int{}, rebuilt[i].neighbors...)
+		g.Nodes[i].Weights = append([]float64{}, rebuilt[i].weights...)
+	}
+	g.Directed = directed
+}
@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+
+	"bfsdfs/internal/algorithms"
+)
+
+// GridMap is a rectangular map of cells for pathfinding on a grid
+// Cells are stored row by row; each one becomes a graph node at its center
+type GridMap struct {
+	Cols, Rows int
+	CellSize   int       // Side of a cell in canvas pixels
+	Walls      []bool    // Walls cannot be entered
+	Costs      []float64 // Cost of entering each cell, 1 for open ground
+	Start      int       // Cell the searches start from, -1 if unset
+	Goal       int       // Cell the searches look for, -1 if unset
+	Diagonal   bool      // 8-connectivity; diagonal moves may not cut the corner of a wall
+}
+
+// NewGridMap creates an open cols x rows map with the start and goal in opposite corners
+// inside the border, where the maze generators always leave a room
+func NewGridMap(cols, rows, cellSize int) *GridMap {
+	m := &GridMap{
+		Cols:     cols,
+		Rows:     rows,
+		CellSize: cellSize,
+		Walls:    make([]bool, cols*rows),
+		Costs:    make([]float64, cols*rows),
+	}
+	for i := range m.Costs {
+		m.Costs[i] = 1
+	}
+	m.resetEndpoints()
+	return m
+}
+
+// resetEndpoints moves the start to the top-left room and the goal to the bottom-right one
+func (m *GridMap) resetEndpoints() {
+	m.Start = m.Index(min(1, m.Cols-1), min(1, m.Rows-1))
+	m.Goal = m.Index(max(0, 2*((m.Cols-1)/2)-1), max(0, 2*((m.Rows-1)/2)-1))
+}
+
+// Index returns the cell at the given column and row, -1 if it is off the map
+func (m *GridMap) Index(col, row int) int {
+	if col < 0 || row < 0 || col >= m.Cols || row >= m.Rows {
+		return -1
+	}
+	return row*m.Cols + col
+}
+
+// CellAt returns the cell under the canvas point (x, y), -1 if it is off the map
+func (m *GridMap) CellAt(x, y int) int {
+	if x < 0 || y < 0 {
+		return -1
+	}
+	return m.Index(x/m.CellSize, y/m.CellSize)
+}
+
+// Center returns the canvas position of the middle of a cell
+func (m *GridMap) Center(cell int) (int, int) {
+	return (cell%m.Cols)*m.CellSize + m.CellSize/2, (cell/m.Cols)*m.CellSize + m.CellSize/2
+}
+
+// Open reports whether a cell can be entered
+func (m *GridMap) Open(cell int) bool {
+	return cell >= 0 && cell < len(m.Walls) && !m.Walls[cell]
+}
+
+// Clear removes every wall and terrain cost, keeping the start and goal
+func (m *GridMap) Clear() {
+	for i := range m.Walls {
+		m.Walls[i] = false
+		m.Costs[i] = 1
+	}
+}
+
+// Generate replaces the map with a maze from the chosen generator and moves the start
+// and goal back to the corners
+func (m *GridMap) Generate(kind algorithms.MazeKind, r *rand.Rand) {
+	m.Walls = algorithms.GenerateMaze(kind, m.Cols, m.Rows, r)
+	for i := range m.Costs {
+		m.Costs[i] = 1
+	}
+	m.resetEndpoints()
+}
+
+// Graph converts the map into a directed graph with one node per cell, walls included as
+// isolated nodes so node and cell indices agree
+// A move costs the terrain cost of the cell it enters, times √2 for a diagonal
+func (m *GridMap) Graph() Graph {
+	g := Graph{Directed: true}
+	for cell := range m.Walls {
+		x, y := m.Center(cell)
+		g.Nodes = append(g.Nodes, Node{X: x, Y: y, Neighbors: []int{}, Weights: []float64{}})
+	}
+
+	// Right, down, left, up, then the diagonals
+	moves := [][2]int{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+	if m.Diagonal {
+		moves = append(moves, [2]int{1, 1}, [2]int{-1, 1}, [2]int{-1, -1}, [2]int{1, -1})
+	}
+	for cell := range m.Walls {
+		if !m.Open(cell) {
+			continue
+		}
+		col, row := cell%m.Cols, cell/m.Cols
+		for _, move := range moves {
+			next := m.Index(col+move[0], row+move[1])
+			if !m.Open(next) {
+				continue
+			}
+			weight := m.Costs[next]
+			if move[0] != 0 && move[1] != 0 {
+				if !m.Open(m.Index(col+move[0], row)) || !m.Open(m.Index(col, row+move[1])) {
+					continue // Squeezing past a wall corner
+				}
+				weight *= math.Sqrt2
+			}
+			g.Nodes[cell].Neighbors = append(g.Nodes[cell].Neighbors, next)
+			g.Nodes[cell].Weights = append(g.Nodes[cell].Weights, weight)
+			g.Edges = append(g.Edges, [2]int{cell, next})
+			g.WeightedEdges = append(g.WeightedEdges, algorithms.Edge{From: cell, To: next, Weight: weight})
+		}
+	}
+	return g
+}
@@ -0,0 +1,16 @@
+package graph
+
+import (
+	"bfsdfs/internal/algorithms"
+)
+
+// Stats measures g: node and edge counts, density, degree distribution, diameter, radius,
+// girth, clustering coefficient and number of components
+func (g *Graph) Stats() algorithms.GraphStats {
+	return algorithms.ComputeGraphStats(g.GetUnweightedNeighbors(), len(g.Nodes), len(g.Edges), g.Directed)
+}
+
+// Centrality returns the chosen centrality score of every node of g
+func (g *Graph) Centrality(c algorithms.Centrality) []float64 {
+	return algorithms.CentralityScores(c, g.GetUnweightedNeighbors(), len(g.Nodes), g.Directed)
+}
@@ -1,6 +1,8 @@
 package simulator
 
 import (
+	"math"
+
 	"bfsdfs/internal/algorithms"
 	"bfsdfs/internal/graph"
 )
@@ -15,42 +17,136 @@ type Simulator struct {
 	Current    int // Currently active node
 	LastActive int // Last active node for animation
 	Mode       algorithms.TraversalMode
+	Source     int // Source node of the current single-source algorithm
+	Target     int // Goal node of the current source-to-target algorithm, -1 if none
 	Step       int
 	Done       bool
-	avlTree    *algorithms.AVLTree
+	tree       algorithms.SearchTree // Tree of the tree mode, of kind TreeKind
 	avlValue   int
 	avlAction  string // "insert", "delete", "search"
 
+	// Tree mode: the kind of tree, the operations run on it and the replay of the last one
+	TreeKind       algorithms.TreeKind
+	BTreeOrder     int                         // Most children of a B-tree node
+	TreeOps        []algorithms.TreeOp         // Every operation since the tree was started
+	TreeComparison []algorithms.TreeComparison // Every kind of tree after the same TreeOps
+	treeSteps      []algorithms.TreeStep       // Steps of the last operation, replayed one per Update
+	treeBefore     algorithms.TreeNode         // Copy of the tree before the last operation
+
 	// Algorithm-specific results
-	ShortestPaths map[int]float64
-	Predecessors  map[int]int
-	Path          []int
-	MST           []algorithms.Edge
-	SCCs          [][]int
-	TopOrder      []int
+	ShortestPaths  map[int]float64
+	Predecessors   map[int]int
+	Path           []int
+	MST            []algorithms.Edge
+	SCCs           [][]int
+	Condensation   *graph.Graph // Component DAG built from SCCs, nil until requested
+	ComponentOf    []int        // Condensation node of every graph node
+	TopOrder       []int
+	Cycle          []int   // Witness cycle of a failed topological sort or bipartite check, or the detected or selected cycle; first node repeated at the end
+	Cycles         [][]int // Elementary cycles found by Johnson's algorithm
+	CyclesCapped   bool    // More cycles exist than CycleLimit allowed
+	SelectedCycle  int     // Index into Cycles of the highlighted cycle, -1 if none
+	Components     [][]int
+	Connectivity   *algorithms.BiconnectivityResult
+	Partition      []int   // Side (0 or 1) of every node of a bipartite graph
+	Matching       []int   // Partner of every node, -1 if unmatched
+	MatchingCost   float64 // Total weight of the Hungarian assignment
+	MatchingPhases int     // Hopcroft-Karp phases
+	NodeColors     []int   // Palette index of every node for colorings, -1 for the default color
+	Tour           []int   // Travelling salesman tour from Source, returning to it
+	TourLength     float64
+	TourTree       [][2]int // Spanning tree behind the MST tour
+	TSPComparison  []algorithms.TSPStats
+	TSPLowerBound  float64                   // MST weight, which no tour can beat; NaN for asymmetric distances
+	Paths          []algorithms.WeightedPath // Source-to-target paths listed by Yen's algorithm or the simple path search
+	PathsCapped    bool                      // More simple paths exist than PathLimit allowed
+	SelectedPath   int                       // Index into Paths of the highlighted path, -1 if none
+	Stats          *algorithms.GraphStats
+	Centrality     []float64 // Score of every node under CentralityKind
+	AllPairs       *algorithms.AllPairsResult
+	PathCost       float64
+	MSTComparison  []algorithms.MSTStats
+	Levels         map[int]int // BFS distance (level) of every discovered node
+	Parents        map[int]int // BFS tree parent of every discovered node except the start
+
+	// A* heuristic settings and results
+	Heuristic           algorithms.HeuristicKind
+	Epsilon             float64         // Inflation factor for weighted A*
+	CustomHeuristic     map[int]float64 // User-defined per-node h values
+	HeuristicValues     map[int]float64 // h(v) of the selected heuristic for the last search
+	HeuristicComparison []algorithms.HeuristicStats
+
+	// Search settings
+	DepthLimit     int // Limit for depth-limited DFS
+	HamiltonBudget int // Maximum number of path extensions for the Hamiltonian search
+	CycleLimit     int // Maximum number of cycles Johnson's algorithm lists
+	PathCount      int // Number of paths Yen's algorithm finds
+	PathLimit      int // Maximum number of simple paths listed
+
+	// Travelling salesman settings
+	TSPMetric algorithms.TSPMetric
+
+	// Centrality shown by the graph metrics mode
+	CentralityKind algorithms.Centrality
+
+	// Grid pathfinding: Graph is built from Maze while it is open
+	Maze       *graph.GridMap           // Cell map of the maze mode, nil outside it
+	MazeSearch algorithms.TraversalMode // Search running on the maze: BFS, DFS, Dijkstra or A*
+	userGraph  graph.Graph              // The drawn graph, restored when the maze closes
+
+	// Steppers for algorithms that advance one phase per Update
+	floydWarshall  *algorithms.FloydWarshallStepper
+	johnson        *algorithms.JohnsonStepper
+	bidirectional  *algorithms.BidirectionalBFSStepper
+	depthLimited   *algorithms.DepthLimitedStepper
+	iddfs          *algorithms.IDDFSStepper
+	ucs            *algorithms.UCSStepper
+	dfsTimes       *algorithms.DFSTimesStepper
+	kruskal        *algorithms.KruskalStepper
+	prim           *algorithms.PrimStepper
+	boruvka        *algorithms.BoruvkaStepper
+	reverseDelete  *algorithms.ReverseDeleteStepper
+	tarjan         *algorithms.TarjanStepper
+	kahn           *algorithms.KahnStepper
+	greedyColoring *algorithms.GreedyColoringStepper
+	exactColoring  *algorithms.ExactColoringStepper
+	hierholzer     *algorithms.HierholzerStepper
+	hamiltonian    *algorithms.HamiltonianStepper
+	twoOpt         *algorithms.TwoOptStepper
+	edmondsKarp    *algorithms.EdmondsKarpStepper
+	dinic          *algorithms.DinicStepper
+	mazeSearch     *algorithms.FrontierStepper
 }
 
 // NewSimulator creates a new simulator with n nodes
 func NewSimulator(n int) *Simulator {
 	return &Simulator{
-		Graph:      graph.NewRandomGraph(n),
-		Visited:    map[int]bool{},
-		Current:    -1,
-		LastActive: -1,
-		Mode:       algorithms.ModeIdle,
+		Graph:          graph.NewRandomGraph(n),
+		Visited:        map[int]bool{},
+		Current:        -1,
+		LastActive:     -1,
+		Target:         -1,
+		Mode:           algorithms.ModeIdle,
+		Epsilon:        2.0,
+		DepthLimit:     2,
+		HamiltonBudget: 5000,
+		CycleLimit:     200,
+		PathCount:      5,
+		PathLimit:      200,
+		SelectedCycle:  -1,
+		SelectedPath:   -1,
+		BTreeOrder:     4,
 	}
 }
 
 // StartBFS starts a BFS traversal from the given start node
 func (s *Simulator) StartBFS(start int) {
 	s.Mode = algorithms.ModeBFS
+	s.resetState()
 	s.Queue = []int{start}
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
-	s.Current = -1
-	s.LastActive = -1
-	s.Done = false
+	s.Source = start
+	s.Levels = map[int]int{start: 0}
+	s.Parents = map[int]int{}
 }
 
 // StartDFS starts a DFS traversal from the given start node
@@ -67,6 +163,11 @@ func (s *Simulator) StartDFS(start int) {
 
 // StartAVL initializes the simulator for AVL tree operations
 func (s *Simulator) StartAVL() {
+	s.StartTree(algorithms.TreeAVL)
+}
+
+// StartTree initializes the simulator for operations on an empty tree of the given kind
+func (s *Simulator) StartTree(kind algorithms.TreeKind) {
 	s.Mode = algorithms.ModeAVL
 	s.Queue = nil
 	s.Stack = nil
@@ -74,10 +175,52 @@ func (s *Simulator) StartAVL() {
 	s.Visited = map[int]bool{}
 	s.Current = -1
 	s.LastActive = -1
-	s.Done = false
-	s.avlTree = algorithms.NewAVLTree()
+	s.Done = true // Nothing to replay until the first operation
+	s.Step = 0
+	s.TreeKind = kind
+	s.tree = algorithms.NewSearchTree(kind, s.BTreeOrder)
 	s.avlValue = 0
 	s.avlAction = "insert"
+	s.TreeOps = nil
+	s.TreeComparison = nil
+	s.treeSteps = nil
+	s.treeBefore = nil
+}
+
+// SetTreeKind switches the tree mode to another kind of tree, rebuilt from the same operations
+// so the two can be compared; outside the tree mode it starts an empty tree of that kind
+func (s *Simulator) SetTreeKind(kind algorithms.TreeKind) {
+	if s.Mode != algorithms.ModeAVL {
+		s.StartTree(kind)
+		return
+	}
+	s.TreeKind = kind
+	s.rebuildTree()
+}
+
+// SetBTreeOrder changes the most children of a B-tree node, rebuilding a B-tree in use
+func (s *Simulator) SetBTreeOrder(order int) {
+	s.BTreeOrder = order
+	if s.Mode != algorithms.ModeAVL {
+		return
+	}
+	if s.TreeKind == algorithms.TreeBTree {
+		s.rebuildTree()
+	}
+	s.TreeComparison = algorithms.CompareTrees(s.TreeOps, s.BTreeOrder)
+}
+
+// rebuildTree runs every operation so far on a new tree of the selected kind, with nothing to replay
+func (s *Simulator) rebuildTree() {
+	s.tree = algorithms.NewSearchTree(s.TreeKind, s.BTreeOrder)
+	for _, op := range s.TreeOps {
+		op.Apply(s.tree)
+	}
+	s.treeSteps = nil
+	s.treeBefore = nil
+	s.Step = 0
+	s.Done = true
+	s.UpdateAVL()
 }
 
 // StartDijkstra initializes Dijkstra's algorithm from a source node
@@ -88,6 +231,7 @@ func (s *Simulator) StartDijkstra(source int) {
 	s.Order = nil
 	s.Visited = map[int]bool{}
 	s.Current = source
+	s.Source = source
 	s.LastActive = -1
 	s.Done = false
 
@@ -99,114 +243,611 @@ func (s *Simulator) StartDijkstra(source int) {
 	s.Done = true
 }
 
-// StartAStar initializes A* algorithm from source to goal
+// StartAStar initializes A* algorithm from source to goal using the selected heuristic
+// A goal of -1 enters A* mode without searching until a goal is chosen
 func (s *Simulator) StartAStar(source, goal int) {
 	s.Mode = algorithms.ModeAStar
-	s.Queue = nil
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
+	s.resetState()
 	s.Current = source
-	s.LastActive = -1
-	s.Done = false
+	s.Source = source
+	s.Target = goal
+	s.Done = true
+
+	if goal < 0 || goal >= len(s.Graph.Nodes) {
+		return
+	}
 
 	// Run A* algorithm
 	neighbors := s.Graph.GetWeightedNeighbors()
+	opts := s.heuristicOptions(neighbors)
+	h := algorithms.NewHeuristic(s.Heuristic, goal, opts)
+	result := algorithms.AStarSearch(neighbors, source, goal, h)
+	s.Path = result.Path
+	s.PathCost = result.Cost
+	s.Order = result.Expanded
+	for _, node := range result.Expanded {
+		s.Visited[node] = true
+	}
+
+	s.HeuristicValues = make(map[int]float64, len(s.Graph.Nodes))
+	for i := range s.Graph.Nodes {
+		s.HeuristicValues[i] = h(i)
+	}
+	s.HeuristicComparison = algorithms.CompareHeuristics(neighbors, source, goal, len(s.Graph.Nodes), opts)
+}
+
+// heuristicOptions collects the inputs for the A* heuristics from the current graph
+func (s *Simulator) heuristicOptions(neighbors map[int][]algorithms.Edge) algorithms.HeuristicOptions {
 	positions := s.Graph.GetPositions()
-	path, _ := algorithms.AStar(neighbors, source, goal, positions)
-	s.Path = path
-	s.Done = true
+	return algorithms.HeuristicOptions{
+		Positions: positions,
+		Scale:     algorithms.AdmissibleScale(neighbors, positions),
+		Epsilon:   s.Epsilon,
+		Custom:    s.CustomHeuristic,
+	}
 }
 
-// StartTopological initializes topological sort
+// StartTopological initializes the DFS-based topological sort
+// A graph with a cycle has no order; Cycle then holds a witness instead
 func (s *Simulator) StartTopological() {
 	s.Mode = algorithms.ModeTopological
-	s.Queue = nil
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
-	s.Current = -1
-	s.LastActive = -1
-	s.Done = false
+	s.resetState()
 
-	// Run topological sort
 	neighbors := s.Graph.GetUnweightedNeighbors()
-	topOrder := algorithms.TopologicalSort(neighbors, len(s.Graph.Nodes))
-	s.TopOrder = topOrder
+	s.TopOrder, s.Cycle = algorithms.TopologicalSort(neighbors, len(s.Graph.Nodes))
 	s.Done = true
 }
 
-// StartKruskal initializes Kruskal's MST algorithm
+// StartKahn initializes the stepped Kahn topological sort
+func (s *Simulator) StartKahn() {
+	s.Mode = algorithms.ModeKahn
+	s.resetState()
+
+	s.kahn = algorithms.NewKahnStepper(s.Graph.GetUnweightedNeighbors(), len(s.Graph.Nodes))
+	s.Cycle = s.kahn.Cycle
+	s.Done = s.kahn.Done()
+	if s.Done && s.Cycle == nil {
+		s.TopOrder = s.kahn.Order
+	}
+}
+
+// StartKruskal initializes the stepped Kruskal MST algorithm
+// Each Update considers the next cheapest edge
 func (s *Simulator) StartKruskal() {
 	s.Mode = algorithms.ModeKruskal
-	s.Queue = nil
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
-	s.Current = -1
-	s.LastActive = -1
-	s.Done = false
+	s.resetState()
 
-	// Run Kruskal's algorithm
-	mst := algorithms.Kruskal(s.Graph.WeightedEdges, len(s.Graph.Nodes))
-	s.MST = mst
-	s.Done = true
+	s.kruskal = algorithms.NewKruskalStepper(s.Graph.WeightedEdges, len(s.Graph.Nodes))
+	s.MST = s.kruskal.MST
+	s.Done = s.kruskal.Done()
 }
 
-// StartPrim initializes Prim's MST algorithm
+// StartPrim initializes the stepped Prim MST algorithm rooted at node 0
 func (s *Simulator) StartPrim() {
+	s.StartPrimFrom(0)
+}
+
+// StartPrimFrom initializes the stepped Prim MST algorithm rooted at the given node
+// Each Update adds one node to the tree
+func (s *Simulator) StartPrimFrom(root int) {
 	s.Mode = algorithms.ModePrim
-	s.Queue = nil
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
-	s.Current = -1
-	s.LastActive = -1
-	s.Done = false
+	s.resetState()
+	s.Source = root
 
-	// Run Prim's algorithm
-	neighbors := s.Graph.GetWeightedNeighbors()
-	mst := algorithms.Prim(neighbors, len(s.Graph.Nodes))
-	s.MST = mst
+	s.prim = algorithms.NewPrimStepper(s.Graph.GetWeightedNeighbors(), root, len(s.Graph.Nodes))
+	s.MST = s.prim.MST
+	s.Done = s.prim.Done()
+	if s.prim.Current != -1 {
+		s.Current = s.prim.Current
+		s.Visited[s.prim.Current] = true
+		s.Order = append(s.Order, s.prim.Current)
+	}
+}
+
+// StartBoruvka initializes the stepped Borůvka MST algorithm
+// Each Update runs one phase
+func (s *Simulator) StartBoruvka() {
+	s.Mode = algorithms.ModeBoruvka
+	s.resetState()
+
+	s.boruvka = algorithms.NewBoruvkaStepper(s.Graph.WeightedEdges, len(s.Graph.Nodes))
+	s.MST = s.boruvka.MST
+	s.Done = s.boruvka.Done()
+}
+
+// StartReverseDelete initializes the stepped reverse-delete MST algorithm
+// Each Update considers the heaviest remaining edge
+func (s *Simulator) StartReverseDelete() {
+	s.Mode = algorithms.ModeReverseDelete
+	s.resetState()
+
+	s.reverseDelete = algorithms.NewReverseDeleteStepper(s.Graph.WeightedEdges, len(s.Graph.Nodes))
+	s.MST = s.reverseDelete.MST()
+	s.Done = s.reverseDelete.Done()
+}
+
+// StartMSTComparison runs every MST algorithm on the current graph, Prim from the given root
+func (s *Simulator) StartMSTComparison(root int) {
+	s.Mode = algorithms.ModeMSTCompare
+	s.resetState()
+	s.Source = root
+
+	s.MSTComparison = algorithms.CompareMST(s.Graph.WeightedEdges, len(s.Graph.Nodes), root)
 	s.Done = true
 }
 
-// StartTarjan initializes Tarjan's SCC algorithm
+// StartTarjan initializes the stepped Tarjan SCC algorithm
 func (s *Simulator) StartTarjan() {
 	s.Mode = algorithms.ModeTarjan
-	s.Queue = nil
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
-	s.Current = -1
-	s.LastActive = -1
-	s.Done = false
+	s.resetState()
 
-	// Run Tarjan's algorithm
-	neighbors := s.Graph.GetUnweightedNeighbors()
-	sccs := algorithms.Tarjan(neighbors, len(s.Graph.Nodes))
-	s.SCCs = sccs
-	s.Done = true
+	s.tarjan = algorithms.NewTarjanStepper(s.Graph.GetUnweightedNeighbors(), len(s.Graph.Nodes))
+	s.SCCs = s.tarjan.SCCs
+	s.Done = s.tarjan.Done()
 }
 
 // StartKosaraju initializes Kosaraju's SCC algorithm
 func (s *Simulator) StartKosaraju() {
 	s.Mode = algorithms.ModeKosaraju
-	s.Queue = nil
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
-	s.Current = -1
-	s.LastActive = -1
-	s.Done = false
+	s.resetState()
 
 	// Run Kosaraju's algorithm
 	neighbors := s.Graph.GetUnweightedNeighbors()
-	sccs := algorithms.Kosaraju(neighbors, len(s.Graph.Nodes))
-	s.SCCs = sccs
+	s.SCCs = algorithms.Kosaraju(neighbors, len(s.Graph.Nodes))
+	s.Done = true
+}
+
+// BuildCondensation collapses the strongly connected components found by a finished
+// Tarjan or Kosaraju run into the condensation DAG
+// Returns false if no components are available yet
+func (s *Simulator) BuildCondensation() bool {
+	if (s.Mode != algorithms.ModeTarjan && s.Mode != algorithms.ModeKosaraju) || !s.Done || len(s.SCCs) == 0 {
+		return false
+	}
+	dag, componentOf := s.Graph.Condensation(s.SCCs)
+	s.Condensation = &dag
+	s.ComponentOf = componentOf
+	return true
+}
+
+// StartConnectedComponents finds the connected components, ignoring edge directions
+func (s *Simulator) StartConnectedComponents() {
+	s.Mode = algorithms.ModeConnected
+	s.resetState()
+
+	s.Components = algorithms.ConnectedComponents(s.undirectedNeighbors(), len(s.Graph.Nodes))
+	s.Done = true
+}
+
+// StartBridges finds the bridges and articulation points, ignoring edge directions
+func (s *Simulator) StartBridges() {
+	s.Mode = algorithms.ModeBridges
+	s.resetState()
+
+	result := algorithms.Biconnectivity(s.undirectedNeighbors(), len(s.Graph.Nodes))
+	s.Connectivity = &result
+	s.Done = true
+}
+
+// StartBiconnected finds the biconnected components, ignoring edge directions
+func (s *Simulator) StartBiconnected() {
+	s.Mode = algorithms.ModeBiconnected
+	s.resetState()
+
+	result := algorithms.Biconnectivity(s.undirectedNeighbors(), len(s.Graph.Nodes))
+	s.Connectivity = &result
+	s.Done = true
+}
+
+// StartEdmondsKarp starts a stepped Edmonds-Karp max flow from source to sink,
+// using the edge weights as capacities
+func (s *Simulator) StartEdmondsKarp(source, sink int) {
+	s.Mode = algorithms.ModeEdmondsKarp
+	s.resetState()
+	s.Source = source
+	s.Target = sink
+
+	s.edmondsKarp = algorithms.NewEdmondsKarpStepper(s.Graph.GetWeightedNeighbors(), source, sink, len(s.Graph.Nodes))
+	s.Done = s.edmondsKarp.Done()
+}
+
+// StartDinic starts a stepped Dinic max flow from source to sink,
+// using the edge weights as capacities
+func (s *Simulator) StartDinic(source, sink int) {
+	s.Mode = algorithms.ModeDinic
+	s.resetState()
+	s.Source = source
+	s.Target = sink
+
+	s.dinic = algorithms.NewDinicStepper(s.Graph.GetWeightedNeighbors(), source, sink, len(s.Graph.Nodes))
+	s.Done = s.dinic.Done()
+}
+
+// StartBipartite 2-colors the graph, ignoring edge directions
+// A graph that is not bipartite leaves Partition nil and an odd cycle in Cycle
+func (s *Simulator) StartBipartite() {
+	s.Mode = algorithms.ModeBipartite
+	s.resetState()
+
+	s.Partition, s.Cycle = algorithms.Bipartite(s.undirectedNeighbors(), len(s.Graph.Nodes))
+	s.Done = true
+}
+
+// StartHopcroftKarp finds a maximum matching between the two sides of a bipartite graph
+func (s *Simulator) StartHopcroftKarp() {
+	s.Mode = algorithms.ModeHopcroftKarp
+	s.resetState()
+
+	neighbors := s.undirectedNeighbors()
+	s.Partition, s.Cycle = algorithms.Bipartite(neighbors, len(s.Graph.Nodes))
+	if s.Partition != nil {
+		s.Matching, s.MatchingPhases = algorithms.HopcroftKarp(neighbors, s.Partition)
+	}
+	s.Done = true
+}
+
+// StartHungarian finds a maximum matching of least total weight between the two sides
+// of a bipartite graph
+func (s *Simulator) StartHungarian() {
+	s.Mode = algorithms.ModeHungarian
+	s.resetState()
+
+	s.Partition, s.Cycle = algorithms.Bipartite(s.undirectedNeighbors(), len(s.Graph.Nodes))
+	if s.Partition != nil {
+		s.Matching, s.MatchingCost = algorithms.Hungarian(s.undirectedWeightedNeighbors(), s.Partition)
+	}
+	s.Done = true
+}
+
+// StartGreedyColoring starts a stepped greedy coloring in the given mode:
+// ModeGreedyColoring (index order), ModeWelshPowell or ModeDSatur
+// Edge directions are ignored
+func (s *Simulator) StartGreedyColoring(mode algorithms.TraversalMode) {
+	strategy := algorithms.ColoringIndex
+	switch mode {
+	case algorithms.ModeWelshPowell:
+		strategy = algorithms.ColoringWelshPowell
+	case algorithms.ModeDSatur:
+		strategy = algorithms.ColoringDSatur
+	default:
+		mode = algorithms.ModeGreedyColoring
+	}
+	s.Mode = mode
+	s.resetState()
+
+	s.greedyColoring = algorithms.NewGreedyColoringStepper(s.undirectedNeighbors(), len(s.Graph.Nodes), strategy)
+	s.NodeColors = s.greedyColoring.Colors
+	s.Done = s.greedyColoring.Done()
+}
+
+// StartExactColoring starts the stepped backtracking search for the chromatic number
+// Edge directions are ignored
+func (s *Simulator) StartExactColoring() {
+	s.Mode = algorithms.ModeExactColoring
+	s.resetState()
+
+	s.exactColoring = algorithms.NewExactColoringStepper(s.undirectedNeighbors(), len(s.Graph.Nodes))
+	s.NodeColors = s.exactColoring.Colors
+	s.Done = s.exactColoring.Done()
+}
+
+// StartEuler starts a stepped Hierholzer search for an Eulerian path or circuit
+// If the degrees rule one out, the stepper is done at once and holds the diagnosis
+func (s *Simulator) StartEuler() {
+	s.Mode = algorithms.ModeEuler
+	s.resetState()
+
+	s.hierholzer = algorithms.NewHierholzerStepper(s.Graph.Edges, len(s.Graph.Nodes), s.Graph.Directed)
+	s.Done = s.hierholzer.Done()
+	s.Path = s.hierholzer.Path()
+}
+
+// StartHamiltonian starts a stepped backtracking search for a Hamiltonian path or cycle,
+// giving up after s.HamiltonBudget path extensions
+func (s *Simulator) StartHamiltonian(cycle bool) {
+	s.Mode = algorithms.ModeHamiltonianPath
+	if cycle {
+		s.Mode = algorithms.ModeHamiltonianCycle
+	}
+	s.resetState()
+
+	s.hamiltonian = algorithms.NewHamiltonianStepper(s.Graph.GetUnweightedNeighbors(), len(s.Graph.Nodes), cycle, s.HamiltonBudget)
+	s.Done = s.hamiltonian.Done()
+}
+
+// StartCycleDetection looks for one cycle: by DFS back edges in a directed graph, or by an edge
+// to an already visited node other than the parent in an undirected one
+// Cycle holds the witness, or nil if the graph is acyclic
+func (s *Simulator) StartCycleDetection() {
+	s.Mode = algorithms.ModeCycleDetect
+	s.resetState()
+
+	neighbors := s.Graph.GetUnweightedNeighbors()
+	if s.Graph.Directed {
+		_, s.Cycle = algorithms.TopologicalSort(neighbors, len(s.Graph.Nodes))
+	} else {
+		s.Cycle = algorithms.FindUndirectedCycle(neighbors, len(s.Graph.Nodes))
+	}
+	s.Done = true
+}
+
+// StartCycleEnumeration lists up to s.CycleLimit elementary cycles with Johnson's algorithm
+// and highlights the first one
+func (s *Simulator) StartCycleEnumeration() {
+	s.Mode = algorithms.ModeCycleEnumeration
+	s.resetState()
+
+	s.Cycles, s.CyclesCapped = algorithms.ElementaryCycles(s.Graph.GetUnweightedNeighbors(), len(s.Graph.Nodes), s.Graph.Directed, s.CycleLimit)
+	s.SelectCycle(0)
+	s.Done = true
+}
+
+// SelectCycle highlights the listed cycle with the given index; out of range clears the selection
+func (s *Simulator) SelectCycle(index int) {
+	if index < 0 || index >= len(s.Cycles) {
+		s.SelectedCycle = -1
+		s.Cycle = nil
+		return
+	}
+	s.SelectedCycle = index
+	s.Cycle = s.Cycles[index]
+}
+
+// StartKShortestPaths finds the s.PathCount cheapest loopless paths from source to target with
+// Yen's algorithm and highlights the first one
+func (s *Simulator) StartKShortestPaths(source, target int) {
+	s.Mode = algorithms.ModeKShortestPaths
+	s.resetState()
+	s.Source, s.Target = source, target
+
+	s.Paths = algorithms.YenKShortestPaths(s.Graph.GetWeightedNeighbors(), source, target, len(s.Graph.Nodes), s.PathCount)
+	s.SelectPath(0)
+	s.Done = true
+}
+
+// StartAllSimplePaths lists up to s.PathLimit simple paths from source to target and
+// highlights the first one
+func (s *Simulator) StartAllSimplePaths(source, target int) {
+	s.Mode = algorithms.ModeAllSimplePaths
+	s.resetState()
+	s.Source, s.Target = source, target
+
+	s.Paths, s.PathsCapped = algorithms.AllSimplePaths(s.Graph.GetWeightedNeighbors(), source, target, len(s.Graph.Nodes), s.PathLimit)
+	s.SelectPath(0)
 	s.Done = true
 }
 
+// SelectPath highlights the listed path with the given index; out of range clears the selection
+func (s *Simulator) SelectPath(index int) {
+	if index < 0 || index >= len(s.Paths) {
+		s.SelectedPath = -1
+		s.Path = nil
+		s.PathCost = 0
+		return
+	}
+	s.SelectedPath = index
+	s.Path = s.Paths[index].Nodes
+	s.PathCost = s.Paths[index].Cost
+}
+
+// StartMetrics measures the graph and scores every node by s.CentralityKind
+func (s *Simulator) StartMetrics() {
+	s.Mode = algorithms.ModeMetrics
+	s.resetState()
+
+	stats := s.Graph.Stats()
+	s.Stats = &stats
+	s.Centrality = s.Graph.Centrality(s.CentralityKind)
+	s.Done = true
+}
+
+// StartTSP builds a travelling salesman tour from start with the algorithm of the given mode,
+// measuring distances with s.TSPMetric; 2-opt steps from the nearest-neighbor tour
+// Held-Karp leaves Tour nil on graphs larger than algorithms.HeldKarpMaxNodes, and the MST tour
+// on asymmetric distances, where its bound does not hold
+func (s *Simulator) StartTSP(mode algorithms.TraversalMode, start int) {
+	s.Mode = mode
+	s.resetState()
+	s.Source = start
+
+	dist := s.tspDistances()
+	switch mode {
+	case algorithms.ModeNearestNeighborTSP:
+		s.Tour = algorithms.NearestNeighborTour(dist, start)
+	case algorithms.ModeTwoOpt:
+		s.twoOpt = algorithms.NewTwoOptStepper(dist, algorithms.NearestNeighborTour(dist, start))
+		s.Tour = s.twoOpt.Tour
+		s.Done = s.twoOpt.Done()
+	case algorithms.ModeMSTTour:
+		if algorithms.SymmetricDistances(dist) {
+			s.Tour, s.TourTree = algorithms.MSTTour(dist, start)
+		}
+	case algorithms.ModeHeldKarp:
+		s.Tour, _ = algorithms.HeldKarp(dist, start)
+	case algorithms.ModeTSPCompare:
+		s.TSPComparison, s.TSPLowerBound = algorithms.CompareTSP(dist, start)
+		best := s.TSPComparison[0]
+		for _, stats := range s.TSPComparison[1:] {
+			if stats.Tour != nil && stats.Length <= best.Length {
+				best = stats
+			}
+		}
+		s.Tour = best.Tour
+	}
+	if s.Tour != nil {
+		s.TourLength = algorithms.TourLength(dist, s.Tour)
+	}
+	if mode != algorithms.ModeTwoOpt {
+		s.Done = true
+	}
+}
+
+// TSPSymmetric reports whether the tour distances are the same both ways, which only edge
+// weights on a directed graph can break
+func (s *Simulator) TSPSymmetric() bool {
+	return algorithms.SymmetricDistances(s.tspDistances())
+}
+
+// tspDistances returns the distance matrix for the travelling salesman algorithms
+func (s *Simulator) tspDistances() [][]float64 {
+	return algorithms.TSPDistances(s.TSPMetric, s.Graph.GetWeightedNeighbors(), s.Graph.GetPositions(), len(s.Graph.Nodes))
+}
+
+// undirectedNeighbors returns the adjacency lists with every edge in both directions
+func (s *Simulator) undirectedNeighbors() map[int][]int {
+	if !s.Graph.Directed {
+		return s.Graph.GetUnweightedNeighbors()
+	}
+	neighbors := make(map[int][]int, len(s.Graph.Nodes))
+	for _, edge := range s.Graph.Edges {
+		neighbors[edge[0]] = append(neighbors[edge[0]], edge[1])
+		neighbors[edge[1]] = append(neighbors[edge[1]], edge[0])
+	}
+	return neighbors
+}
+
+// undirectedWeightedNeighbors returns the weighted adjacency lists with every edge in both directions
+func (s *Simulator) undirectedWeightedNeighbors() map[int][]algorithms.Edge {
+	if !s.Graph.Directed {
+		return s.Graph.GetWeightedNeighbors()
+	}
+	neighbors := make(map[int][]algorithms.Edge, len(s.Graph.Nodes))
+	for _, edge := range s.Graph.Edges {
+		weight, _ := s.Graph.EdgeWeight(edge[0], edge[1])
+		neighbors[edge[0]] = append(neighbors[edge[0]], algorithms.Edge{From: edge[0], To: edge[1], Weight: weight})
+		neighbors[edge[1]] = append(neighbors[edge[1]], algorithms.Edge{From: edge[1], To: edge[0], Weight: weight})
+	}
+	return neighbors
+}
+
+// OpenMaze switches to the maze mode on the given cell map, keeping the drawn graph for CloseMaze
+// Calling it again after editing the map rebuilds the graph and clears any search
+func (s *Simulator) OpenMaze(m *graph.GridMap) {
+	if s.Maze == nil {
+		s.userGraph = s.Graph
+	}
+	s.Maze = m
+	s.Mode = algorithms.ModeMaze
+	s.resetState()
+	s.Graph = m.Graph()
+	s.Done = true // Nothing to step until a search starts
+}
+
+// CloseMaze leaves the maze mode and restores the drawn graph
+func (s *Simulator) CloseMaze() {
+	if s.Maze == nil {
+		return
+	}
+	s.Graph = s.userGraph
+	s.userGraph = graph.Graph{}
+	s.Maze = nil
+	s.Reset()
+}
+
+// StartMazeSearch starts a stepped BFS, DFS, Dijkstra or A* search from the maze start to its goal
+// A* uses the selected heuristic; custom values belong to the drawn graph, so they fall back to Euclidean
+func (s *Simulator) StartMazeSearch(kind algorithms.TraversalMode) {
+	s.Mode = algorithms.ModeMaze
+	s.resetState()
+	s.MazeSearch = kind
+	s.Source = s.Maze.Start
+	s.Target = s.Maze.Goal
+
+	neighbors := s.Graph.GetWeightedNeighbors()
+	var h algorithms.Heuristic
+	if kind == algorithms.ModeAStar {
+		h = algorithms.NewHeuristic(s.MazeHeuristic(), s.Target, s.heuristicOptions(neighbors))
+	}
+	s.mazeSearch = algorithms.NewFrontierStepper(kind, neighbors, s.Source, s.Target, h)
+}
+
+// MazeHeuristic returns the heuristic A* uses on the maze
+func (s *Simulator) MazeHeuristic() algorithms.HeuristicKind {
+	if s.Heuristic == algorithms.HeuristicCustom {
+		return algorithms.HeuristicEuclidean
+	}
+	return s.Heuristic
+}
+
+// StartFloydWarshall initializes the stepped Floyd-Warshall all-pairs algorithm
+func (s *Simulator) StartFloydWarshall() {
+	s.Mode = algorithms.ModeFloydWarshall
+	s.resetState()
+
+	neighbors := s.Graph.GetWeightedNeighbors()
+	s.floydWarshall = algorithms.NewFloydWarshallStepper(neighbors, len(s.Graph.Nodes))
+	s.AllPairs = &s.floydWarshall.AllPairsResult
+	s.Done = s.floydWarshall.Done()
+}
+
+// StartJohnson initializes the stepped Johnson all-pairs algorithm
+func (s *Simulator) StartJohnson() {
+	s.Mode = algorithms.ModeJohnson
+	s.resetState()
+
+	neighbors := s.Graph.GetWeightedNeighbors()
+	s.johnson = algorithms.NewJohnsonStepper(neighbors, len(s.Graph.Nodes))
+	s.AllPairs = &s.johnson.AllPairsResult
+	s.Done = s.johnson.Done()
+}
+
+// StartBidirectional starts a bidirectional BFS between source and target
+func (s *Simulator) StartBidirectional(source, target int) {
+	s.Mode = algorithms.ModeBidirectional
+	s.resetState()
+	s.Source = source
+	s.Target = target
+
+	s.bidirectional = algorithms.NewBidirectionalBFSStepper(s.Graph.GetUnweightedNeighbors(), source, target)
+	s.Done = s.bidirectional.Done()
+	s.Path = s.bidirectional.Path()
+}
+
+// StartDepthLimited starts a depth-limited DFS from source that stops at s.DepthLimit
+// A target of -1 explores every node within the limit
+func (s *Simulator) StartDepthLimited(source, target int) {
+	s.Mode = algorithms.ModeDepthLimited
+	s.resetState()
+	s.Source = source
+	s.Target = target
+
+	s.depthLimited = algorithms.NewDepthLimitedStepper(s.Graph.GetUnweightedNeighbors(), source, target, s.DepthLimit)
+}
+
+// StartIDDFS starts an iterative deepening DFS from source
+// A target of -1 deepens until every reachable node has been searched
+func (s *Simulator) StartIDDFS(source, target int) {
+	s.Mode = algorithms.ModeIDDFS
+	s.resetState()
+	s.Source = source
+	s.Target = target
+
+	s.iddfs = algorithms.NewIDDFSStepper(s.Graph.GetUnweightedNeighbors(), source, target, len(s.Graph.Nodes))
+}
+
+// StartUCS starts a uniform-cost search from source
+// A target of -1 expands every reachable node
+func (s *Simulator) StartUCS(source, target int) {
+	s.Mode = algorithms.ModeUCS
+	s.resetState()
+	s.Source = source
+	s.Target = target
+
+	s.ucs = algorithms.NewUCSStepper(s.Graph.GetWeightedNeighbors(), source, target)
+}
+
+// StartDFSTimes starts a recursive DFS from source that records discovery/finish times
+// and classifies every edge, restarting from undiscovered nodes until all are finished
+func (s *Simulator) StartDFSTimes(source int) {
+	s.Mode = algorithms.ModeDFSTimes
+	s.resetState()
+	s.Source = source
+
+	// The graph stores every edge in both directions
+	s.dfsTimes = algorithms.NewDFSTimesStepper(s.Graph.GetUnweightedNeighbors(), source, len(s.Graph.Nodes), s.Graph.Directed)
+	s.Done = s.dfsTimes.Done()
+}
+
 // Update performs one step of the selected algorithm
 func (s *Simulator) Update() error {
 	if s.Done || s.Mode == algorithms.ModeIdle {
@@ -219,24 +860,127 @@ func (s *Simulator) Update() error {
 		neighbors[i] = node.Neighbors
 	}
 
-	var nextNode int
-	var isDone bool
+	nextNode := -1
+	isDone := false
 
 	// Update the last active node
 	s.LastActive = s.Current
 
-	if s.Mode == algorithms.ModeBFS {
-		s.Queue, nextNode, isDone = algorithms.BFSStep(s.Queue, s.Visited, neighbors)
-	} else if s.Mode == algorithms.ModeDFS {
+	switch s.Mode {
+	case algorithms.ModeBFS:
+		s.Queue, nextNode, isDone = algorithms.BFSTreeStep(s.Queue, s.Visited, neighbors, s.Levels, s.Parents)
+	case algorithms.ModeDFS:
 		s.Stack, nextNode, isDone = algorithms.DFSStep(s.Stack, s.Visited, neighbors)
-	} else if s.Mode == algorithms.ModeAVL {
-		// AVL specific update logic will go here
-		// For now, we can just set Done to true to prevent infinite loops
-		// or handle it based on AVL operation steps.
-		isDone = true // Placeholder
+	case algorithms.ModeAVL:
+		// Step counts the steps of the last tree operation replayed so far
+		isDone = s.Step+1 >= len(s.treeSteps)
+	case algorithms.ModeFloydWarshall:
+		// The intermediate node being processed counts as visited
+		nextNode = s.floydWarshall.K
+		isDone = s.floydWarshall.Step()
+	case algorithms.ModeJohnson:
+		// Source is -1 during the Bellman-Ford reweighting pass
+		nextNode = s.johnson.Source
+		isDone = s.johnson.Step()
+	case algorithms.ModeBidirectional:
+		isDone = s.bidirectional.Step()
+		nextNode = s.bidirectional.Current
+		if isDone {
+			s.Path = s.bidirectional.Path()
+		}
+	case algorithms.ModeDepthLimited:
+		isDone = s.depthLimited.Step()
+		nextNode = s.depthLimited.Current
+		if isDone {
+			s.Path = s.depthLimited.Path()
+		}
+	case algorithms.ModeIDDFS:
+		limit := s.iddfs.Limit
+		isDone = s.iddfs.Step()
+		if s.iddfs.Limit != limit {
+			// A new iteration starts from scratch
+			s.Visited = map[int]bool{}
+			s.Order = nil
+		}
+		nextNode = s.iddfs.Current
+		if isDone {
+			s.Path = s.iddfs.Path()
+		}
+	case algorithms.ModeKruskal:
+		isDone = s.kruskal.Step()
+		s.MST = s.kruskal.MST
+	case algorithms.ModePrim:
+		isDone = s.prim.Step()
+		nextNode = s.prim.Current
+		s.MST = s.prim.MST
+	case algorithms.ModeBoruvka:
+		isDone = s.boruvka.Step()
+		s.MST = s.boruvka.MST
+	case algorithms.ModeReverseDelete:
+		isDone = s.reverseDelete.Step()
+		s.MST = s.reverseDelete.MST()
+	case algorithms.ModeDFSTimes:
+		isDone = s.dfsTimes.Step()
+		if s.dfsTimes.Action == algorithms.DFSDiscover {
+			nextNode = s.dfsTimes.Current
+		} else if s.dfsTimes.Action == algorithms.DFSFinish {
+			s.Current = s.dfsTimes.Current
+		}
+	case algorithms.ModeTarjan:
+		isDone = s.tarjan.Step()
+		if s.tarjan.Action == algorithms.TarjanVisit {
+			nextNode = s.tarjan.Current
+		}
+		s.SCCs = s.tarjan.SCCs
+	case algorithms.ModeKahn:
+		isDone = s.kahn.Step()
+		nextNode = s.kahn.Current
+		s.Cycle = s.kahn.Cycle
+		if isDone && s.Cycle == nil {
+			s.TopOrder = s.kahn.Order
+		}
+	case algorithms.ModeEdmondsKarp:
+		isDone = s.edmondsKarp.Step()
+	case algorithms.ModeGreedyColoring, algorithms.ModeWelshPowell, algorithms.ModeDSatur:
+		isDone = s.greedyColoring.Step()
+	case algorithms.ModeExactColoring:
+		isDone = s.exactColoring.Step()
+	case algorithms.ModeTwoOpt:
+		isDone = s.twoOpt.Step()
+		s.Tour = s.twoOpt.Tour
+		s.TourLength = s.twoOpt.Length
+	case algorithms.ModeEuler:
+		isDone = s.hierholzer.Step()
+		if stack := s.hierholzer.Stack; len(stack) > 0 {
+			s.Current = stack[len(stack)-1]
+		}
+		s.Path = s.hierholzer.Path()
+	case algorithms.ModeHamiltonianPath, algorithms.ModeHamiltonianCycle:
+		isDone = s.hamiltonian.Step()
+		if path := s.hamiltonian.Path; len(path) > 0 {
+			s.Current = path[len(path)-1]
+		}
+		if s.hamiltonian.Found {
+			s.Path = s.hamiltonian.Path
+		}
+	case algorithms.ModeDinic:
+		isDone = s.dinic.Step()
+	case algorithms.ModeUCS:
+		isDone = s.ucs.Step()
+		nextNode = s.ucs.Current
+		if isDone {
+			s.Path, s.PathCost = s.ucs.Path()
+		}
+	case algorithms.ModeMaze:
+		isDone = s.mazeSearch.Step()
+		nextNode = s.mazeSearch.Current
+		if isDone {
+			s.Path, s.PathCost = s.mazeSearch.Path()
+		}
 	}
 
 	s.Done = isDone
+	s.Step++
 
 	// If we found a new node to visit, add it to the order
 	if nextNode != -1 {
@@ -249,59 +993,107 @@ func (s *Simulator) Update() error {
 }
 
 // Reset clears the simulation state
+// An open maze stays open with its search cleared; CloseMaze leaves it
 func (s *Simulator) Reset() {
 	s.Mode = algorithms.ModeIdle
-	s.Queue = nil
-	s.Stack = nil
-	s.Order = nil
-	s.Visited = map[int]bool{}
-	s.Current = -1
-	s.LastActive = -1
-	s.Done = false
-
-	// Clear algorithm results
-	s.ShortestPaths = nil
-	s.Predecessors = nil
-	s.Path = nil
-	s.MST = nil
-	s.SCCs = nil
-	s.TopOrder = nil
+	s.resetState()
+	if s.Maze != nil {
+		s.Mode = algorithms.ModeMaze
+		s.Done = true
+	}
 }
 
-// UpdateAVL updates the AVL tree visualization
+// UpdateAVL updates the tree visualization
 func (s *Simulator) UpdateAVL() {
-	if s.avlTree == nil {
+	if s.tree == nil {
 		return
 	}
 
-	// Update node positions for visualization
-	s.avlTree.UpdatePositions(400, 50, 60) // Center of screen, starting Y, level height
+	// Center on a default window; the drawer lays the tree out again for the current window
+	algorithms.UpdateTreePositions(s.tree.TreeRoot(), 400, 50, 60) // Center X, starting Y, level height
 }
 
-// InsertAVL inserts a value into the AVL tree
+// InsertAVL inserts a value into the tree
 func (s *Simulator) InsertAVL(value int) {
-	if s.avlTree == nil {
+	if s.tree == nil {
 		s.StartAVL()
 	}
-	s.avlTree.Insert(value)
+	s.runTreeOp(algorithms.TreeOp{Action: "insert", Key: value})
 	s.UpdateAVL()
 }
 
-// DeleteAVL deletes a value from the AVL tree
+// DeleteAVL deletes a value from the tree
 func (s *Simulator) DeleteAVL(value int) {
-	if s.avlTree == nil {
+	if s.tree == nil {
 		return
 	}
-	s.avlTree.Delete(value)
+	s.runTreeOp(algorithms.TreeOp{Action: "delete", Key: value})
 	s.UpdateAVL()
 }
 
-// SearchAVL searches for a value in the AVL tree
-func (s *Simulator) SearchAVL(value int) *algorithms.AVLNode {
-	if s.avlTree == nil {
+// SearchAVL reports whether the tree holds a value
+func (s *Simulator) SearchAVL(value int) bool {
+	if s.tree == nil {
+		return false
+	}
+	s.runTreeOp(algorithms.TreeOp{Action: "search", Key: value})
+	for _, step := range s.treeSteps {
+		if step.Kind == algorithms.StepFound {
+			return true
+		}
+	}
+	return false
+}
+
+// runTreeOp runs an operation on the tree and queues its steps, so Step and Auto replay it
+// from the tree as it was before
+func (s *Simulator) runTreeOp(op algorithms.TreeOp) {
+	// Every operation ends with a step holding a copy of the tree it leaves
+	var before algorithms.TreeNode
+	if steps := s.tree.Steps(); len(steps) > 0 {
+		before = steps[len(steps)-1].Tree
+	}
+
+	op.Apply(s.tree)
+	s.TreeOps = append(s.TreeOps, op)
+	s.TreeComparison = algorithms.CompareTrees(s.TreeOps, s.BTreeOrder)
+
+	s.treeBefore = before
+	s.treeSteps = s.tree.Steps()
+	s.Step = 0
+	s.Done = len(s.treeSteps) == 0
+}
+
+// GetTree returns the tree of the tree mode
+func (s *Simulator) GetTree() algorithms.SearchTree {
+	return s.tree
+}
+
+// GetTreeSteps returns the steps of the last tree operation
+func (s *Simulator) GetTreeSteps() []algorithms.TreeStep {
+	return s.treeSteps
+}
+
+// CurrentTreeStep returns the last replayed step of the tree operation, or nil before the first
+func (s *Simulator) CurrentTreeStep() *algorithms.TreeStep {
+	if s.Step == 0 || s.Step > len(s.treeSteps) {
+		return nil
+	}
+	return &s.treeSteps[s.Step-1]
+}
+
+// DisplayTree returns the tree as it stands at the current replay step
+func (s *Simulator) DisplayTree() algorithms.TreeNode {
+	if step := s.CurrentTreeStep(); step != nil {
+		return step.Tree
+	}
+	if s.treeSteps != nil {
+		return s.treeBefore
+	}
+	if s.tree == nil {
 		return nil
 	}
-	return s.avlTree.Search(value)
+	return s.tree.TreeRoot()
 }
 
 // GetMode returns the current mode
@@ -309,9 +1101,10 @@ func (s *Simulator) GetMode() algorithms.TraversalMode {
 	return s.Mode
 }
 
-// GetAVLTree returns the AVL tree
+// GetAVLTree returns the tree when it is an AVL tree, nil otherwise
 func (s *Simulator) GetAVLTree() *algorithms.AVLTree {
-	return s.avlTree
+	avl, _ := s.tree.(*algorithms.AVLTree)
+	return avl
 }
 
 // GetAVLAction returns the current AVL action
@@ -351,6 +1144,25 @@ func (s *Simulator) GetShortestPaths() map[int]float64 {
 	return s.ShortestPaths
 }
 
+// ShortestPathTo returns the Dijkstra shortest path from the source to target and its cost
+// Returns nil and +Inf if Dijkstra has not run or the target is unreachable
+func (s *Simulator) ShortestPathTo(target int) ([]int, float64) {
+	dist, exists := s.ShortestPaths[target]
+	if !exists || math.IsInf(dist, 1) {
+		return nil, math.Inf(1)
+	}
+	return algorithms.PathFromPredecessors(s.Predecessors, target), dist
+}
+
+// BFSPathTo returns the unweighted shortest path from the BFS start to target,
+// or nil if BFS has not discovered target yet
+func (s *Simulator) BFSPathTo(target int) []int {
+	if _, seen := s.Levels[target]; !seen {
+		return nil
+	}
+	return algorithms.PathFromPredecessors(s.Parents, target)
+}
+
 // GetPath returns the path found by A*
 func (s *Simulator) GetPath() []int {
 	return s.Path
@@ -366,11 +1178,126 @@ func (s *Simulator) GetSCCs() [][]int {
 	return s.SCCs
 }
 
-// GetTopologicalOrder returns the topological ordering
+// GetTopologicalOrder returns the topological ordering, or nil if the graph has a cycle
 func (s *Simulator) GetTopologicalOrder() []int {
 	return s.TopOrder
 }
 
+// GetCycle returns the witness cycle found by a failed topological sort, or nil
+func (s *Simulator) GetCycle() []int {
+	return s.Cycle
+}
+
+// GetKahn returns the Kahn topological sort stepper, or nil outside that mode
+func (s *Simulator) GetKahn() *algorithms.KahnStepper {
+	return s.kahn
+}
+
+// GetGreedyColoring returns the greedy coloring stepper, or nil outside the greedy coloring modes
+func (s *Simulator) GetGreedyColoring() *algorithms.GreedyColoringStepper {
+	return s.greedyColoring
+}
+
+// GetHierholzer returns the Eulerian path stepper, or nil outside that mode
+func (s *Simulator) GetHierholzer() *algorithms.HierholzerStepper {
+	return s.hierholzer
+}
+
+// GetHamiltonian returns the Hamiltonian search stepper, or nil outside the Hamiltonian modes
+func (s *Simulator) GetHamiltonian() *algorithms.HamiltonianStepper {
+	return s.hamiltonian
+}
+
+// GetTwoOpt returns the 2-opt stepper, or nil outside that mode
+func (s *Simulator) GetTwoOpt() *algorithms.TwoOptStepper {
+	return s.twoOpt
+}
+
+// GetExactColoring returns the exact coloring stepper, or nil outside that mode
+func (s *Simulator) GetExactColoring() *algorithms.ExactColoringStepper {
+	return s.exactColoring
+}
+
+// GetAllPairs returns the all-pairs distance and next-hop matrices
+func (s *Simulator) GetAllPairs() *algorithms.AllPairsResult {
+	return s.AllPairs
+}
+
+// GetFloydWarshall returns the Floyd-Warshall stepper, or nil outside that mode
+func (s *Simulator) GetFloydWarshall() *algorithms.FloydWarshallStepper {
+	return s.floydWarshall
+}
+
+// GetJohnson returns the Johnson stepper, or nil outside that mode
+func (s *Simulator) GetJohnson() *algorithms.JohnsonStepper {
+	return s.johnson
+}
+
+// GetBidirectional returns the bidirectional BFS stepper, or nil outside that mode
+func (s *Simulator) GetBidirectional() *algorithms.BidirectionalBFSStepper {
+	return s.bidirectional
+}
+
+// GetDepthLimited returns the depth-limited DFS stepper, or nil outside that mode
+func (s *Simulator) GetDepthLimited() *algorithms.DepthLimitedStepper {
+	return s.depthLimited
+}
+
+// GetIDDFS returns the iterative deepening DFS stepper, or nil outside that mode
+func (s *Simulator) GetIDDFS() *algorithms.IDDFSStepper {
+	return s.iddfs
+}
+
+// GetUCS returns the uniform-cost search stepper, or nil outside that mode
+func (s *Simulator) GetUCS() *algorithms.UCSStepper {
+	return s.ucs
+}
+
+// GetTarjan returns the Tarjan SCC stepper, or nil outside that mode
+func (s *Simulator) GetTarjan() *algorithms.TarjanStepper {
+	return s.tarjan
+}
+
+// GetEdmondsKarp returns the Edmonds-Karp max-flow stepper, or nil outside that mode
+func (s *Simulator) GetEdmondsKarp() *algorithms.EdmondsKarpStepper {
+	return s.edmondsKarp
+}
+
+// GetDinic returns the Dinic max-flow stepper, or nil outside that mode
+func (s *Simulator) GetDinic() *algorithms.DinicStepper {
+	return s.dinic
+}
+
+// GetDFSTimes returns the DFS edge classification stepper, or nil outside that mode
+func (s *Simulator) GetDFSTimes() *algorithms.DFSTimesStepper {
+	return s.dfsTimes
+}
+
+// GetKruskal returns the Kruskal stepper, or nil outside that mode
+func (s *Simulator) GetKruskal() *algorithms.KruskalStepper {
+	return s.kruskal
+}
+
+// GetPrim returns the Prim stepper, or nil outside that mode
+func (s *Simulator) GetPrim() *algorithms.PrimStepper {
+	return s.prim
+}
+
+// GetBoruvka returns the Borůvka stepper, or nil outside that mode
+func (s *Simulator) GetBoruvka() *algorithms.BoruvkaStepper {
+	return s.boruvka
+}
+
+// GetReverseDelete returns the reverse-delete stepper, or nil outside that mode
+func (s *Simulator) GetReverseDelete() *algorithms.ReverseDeleteStepper {
+	return s.reverseDelete
+}
+
+// GetMazeSearch returns the maze search stepper, or nil if no search is running
+func (s *Simulator) GetMazeSearch() *algorithms.FrontierStepper {
+	return s.mazeSearch
+}
+
 // resetState resets common simulation state
 func (s *Simulator) resetState() {
 	s.Queue = nil
@@ -380,10 +1307,63 @@ func (s *Simulator) resetState() {
 	s.Current = -1
 	s.LastActive = -1
 	s.Done = false
+	s.Step = 0
+	s.Target = -1
 	s.ShortestPaths = nil
 	s.Predecessors = nil
 	s.Path = nil
 	s.MST = nil
 	s.SCCs = nil
+	s.Condensation = nil
+	s.ComponentOf = nil
 	s.TopOrder = nil
+	s.Cycle = nil
+	s.Cycles = nil
+	s.CyclesCapped = false
+	s.SelectedCycle = -1
+	s.Paths = nil
+	s.PathsCapped = false
+	s.SelectedPath = -1
+	s.Components = nil
+	s.Connectivity = nil
+	s.Partition = nil
+	s.Matching = nil
+	s.MatchingCost = 0
+	s.MatchingPhases = 0
+	s.NodeColors = nil
+	s.Tour = nil
+	s.TourLength = 0
+	s.TourTree = nil
+	s.TSPComparison = nil
+	s.TSPLowerBound = 0
+	s.Stats = nil
+	s.Centrality = nil
+	s.AllPairs = nil
+	s.PathCost = 0
+	s.MSTComparison = nil
+	s.Levels = nil
+	s.Parents = nil
+	s.HeuristicValues = nil
+	s.HeuristicComparison = nil
+	s.floydWarshall = nil
+	s.johnson = nil
+	s.bidirectional = nil
+	s.depthLimited = nil
+	s.iddfs = nil
+	s.ucs = nil
+	s.dfsTimes = nil
+	s.kruskal = nil
+	s.prim = nil
+	s.boruvka = nil
+	s.reverseDelete = nil
+	s.tarjan = nil
+	s.kahn = nil
+	s.greedyColoring = nil
+	s.exactColoring = nil
+	s.hierholzer = nil
+	s.hamiltonian = nil
+	s.twoOpt = nil
+	s.edmondsKarp = nil
+	s.dinic = nil
+	s.mazeSearch = nil
 }
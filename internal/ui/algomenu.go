@@ -0,0 +1,428 @@
+package ui
+
+import (
+	"fmt"
+
+	"bfsdfs/internal/algorithms"
+)
+
+// algorithmEntry describes an algorithm that can be launched from the Algorithms menu
+type algorithmEntry struct {
+	Category string
+	Label    string
+	Start    func()
+	Setting  bool // Adjusts a parameter instead of starting an algorithm, so it is allowed at any time
+}
+
+// algorithmEntries returns the algorithms offered by the Algorithms menu, grouped by category
+func (g *Game) algorithmEntries() []algorithmEntry {
+	entries := []algorithmEntry{
+		{Category: "Traversal", Label: "DFS (edge classification)", Start: func() {
+			if g.StartNode < 0 || g.StartNode >= len(g.Sim.Graph.Nodes) {
+				g.showMessage("Please select a start node first")
+				return
+			}
+			g.Sim.StartDFSTimes(g.StartNode)
+			g.showMessage("DFS from " + string(rune('A'+g.StartNode)) + ". Each step discovers a node, classifies an edge or finishes a node.")
+		}},
+		{Category: "Shortest Paths", Label: "Dijkstra", Start: func() {
+			if g.StartNode < 0 || g.StartNode >= len(g.Sim.Graph.Nodes) {
+				g.showMessage("Please select a start node first")
+				return
+			}
+			g.Sim.StartDijkstra(g.StartNode)
+			g.updateTargetPath()
+			if g.TargetNode == -1 {
+				g.showMessage("Dijkstra from " + string(rune('A'+g.StartNode)) + ". Click a node to pick a target.")
+			}
+		}},
+		{Category: "Shortest Paths", Label: "Floyd-Warshall", Start: func() {
+			g.Sim.StartFloydWarshall()
+			g.showMessage("Floyd-Warshall started. Step through the k loop.")
+		}},
+		{Category: "Shortest Paths", Label: "Johnson", Start: func() {
+			g.Sim.StartJohnson()
+			g.showMessage("Johnson started. First step reweights the edges.")
+		}},
+	}
+
+	entries = append(entries, algorithmEntry{Category: "Minimum Spanning Tree", Label: "Kruskal", Start: func() {
+		g.Sim.StartKruskal()
+		g.showMessage("Kruskal started. Each step considers the next cheapest edge.")
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Minimum Spanning Tree", Label: "Prim", Start: func() {
+		if g.StartNode < 0 || g.StartNode >= len(g.Sim.Graph.Nodes) {
+			g.showMessage("Please select a start node first")
+			return
+		}
+		g.Sim.StartPrimFrom(g.StartNode)
+		g.showMessage("Prim from " + string(rune('A'+g.StartNode)) + ". Each step adds the lightest edge crossing the cut.")
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Minimum Spanning Tree", Label: "Boruvka", Start: func() {
+		g.Sim.StartBoruvka()
+		g.showMessage("Boruvka started. Each step adds the cheapest edge leaving every component.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Minimum Spanning Tree", Label: "Reverse-Delete", Start: func() {
+		g.Sim.StartReverseDelete()
+		g.showMessage("Reverse-delete started. Each step tries to delete the heaviest remaining edge.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Minimum Spanning Tree", Label: "Compare All", Start: func() {
+		root := g.StartNode
+		if root < 0 || root >= len(g.Sim.Graph.Nodes) {
+			root = 0
+		}
+		g.Sim.StartMSTComparison(root)
+		g.showMessage("Edges chosen by only some algorithms are dashed orange.")
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Connectivity", Label: "Connected Components", Start: func() {
+		g.Sim.StartConnectedComponents()
+		g.showMessage(fmt.Sprintf("%d connected components", len(g.Sim.Components)))
+	}})
+	entries = append(entries, algorithmEntry{Category: "Connectivity", Label: "Bridges & Cut Vertices", Start: func() {
+		g.Sim.StartBridges()
+		g.showMessage("Bridges are red; cut vertices have square frames")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Connectivity", Label: "Biconnected Components", Start: func() {
+		g.Sim.StartBiconnected()
+		g.showMessage(fmt.Sprintf("%d biconnected components", len(g.Sim.Connectivity.Blocks)))
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Matching", Label: "Bipartite Check", Start: func() {
+		g.Sim.StartBipartite()
+		g.showMatchingMessage("Nodes are colored by side")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Matching", Label: "Hopcroft-Karp", Start: func() {
+		g.Sim.StartHopcroftKarp()
+		g.showMatchingMessage(fmt.Sprintf("Maximum matching of %d edges", len(matchedPairs(g.Sim.Matching, g.Sim.Partition))))
+	}})
+	entries = append(entries, algorithmEntry{Category: "Matching", Label: "Hungarian (min weight)", Start: func() {
+		g.Sim.StartHungarian()
+		g.showMatchingMessage(fmt.Sprintf("Assignment of total weight %.1f", g.Sim.MatchingCost))
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Coloring", Label: "Greedy (index order)", Start: func() {
+		g.Sim.StartGreedyColoring(algorithms.ModeGreedyColoring)
+		g.showMessage("Greedy coloring started. Each step colors the next node.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Coloring", Label: "Welsh-Powell", Start: func() {
+		g.Sim.StartGreedyColoring(algorithms.ModeWelshPowell)
+		g.showMessage("Welsh-Powell started. Nodes are colored by decreasing degree.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Coloring", Label: "DSatur", Start: func() {
+		g.Sim.StartGreedyColoring(algorithms.ModeDSatur)
+		g.showMessage("DSatur started. The most constrained node is colored next.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Coloring", Label: "Exact (backtracking)", Start: func() {
+		g.Sim.StartExactColoring()
+		g.showMessage("Exact coloring started. Each step assigns or undoes one color.")
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Euler & Hamilton", Label: "Eulerian Path/Circuit", Start: func() {
+		g.Sim.StartEuler()
+		if h := g.Sim.GetHierholzer(); h.Diagnosis.Kind == algorithms.EulerNone {
+			g.showMessage("No route uses every edge exactly once; the red nodes break the degree rule")
+			return
+		}
+		g.showMessage("Hierholzer started. Each step walks an edge or moves a stuck node onto the trail.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Euler & Hamilton", Label: "Hamiltonian Path", Start: func() {
+		g.Sim.StartHamiltonian(false)
+		g.showMessage("Backtracking search started. Each step visits or abandons one node.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Euler & Hamilton", Label: "Hamiltonian Cycle", Start: func() {
+		g.Sim.StartHamiltonian(true)
+		g.showMessage("Backtracking search started. Each step visits or abandons one node.")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Euler & Hamilton", Label: "Set Search Budget...", Setting: true, Start: func() {
+		g.openHamiltonBudgetInput()
+	}})
+
+	// Centralities are settings so that they can be switched while the metrics are shown
+	for _, kind := range algorithms.Centralities {
+		kind := kind
+		entries = append(entries, algorithmEntry{Category: "Graph Metrics", Label: kind.String() + " Centrality", Setting: true, Start: func() {
+			g.showCentrality(kind)
+		}})
+	}
+	metricDisplayLabel := "Show as Node Size"
+	if g.MetricAsSize {
+		metricDisplayLabel = "Show as Node Color"
+	}
+	entries = append(entries, algorithmEntry{Category: "Graph Metrics", Label: metricDisplayLabel, Setting: true, Start: func() {
+		g.MetricAsSize = !g.MetricAsSize
+		g.canvasNeedsRedraw = true
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Cycles", Label: "Detect a Cycle", Start: func() {
+		g.Sim.StartCycleDetection()
+		if g.Sim.Cycle == nil {
+			g.showMessage("The graph has no cycles")
+		}
+	}})
+	entries = append(entries, algorithmEntry{Category: "Cycles", Label: "Elementary Cycles (Johnson)", Start: func() {
+		g.startCycleEnumeration()
+	}})
+	entries = append(entries, algorithmEntry{Category: "Cycles", Label: "Set Cycle Limit...", Setting: true, Start: func() {
+		g.openCycleLimitInput()
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Topological Sort", Label: "DFS Order", Start: func() {
+		g.Sim.StartTopological()
+		if g.Sim.Cycle != nil {
+			g.showMessage("The graph has a cycle, so no topological order exists")
+		}
+	}})
+	entries = append(entries, algorithmEntry{Category: "Topological Sort", Label: "Kahn", Start: func() {
+		g.Sim.StartKahn()
+		g.showMessage("Kahn started. Each step outputs a node with in-degree zero.")
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Strongly Connected Components", Label: "Tarjan", Start: func() {
+		g.Sim.StartTarjan()
+		g.showMessage("Tarjan started. Nodes are labelled index/lowlink.")
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Strongly Connected Components", Label: "Kosaraju", Start: func() {
+		g.Sim.StartKosaraju()
+		g.showMessage(fmt.Sprintf("Kosaraju found %d strongly connected components", len(g.Sim.SCCs)))
+	}})
+	entries = append(entries, algorithmEntry{Category: "Strongly Connected Components", Label: "Condensation DAG", Setting: true, Start: func() {
+		if !g.Sim.BuildCondensation() {
+			g.showMessage("Run Tarjan or Kosaraju to completion first")
+			return
+		}
+		g.showMessage("Each component is collapsed into one node of the condensation DAG")
+	}})
+	directedLabel := "Make Edges Directed"
+	if g.Sim.Graph.Directed {
+		directedLabel = "Make Edges Undirected"
+	}
+	entries = append(entries, algorithmEntry{Category: "Strongly Connected Components", Label: directedLabel, Setting: true, Start: func() {
+		g.Sim.Graph.SetDirected(!g.Sim.Graph.Directed)
+		g.Sim.Reset()
+		g.canvasNeedsRedraw = true
+		if g.Sim.Graph.Directed {
+			g.showMessage("Edges now run in the direction they were drawn")
+		} else {
+			g.showMessage("Edges now run both ways")
+		}
+	}})
+
+	// One A* entry per heuristic, so the comparison table can be explored from any of them
+	for _, kind := range algorithms.HeuristicKinds {
+		kind := kind
+		entries = append(entries, algorithmEntry{Category: "A* Search", Label: kind.String(), Start: func() {
+			g.Sim.Heuristic = kind
+			g.runAStar(g.StartNode)
+		}})
+	}
+	entries = append(entries, algorithmEntry{Category: "A* Search", Label: "Set Epsilon...", Setting: true, Start: func() {
+		g.openHeuristicInput("epsilon", -1)
+	}})
+
+	for _, mode := range searchModes {
+		mode := mode
+		entries = append(entries, algorithmEntry{Category: "Search", Label: mode.String(), Start: func() {
+			g.startSearch(mode, g.StartNode)
+		}})
+	}
+	for _, mode := range flowModes {
+		mode := mode
+		entries = append(entries, algorithmEntry{Category: "Max Flow", Label: mode.String(), Start: func() {
+			g.startFlow(mode, g.StartNode)
+		}})
+	}
+	residualLabel := "Show Residual Graph"
+	if g.ShowResidual {
+		residualLabel = "Show Flow/Capacity"
+	}
+	entries = append(entries, algorithmEntry{Category: "Max Flow", Label: residualLabel, Setting: true, Start: func() {
+		g.ShowResidual = !g.ShowResidual
+	}})
+
+	for _, mode := range pathListModes {
+		mode := mode
+		entries = append(entries, algorithmEntry{Category: "Paths", Label: mode.String(), Start: func() {
+			g.startPathList(mode, g.StartNode)
+		}})
+	}
+	entries = append(entries, algorithmEntry{Category: "Paths", Label: "Set K...", Setting: true, Start: func() {
+		g.openPathListInput("path count")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Paths", Label: "Set Path Limit...", Setting: true, Start: func() {
+		g.openPathListInput("path limit")
+	}})
+
+	symmetric := g.Sim.TSPSymmetric()
+	for _, mode := range tspModes {
+		mode := mode
+		if mode == algorithms.ModeMSTTour && !symmetric {
+			continue // Its bound needs the same distance both ways
+		}
+		entries = append(entries, algorithmEntry{Category: "Travelling Salesman", Label: mode.String(), Start: func() {
+			g.startTSP(mode, g.StartNode)
+		}})
+	}
+	metricLabel := "Use Edge Weights"
+	if g.Sim.TSPMetric == algorithms.TSPEdgeWeights {
+		metricLabel = "Use Straight-Line Distance"
+	}
+	entries = append(entries, algorithmEntry{Category: "Travelling Salesman", Label: metricLabel, Setting: true, Start: func() {
+		if g.Sim.TSPMetric == algorithms.TSPEdgeWeights {
+			g.Sim.TSPMetric = algorithms.TSPStraightLine
+		} else {
+			g.Sim.TSPMetric = algorithms.TSPEdgeWeights
+		}
+		g.canvasNeedsRedraw = true
+		g.showMessage("Tours now measure " + g.Sim.TSPMetric.String())
+		for _, mode := range tspModes {
+			if g.Sim.Mode == mode {
+				g.startTSP(mode, g.Sim.Source)
+			}
+		}
+	}})
+
+	entries = append(entries, algorithmEntry{Category: "Search", Label: "Set Depth Limit...", Setting: true, Start: func() {
+		g.openDepthLimitInput()
+	}})
+
+	// The maze entries manage the maze mode themselves, so they are all settings
+	if g.Sim.Maze == nil {
+		entries = append(entries, algorithmEntry{Category: "Maze", Label: "Open Maze Grid", Setting: true, Start: g.openMaze})
+	} else {
+		entries = append(entries, algorithmEntry{Category: "Maze", Label: "Close Maze Grid", Setting: true, Start: g.closeMaze})
+	}
+	for _, kind := range mazeSearches {
+		kind := kind
+		entries = append(entries, algorithmEntry{Category: "Maze", Label: "Run " + kind.String(), Setting: true, Start: func() {
+			g.startMazeSearch(kind)
+		}})
+	}
+	for _, kind := range algorithms.MazeKinds {
+		kind := kind
+		entries = append(entries, algorithmEntry{Category: "Maze", Label: "Generate: " + kind.String(), Setting: true, Start: func() {
+			g.generateMaze(kind)
+		}})
+	}
+	for _, brush := range mazeBrushes {
+		brush := brush
+		label := "Brush: " + brush.String()
+		if brush == g.MazeBrush {
+			label += " (selected)"
+		}
+		entries = append(entries, algorithmEntry{Category: "Maze", Label: label, Setting: true, Start: func() {
+			g.MazeBrush = brush
+			g.showMessage("The left button paints " + brush.String())
+		}})
+	}
+	entries = append(entries, algorithmEntry{Category: "Maze", Label: "Set Terrain Cost...", Setting: true, Start: func() {
+		g.openTerrainCostInput()
+	}})
+	diagonalLabel := "Use 8-Connectivity"
+	if g.Sim.Maze != nil && g.Sim.Maze.Diagonal {
+		diagonalLabel = "Use 4-Connectivity"
+	}
+	entries = append(entries, algorithmEntry{Category: "Maze", Label: diagonalLabel, Setting: true, Start: func() {
+		g.setMazeDiagonal(diagonalLabel == "Use 8-Connectivity")
+	}})
+	entries = append(entries, algorithmEntry{Category: "Maze", Label: "Clear Maze", Setting: true, Start: g.clearMaze})
+
+	// The tree entries switch the tree mode themselves, rebuilding the tree from its operations
+	for _, kind := range algorithms.TreeKinds {
+		kind := kind
+		label := kind.String()
+		if g.Sim.Mode == algorithms.ModeAVL && kind == g.Sim.TreeKind {
+			label += " (selected)"
+		}
+		entries = append(entries, algorithmEntry{Category: "Trees", Label: label, Setting: true, Start: func() {
+			g.selectTree(kind)
+		}})
+	}
+	entries = append(entries, algorithmEntry{Category: "Trees", Label: "Set B-Tree Order...", Setting: true, Start: func() {
+		g.openBTreeOrderInput()
+	}})
+	entries = append(entries, algorithmEntry{Category: "Trees", Label: fmt.Sprintf("Insert 1 to %d in Order", treeSequenceLength), Setting: true, Start: func() {
+		g.insertTreeSequence(ascendingTreeSequence(), fmt.Sprintf("1 to %d in order", treeSequenceLength))
+	}})
+	entries = append(entries, algorithmEntry{Category: "Trees", Label: fmt.Sprintf("Insert %d Random Values", treeSequenceLength), Setting: true, Start: func() {
+		g.insertTreeSequence(randomTreeSequence(), fmt.Sprintf("%d random values", treeSequenceLength))
+	}})
+
+	return entries
+}
+
+// openAlgorithmMenu shows the algorithm categories just above the given screen position
+func (g *Game) openAlgorithmMenu(x, y int) {
+	g.ContextMenu.ClearItems()
+
+	seen := map[string]bool{}
+	for _, entry := range g.algorithmEntries() {
+		if seen[entry.Category] {
+			continue
+		}
+		seen[entry.Category] = true
+
+		category := entry.Category
+		g.ContextMenu.AddItem(category+" >", func() {
+			g.openAlgorithmCategory(category, x, y)
+		})
+	}
+
+	g.showMenuAbove(x, y)
+}
+
+// openAlgorithmCategory shows the algorithms of one category
+func (g *Game) openAlgorithmCategory(category string, x, y int) {
+	g.ContextMenu.ClearItems()
+	g.ContextMenu.AddItem("< Back", func() {
+		g.openAlgorithmMenu(x, y)
+	})
+
+	for _, entry := range g.algorithmEntries() {
+		if entry.Category != category {
+			continue
+		}
+		start := entry.Start
+		if entry.Setting {
+			g.ContextMenu.AddItem(entry.Label, start)
+			continue
+		}
+		g.ContextMenu.AddItem(entry.Label, func() {
+			g.startAlgorithm(start)
+		})
+	}
+
+	g.showMenuAbove(x, y)
+}
+
+// showMenuAbove displays the context menu so that its bottom edge sits at y
+func (g *Game) showMenuAbove(x, y int) {
+	top := y - len(g.ContextMenu.Items)*g.ContextMenu.ItemHeight
+	if top < 0 {
+		top = 0
+	}
+	g.ContextMenu.Show(x, top, -1)
+}
+
+// startAlgorithm launches an algorithm from the idle state
+func (g *Game) startAlgorithm(start func()) {
+	if g.Sim.Maze != nil {
+		g.showMessage("Close the maze grid first: Algorithms > Maze > Close Maze Grid")
+		return
+	}
+	if g.Sim.Mode != algorithms.ModeIdle {
+		g.showMessage("Reset first to start another algorithm.")
+		return
+	}
+	if len(g.Sim.Graph.Nodes) == 0 {
+		g.showMessage("Add some nodes first.")
+		return
+	}
+
+	g.AutoStep = false
+	g.clearHighlights()
+	start()
+}
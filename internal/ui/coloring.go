@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// nodePaletteColor returns the palette color the current coloring gave a node, if any
+func (g *Game) nodePaletteColor(node int) (color.RGBA, bool) {
+	if node >= len(g.Sim.NodeColors) || g.Sim.NodeColors[node] < 0 {
+		return color.RGBA{}, false
+	}
+	return paletteColor(g.Sim.NodeColors[node]), true
+}
+
+// coloringCurrent returns the node the last coloring step changed and the ring color to mark it with
+func (g *Game) coloringCurrent() (int, color.Color) {
+	if c := g.Sim.GetGreedyColoring(); c != nil {
+		return c.Current, color.RGBA{40, 40, 40, 255}
+	}
+	if e := g.Sim.GetExactColoring(); e != nil && !e.Done() {
+		if e.Action == algorithms.ColoringBacktrack {
+			return e.Current, color.RGBA{220, 20, 60, 255}
+		}
+		return e.Current, color.RGBA{40, 40, 40, 255}
+	}
+	return -1, nil
+}
+
+// drawColoringRing rings the node the last step colored, or in red the node it backtracked from
+func (g *Game) drawColoringRing(canvas *ebiten.Image) {
+	if node, clr := g.coloringCurrent(); node != -1 {
+		g.drawNodeRing(canvas, node, clr)
+	}
+}
+
+// drawColoringLabels labels colored nodes with their color number, and during DSatur the
+// uncolored nodes with their saturation
+func (g *Game) drawColoringLabels(canvas *ebiten.Image) {
+	for v, c := range g.Sim.NodeColors {
+		if c >= 0 {
+			g.drawNodeText(canvas, v, fmt.Sprintf("c%d", c+1), 22, -14, color.RGBA{0, 0, 200, 255})
+		}
+	}
+	if c := g.Sim.GetGreedyColoring(); c != nil && c.Strategy == algorithms.ColoringDSatur {
+		for v, assigned := range c.Colors {
+			if assigned < 0 {
+				g.drawNodeText(canvas, v, fmt.Sprintf("sat:%d", c.Saturation[v]), 22, -14, color.RGBA{200, 0, 0, 255})
+			}
+		}
+	}
+}
+
+// coloringStatus describes the progress of the current coloring mode in up to three lines
+func (g *Game) coloringStatus() (status, detail, extra string) {
+	if c := g.Sim.GetGreedyColoring(); c != nil {
+		status = fmt.Sprintf("%s: %d of %d nodes colored with %d colors", g.Sim.Mode, len(c.Order), len(c.Colors), c.NumColors)
+		if c.Current != -1 {
+			detail = fmt.Sprintf("Gave %s color %d, the smallest none of its neighbors has", string(rune('A'+c.Current)), c.Colors[c.Current]+1)
+		}
+		if c.Done() {
+			detail = fmt.Sprintf("Done: %d colors. Greedy colorings are not always optimal; try Coloring > Exact", c.NumColors)
+		}
+		switch c.Strategy {
+		case algorithms.ColoringIndex:
+			extra = "Nodes are colored in index order"
+		case algorithms.ColoringWelshPowell:
+			extra = "Nodes are colored by decreasing degree"
+		case algorithms.ColoringDSatur:
+			extra = "Next: the uncolored node with the most distinct neighbor colors (sat), ties by degree"
+		}
+		return
+	}
+
+	e := g.Sim.GetExactColoring()
+	if e == nil {
+		return
+	}
+	status = fmt.Sprintf("Exact coloring: trying %d colors, %d of %d nodes colored, step %d", e.K, e.Position, len(e.Colors), e.Steps)
+	node := ""
+	if e.Current != -1 {
+		node = string(rune('A' + e.Current))
+	}
+	switch e.Action {
+	case algorithms.ColoringAssign:
+		detail = fmt.Sprintf("Gave %s color %d", node, e.Colors[e.Current]+1)
+	case algorithms.ColoringBacktrack:
+		detail = fmt.Sprintf("No color fits %s: backtracking", node)
+	case algorithms.ColoringExhausted:
+		detail = fmt.Sprintf("Every way to use %d colors fails, so at least %d are needed", e.K-1, e.K)
+	case algorithms.ColoringFound:
+		detail = fmt.Sprintf("Chromatic number %d: every smaller number of colors was ruled out", e.K)
+	}
+	extra = "Nodes are tried by decreasing degree; a node may open at most one new color"
+	return
+}
+
+// drawColoringPanel lists the degree, saturation and color of every node during greedy coloring,
+// highlighting the node colored last
+func drawColoringPanel(screen *ebiten.Image, c *algorithms.GreedyColoringStepper) {
+	header := []string{"Node", "Degree", "Sat", "Color"}
+	rows := make([][]string, len(c.Colors))
+	for v, assigned := range c.Colors {
+		colorText := "-"
+		if assigned >= 0 {
+			colorText = fmt.Sprintf("%d", assigned+1)
+		}
+		rows[v] = []string{string(rune('A' + v)), fmt.Sprintf("%d", c.Degree[v]), fmt.Sprintf("%d", c.Saturation[v]), colorText}
+	}
+	drawTablePanel(screen, fmt.Sprintf("Coloring order: %s", formatNodeList(c.Order)), header, rows, c.Current)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/internal/graph"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// drawCondensationPanel draws the condensation DAG as a small graph anchored to the top-right corner
+// of the screen, one super-node per component in the component's color, labelled with its members
+// Super-nodes keep the relative positions of their members' centroids
+func drawCondensationPanel(screen *ebiten.Image, dag *graph.Graph, sccs [][]int) {
+	const (
+		width       = 300
+		height      = 240
+		titleHeight = 22
+		padding     = 5
+		margin      = 24 // Keeps super-nodes and their labels inside the panel
+		radius      = 12
+	)
+
+	x := screen.Bounds().Dx() - width - 20
+	y := 10
+	textColor := color.RGBA{220, 220, 220, 255}
+	draw.DrawCachedRect(screen, float64(x), float64(y), float64(width), float64(height), color.RGBA{40, 40, 40, 230})
+	title := fmt.Sprintf("Condensation DAG: %d components, %d edges", len(dag.Nodes), len(dag.Edges))
+	text.Draw(screen, title, basicfont.Face7x13, x+padding, y+15, textColor)
+	if len(dag.Nodes) == 0 {
+		return
+	}
+
+	// Fit the centroids into the drawing area, preserving their aspect ratio
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, node := range dag.Nodes {
+		minX, maxX = math.Min(minX, float64(node.X)), math.Max(maxX, float64(node.X))
+		minY, maxY = math.Min(minY, float64(node.Y)), math.Max(maxY, float64(node.Y))
+	}
+	areaX, areaY := float64(x+margin), float64(y+titleHeight+margin)
+	areaW, areaH := float64(width-2*margin), float64(height-titleHeight-2*margin)
+	scale := math.Min(areaW/math.Max(maxX-minX, 1), areaH/math.Max(maxY-minY, 1))
+	offsetX := areaX + (areaW-(maxX-minX)*scale)/2
+	offsetY := areaY + (areaH-(maxY-minY)*scale)/2
+	position := func(c int) (float64, float64) {
+		return offsetX + (float64(dag.Nodes[c].X)-minX)*scale, offsetY + (float64(dag.Nodes[c].Y)-minY)*scale
+	}
+
+	edgeColor := color.RGBA{200, 200, 200, 255}
+	for _, edge := range dag.Edges {
+		x1, y1 := position(edge[0])
+		x2, y2 := position(edge[1])
+		length := math.Hypot(x2-x1, y2-y1)
+		if length <= 2*radius {
+			continue // Overlapping super-nodes, the arrow would point the wrong way
+		}
+		tipX := x2 - (x2-x1)/length*radius
+		tipY := y2 - (y2-y1)/length*radius
+		draw.DrawCachedLine(screen, x1, y1, tipX, tipY, edgeColor)
+		draw.DrawCachedArrowHead(screen, x1, y1, tipX, tipY, 8, 2, edgeColor)
+	}
+
+	for c := range dag.Nodes {
+		cx, cy := position(c)
+		draw.DrawCachedCircle(screen, int(cx), int(cy), radius, paletteColor(c))
+		label := fmt.Sprintf("%d", c+1)
+		text.Draw(screen, label, basicfont.Face7x13, int(cx)-len(label)*7/2, int(cy)+4, color.White)
+
+		members := ""
+		if c < len(sccs) {
+			members = formatNodeList(sccs[c])
+		}
+		text.Draw(screen, members, basicfont.Face7x13, int(cx)-len(members)*7/2, int(cy)+radius+13, textColor)
+	}
+}
+
+// condensationOrder lists the condensation's components in a topological order
+func condensationOrder(dag *graph.Graph) string {
+	order, _ := algorithms.TopologicalSort(dag.GetUnweightedNeighbors(), len(dag.Nodes))
+	labels := make([]string, len(order))
+	for i, c := range order {
+		labels[i] = fmt.Sprintf("%d", c+1)
+	}
+	return "Components in topological order: " + strings.Join(labels, " -> ")
+}
+
+// formatComponents renders components as brace-enclosed node lists, numbered in the order they were found
+func formatComponents(sccs [][]int) string {
+	parts := make([]string, len(sccs))
+	for i, scc := range sccs {
+		parts[i] = fmt.Sprintf("%d:{%s}", i+1, formatNodeList(scc))
+	}
+	return strings.Join(parts, " ")
+}
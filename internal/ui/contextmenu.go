@@ -78,10 +78,11 @@ func (m *ContextMenu) HandleClick(x, y int) bool {
 	for i, item := range m.Items {
 		itemY := m.Y + i*m.ItemHeight
 		if y >= itemY && y < itemY+m.ItemHeight {
+			// Hide before running the action so it can reopen the menu (e.g. submenus)
+			m.Hide()
 			if item.Action != nil {
 				item.Action()
 			}
-			m.Hide()
 			return true
 		}
 	}
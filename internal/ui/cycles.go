@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// startCycleEnumeration lists the elementary cycles and opens the list at its first page
+func (g *Game) startCycleEnumeration() {
+	g.Sim.StartCycleEnumeration()
+	g.ListPanel.Page = 0
+	switch {
+	case len(g.Sim.Cycles) == 0:
+		g.showMessage("The graph has no cycles")
+	case g.Sim.CyclesCapped:
+		g.showMessage(fmt.Sprintf("Showing the first %d cycles. Click one to highlight it.", len(g.Sim.Cycles)))
+	default:
+		g.showMessage(fmt.Sprintf("%d elementary cycles. Click one to highlight it.", len(g.Sim.Cycles)))
+	}
+}
+
+// drawCycleEdges draws the detected or selected cycle in red and rings its nodes
+func (g *Game) drawCycleEdges(canvas *ebiten.Image) {
+	cycleColor := color.RGBA{220, 20, 60, 255}
+	g.drawPath(canvas, g.Sim.Cycle, 5, cycleColor)
+	for i := 0; i+1 < len(g.Sim.Cycle); i++ {
+		g.drawNodeRing(canvas, g.Sim.Cycle[i], cycleColor)
+	}
+}
+
+// cycleStatus describes the detected cycle or the enumeration in up to three lines
+func (g *Game) cycleStatus() (status, detail, extra string) {
+	cycle := g.Sim.Cycle
+	if g.Sim.Mode == algorithms.ModeCycleDetect {
+		if g.Sim.Graph.Directed {
+			status = "Cycle detection (directed): DFS looks for an edge back to a node still on the stack"
+		} else {
+			status = "Cycle detection (undirected): DFS looks for an edge to a visited node other than the parent"
+		}
+		switch {
+		case cycle != nil:
+			detail = fmt.Sprintf("Found the cycle %s (%d edges)", formatPath(cycle), len(cycle)-1)
+		case g.Sim.Graph.Directed:
+			detail = "No cycle: the graph is a DAG"
+		default:
+			detail = "No cycle: the graph is a forest"
+		}
+		extra = "Algorithms > Cycles > Elementary Cycles lists every cycle"
+		return
+	}
+
+	count := fmt.Sprintf("%d", len(g.Sim.Cycles))
+	if g.Sim.CyclesCapped {
+		count = "more than " + count
+	}
+	status = fmt.Sprintf("Johnson's algorithm: %s elementary cycles", count)
+	switch {
+	case cycle != nil:
+		detail = fmt.Sprintf("Cycle %d: %s (%d edges)", g.Sim.SelectedCycle+1, formatPath(cycle), len(cycle)-1)
+	case g.Sim.Graph.Directed:
+		detail = "No cycles: the graph is a DAG"
+	default:
+		detail = "No cycles: the graph is a forest"
+	}
+	switch {
+	case g.Sim.CyclesCapped:
+		extra = fmt.Sprintf("Stopped at the limit of %d; raise it with Set Cycle Limit...", g.Sim.CycleLimit)
+	case !g.Sim.Graph.Directed:
+		extra = "Undirected cycles need three nodes and are listed in one direction only"
+	default:
+		extra = "Click a cycle in the list to highlight it"
+	}
+	return
+}
+
+// drawCycleList shows the enumerated cycles one page at a time
+func (g *Game) drawCycleList(screen *ebiten.Image) {
+	items := make([]string, len(g.Sim.Cycles))
+	for i, cycle := range g.Sim.Cycles {
+		items[i] = fmt.Sprintf("%3d. %s", i+1, formatPath(cycle))
+	}
+	title := fmt.Sprintf("Elementary cycles (%d)", len(items))
+	if g.Sim.CyclesCapped {
+		title = fmt.Sprintf("Elementary cycles (first %d)", len(items))
+	}
+	g.ListPanel.Draw(screen, title, items, g.Sim.SelectedCycle)
+}
+
+// openCycleLimitInput opens the value modal for the number of cycles to list
+func (g *Game) openCycleLimitInput() {
+	g.AVLAction = "cycle limit"
+	g.AVLInputText = strconv.Itoa(g.Sim.CycleLimit)
+	g.ShowAVLInput = true
+}
+
+// submitCycleLimitInput applies the cycle limit typed into the modal
+func (g *Game) submitCycleLimitInput() {
+	limit, err := strconv.Atoi(g.AVLInputText)
+	if err != nil || limit < 1 {
+		g.showMessage("Invalid cycle limit")
+		g.AVLInputText = ""
+		return
+	}
+
+	g.Sim.CycleLimit = limit
+	g.ShowAVLInput = false
+	g.showMessage(fmt.Sprintf("Cycle limit = %d", limit))
+
+	// List the cycles again under the new limit
+	if g.Sim.Mode == algorithms.ModeCycleEnumeration {
+		g.startCycleEnumeration()
+	}
+}
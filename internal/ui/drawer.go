@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"math"
 	"strings"
 
 	"bfsdfs/internal/algorithms"
@@ -31,6 +32,9 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		if g.Sim.Mode == algorithms.ModeAVL {
 			// Draw AVL tree
 			g.drawAVLTree(g.graphCanvas)
+		} else if g.Sim.Maze != nil {
+			// Draw the maze cells instead of the grid graph behind them
+			g.drawMaze(g.graphCanvas)
 		} else {
 			// Draw normal graph
 			g.drawGraph(g.graphCanvas, screenWidth, screenHeight)
@@ -72,7 +76,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	}
 
 	// Draw algorithm info if active (Visit order, Queue/Stack)
-	if g.Sim.Mode != algorithms.ModeIdle && g.Sim.Mode != algorithms.ModeAVL {
+	if g.Sim.Mode == algorithms.ModeBFS || g.Sim.Mode == algorithms.ModeDFS {
 		// Draw visit order
 		orderStr := "Visit order: "
 		for i, nodeIdx := range g.Sim.Order {
@@ -87,12 +91,12 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		// Draw queue or stack status
 		var dataStructStr string
 		if g.Sim.Mode == algorithms.ModeBFS {
-			dataStructStr = "Queue: "
+			dataStructStr = "Queue (node level): "
 			for i, nodeIdx := range g.Sim.Queue {
 				if i > 0 {
 					dataStructStr += ", "
 				}
-				dataStructStr += string(rune('A' + nodeIdx))
+				dataStructStr += fmt.Sprintf("%s%d", string(rune('A'+nodeIdx)), g.Sim.Levels[nodeIdx])
 			}
 		} else if g.Sim.Mode == algorithms.ModeDFS {
 			dataStructStr = "Stack: "
@@ -105,22 +109,21 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		}
 		// Position queue/stack status below visit order
 		text.Draw(screen, dataStructStr, basicfont.Face7x13, 20, 40, color.Black)
-	} else if g.Sim.Mode == algorithms.ModeAVL {
-		// Draw AVL tree info
-		avlInfoStr := "AVL Tree Mode"
-		text.Draw(screen, avlInfoStr, basicfont.Face7x13, 20, 20, color.Black)
-
-		// Show current action if any
-		if g.Sim.GetAVLAction() != "" {
-			actionStr := fmt.Sprintf("Last Action: %s", g.Sim.GetAVLAction())
-			text.Draw(screen, actionStr, basicfont.Face7x13, 20, 40, color.Black)
-		}
 
-		// Show tree statistics
-		if g.Sim.GetAVLTree() != nil && g.Sim.GetAVLTree().Root != nil {
-			heightStr := fmt.Sprintf("Tree Height: %d", g.Sim.GetAVLTree().Root.Height)
-			text.Draw(screen, heightStr, basicfont.Face7x13, 20, 60, color.Black)
+		// Unweighted shortest path to the clicked node
+		if g.Sim.Mode == algorithms.ModeBFS {
+			pathStr := "Click a node to show its shortest path from " + string(rune('A'+g.Sim.Source))
+			if g.TargetNode >= 0 {
+				if path := g.Sim.BFSPathTo(g.TargetNode); path != nil {
+					pathStr = fmt.Sprintf("Path to %s: %s (%d edges)", string(rune('A'+g.TargetNode)), formatPath(path), len(path)-1)
+				} else {
+					pathStr = string(rune('A'+g.TargetNode)) + " has not been reached yet"
+				}
+			}
+			text.Draw(screen, pathStr, basicfont.Face7x13, 20, 60, color.Black)
 		}
+	} else if g.Sim.Mode != algorithms.ModeIdle {
+		g.drawAlgorithmStatus(screen)
 	}
 
 	// Draw the message display
@@ -369,9 +372,29 @@ func (g *Game) drawGraph(canvas *ebiten.Image, screenWidth, screenHeight int) {
 			// Draw edge
 			edgeColor := color.RGBA{100, 100, 100, 255}
 			draw.DrawCachedLine(canvas, x1, y1, x2, y2, edgeColor)
+
+			// Directed edges end in an arrowhead at the rim of the target node
+			if g.Sim.Graph.Directed {
+				if length := math.Hypot(x2-x1, y2-y1); length > 20 {
+					tipX := x2 - (x2-x1)/length*20
+					tipY := y2 - (y2-y1)/length*20
+					draw.DrawCachedArrowHead(canvas, x1, y1, tipX, tipY, 10, 2, edgeColor)
+				}
+			}
+
+			// Draw edge weight at the midpoint for weighted algorithms
+			if g.showsEdgeWeights() {
+				if weight, ok := g.Sim.Graph.EdgeWeight(edge[0], edge[1]); ok {
+					weightText := fmt.Sprintf("%.1f", weight)
+					text.Draw(canvas, weightText, basicfont.Face7x13, int((x1+x2)/2)+4, int((y1+y2)/2)-4, color.RGBA{90, 90, 90, 255})
+				}
+			}
 		}
 	}
 
+	// Draw algorithm results on top of the edges
+	g.drawEdgeOverlays(canvas)
+
 	// Draw nodes
 	for i, node := range g.Sim.Graph.Nodes {
 		// Convert node position to screen coordinates
@@ -384,95 +407,536 @@ func (g *Game) drawGraph(canvas *ebiten.Image, screenWidth, screenHeight int) {
 			var nodeColor color.RGBA
 			if i == g.Sim.Current {
 				nodeColor = color.RGBA{255, 69, 0, 255} // Red-orange for current node
+			} else if clr, ok := g.nodePaletteColor(i); ok {
+				nodeColor = clr // Per-node palette color assigned by a coloring algorithm
+			} else if clr, ok := g.nodeColorOverride(i); ok {
+				nodeColor = clr // Algorithm-specific node state
 			} else if g.Sim.Visited[i] {
 				nodeColor = color.RGBA{50, 205, 50, 255} // Lime green for visited nodes
 			} else {
 				nodeColor = color.RGBA{70, 130, 180, 255} // Cornflower blue for unvisited nodes
 			}
 
-			// Draw node (fixed radius of 20)
-			draw.DrawCachedCircle(canvas, int(x), int(y), 20, nodeColor)
+			// Ring around the selected target node
+			if i == g.TargetNode && g.usesTargetNode() {
+				draw.DrawCachedCircle(canvas, int(x), int(y), 25, color.RGBA{255, 140, 0, 255})
+			}
+
+			// Draw node (radius 20 unless the graph metrics scale it)
+			draw.DrawCachedCircle(canvas, int(x), int(y), g.nodeRadius(i), nodeColor)
 
 			// Draw node label
 			label := string(rune('A' + i))
 			text.Draw(canvas, label, basicfont.Face7x13, int(x)-3, int(y)+4, color.White)
 		}
 	}
+
+	// Draw per-node algorithm annotations on top of the nodes
+	g.drawNodeOverlays(canvas)
+}
+
+// undirectedCycleHint explains why an undirected graph always has a cycle for topological sorting
+func (g *Game) undirectedCycleHint() string {
+	if g.Sim.Graph.Directed {
+		return ""
+	}
+	return "Every edge runs both ways, so each one is a 2-cycle: use Strongly Connected Components > Make Edges Directed"
+}
+
+// showsEdgeWeights reports whether the current mode uses edge weights
+func (g *Game) showsEdgeWeights() bool {
+	switch g.Sim.Mode {
+	case algorithms.ModeDijkstra, algorithms.ModeAStar, algorithms.ModeKruskal, algorithms.ModePrim,
+		algorithms.ModeBoruvka, algorithms.ModeReverseDelete, algorithms.ModeMSTCompare,
+		algorithms.ModeFloydWarshall, algorithms.ModeJohnson, algorithms.ModeUCS, algorithms.ModeHungarian,
+		algorithms.ModeKShortestPaths, algorithms.ModeAllSimplePaths:
+		return true
+	}
+	// Tours measured along the edges
+	for _, mode := range tspModes {
+		if g.Sim.Mode == mode {
+			return g.Sim.TSPMetric == algorithms.TSPEdgeWeights
+		}
+	}
+	return false
 }
 
-// drawAVLTree draws the AVL tree visualization
+// drawAlgorithmStatus draws the status lines and panels of the non-traversal algorithms
+func (g *Game) drawAlgorithmStatus(screen *ebiten.Image) {
+	var status, detail, extra string
+
+	switch g.Sim.Mode {
+	case algorithms.ModeFloydWarshall:
+		fw := g.Sim.GetFloydWarshall()
+		if fw == nil {
+			return
+		}
+		n := len(fw.Dist)
+		if fw.Done() {
+			status = fmt.Sprintf("Floyd-Warshall: done after %d iterations of k", n)
+		} else {
+			status = fmt.Sprintf("Floyd-Warshall: next k = %s (%d of %d)", string(rune('A'+fw.K)), fw.K+1, n)
+		}
+		if fw.NegativeCycle {
+			detail = "Negative cycle detected - distances are not meaningful"
+		} else {
+			detail = "Click a matrix cell to highlight its shortest path"
+		}
+		focus := -1
+		if fw.K > 0 {
+			focus = fw.K - 1 // Highlight the k that produced the current matrix
+		}
+		g.MatrixPanel.Draw(screen, "Floyd-Warshall", &fw.AllPairsResult, focus, focus, fw.Updated)
+
+	case algorithms.ModeJohnson:
+		j := g.Sim.GetJohnson()
+		if j == nil {
+			return
+		}
+		switch {
+		case j.NegativeCycle:
+			status = "Johnson: negative cycle detected by Bellman-Ford"
+		case j.Source == -1:
+			status = "Johnson: next step reweights edges with Bellman-Ford"
+		case j.Done():
+			status = "Johnson: done"
+		default:
+			status = fmt.Sprintf("Johnson: next Dijkstra from %s", string(rune('A'+j.Source)))
+		}
+		if j.Potential != nil {
+			detail = "h:"
+			for v, h := range j.Potential {
+				detail += fmt.Sprintf(" %s=%.1f", string(rune('A'+v)), h)
+			}
+		}
+		focus := -1
+		if j.Source > 0 {
+			focus = j.Source - 1 // Highlight the row filled by the last Dijkstra run
+		}
+		g.MatrixPanel.Draw(screen, "Johnson", &j.AllPairsResult, focus, -1, nil)
+
+	case algorithms.ModeDijkstra:
+		status = "Dijkstra from " + string(rune('A'+g.Sim.Source)) + ": shortest path tree in purple"
+		if g.TargetNode < 0 || g.TargetNode >= len(g.Sim.Graph.Nodes) {
+			detail = "Click a node (or right-click > Set as Target Node) to pick a target"
+		} else if path, cost := g.Sim.ShortestPathTo(g.TargetNode); path == nil {
+			detail = "Target " + string(rune('A'+g.TargetNode)) + " is unreachable"
+		} else {
+			detail = fmt.Sprintf("Path: %s  (total cost %.1f)", formatPath(path), cost)
+		}
+
+	case algorithms.ModeAStar:
+		status = fmt.Sprintf("A* from %s, heuristic: %s", string(rune('A'+g.Sim.Source)), g.Sim.Heuristic)
+		if g.Sim.Heuristic == algorithms.HeuristicWeighted {
+			status += fmt.Sprintf(" (epsilon %g)", g.Sim.Epsilon)
+		}
+		switch {
+		case g.Sim.Target < 0:
+			detail = "Click a node (or right-click > Set as Target Node) to pick a goal"
+		case g.Sim.Path == nil:
+			detail = fmt.Sprintf("Goal %s is unreachable (%d nodes expanded)", string(rune('A'+g.Sim.Target)), len(g.Sim.Order))
+		default:
+			detail = fmt.Sprintf("Path: %s  (cost %.1f, %d nodes expanded)", formatPath(g.Sim.Path), g.Sim.PathCost, len(g.Sim.Order))
+		}
+		g.drawHeuristicComparison(screen)
+
+	case algorithms.ModeBidirectional, algorithms.ModeIDDFS, algorithms.ModeDepthLimited, algorithms.ModeUCS:
+		status, detail, extra = g.searchStatus()
+
+	case algorithms.ModeEdmondsKarp, algorithms.ModeDinic:
+		status, detail, extra = g.flowStatus()
+
+	case algorithms.ModeBipartite, algorithms.ModeHopcroftKarp, algorithms.ModeHungarian:
+		status, detail, extra = g.matchingStatus()
+		g.drawAssignmentPanel(screen)
+
+	case algorithms.ModeGreedyColoring, algorithms.ModeWelshPowell, algorithms.ModeDSatur, algorithms.ModeExactColoring:
+		status, detail, extra = g.coloringStatus()
+		if c := g.Sim.GetGreedyColoring(); c != nil {
+			drawColoringPanel(screen, c)
+		}
+
+	case algorithms.ModeEuler, algorithms.ModeHamiltonianPath, algorithms.ModeHamiltonianCycle:
+		status, detail, extra = g.walkStatus()
+
+	case algorithms.ModeNearestNeighborTSP, algorithms.ModeTwoOpt, algorithms.ModeMSTTour, algorithms.ModeHeldKarp, algorithms.ModeTSPCompare:
+		status, detail, extra = g.tspStatus()
+		g.drawTSPComparison(screen)
+
+	case algorithms.ModeKShortestPaths, algorithms.ModeAllSimplePaths:
+		status, detail, extra = g.pathListStatus()
+		g.drawPathList(screen)
+
+	case algorithms.ModeMaze:
+		status, detail, extra = g.mazeStatus()
+
+	case algorithms.ModeAVL:
+		status, detail, extra = g.treeStatus()
+		g.drawTreeComparison(screen)
+
+	case algorithms.ModeMetrics:
+		status, detail, extra = g.metricsStatus()
+		g.drawMetricsPanel(screen)
+
+	case algorithms.ModeCycleDetect, algorithms.ModeCycleEnumeration:
+		status, detail, extra = g.cycleStatus()
+		if g.Sim.Mode == algorithms.ModeCycleEnumeration {
+			g.drawCycleList(screen)
+		}
+
+	case algorithms.ModeKruskal:
+		k := g.Sim.GetKruskal()
+		if k == nil {
+			return
+		}
+		status = fmt.Sprintf("Kruskal: %d of %d tree edges, total weight %.1f", len(k.MST), len(g.Sim.Graph.Nodes)-1, edgeWeightSum(k.MST))
+		switch {
+		case k.Done() && len(k.MST) < len(g.Sim.Graph.Nodes)-1:
+			detail = "Done: the graph is disconnected, so the result is a minimum spanning forest"
+		case k.Done():
+			detail = "Done: minimum spanning tree complete"
+		case k.Index > 0:
+			last := k.Sorted[k.Index-1]
+			detail = fmt.Sprintf("Edge %s-%s (%.1f) %s", string(rune('A'+last.From)), string(rune('A'+last.To)), last.Weight, k.Decisions[k.Index-1])
+		default:
+			detail = "Edges are considered in order of weight"
+		}
+		extra = "Components share a color; accepted edges green, rejected red dashed, next edge orange"
+		g.drawKruskalPanel(screen, k)
+
+	case algorithms.ModePrim:
+		p := g.Sim.GetPrim()
+		if p == nil {
+			return
+		}
+		status = fmt.Sprintf("Prim from %s: %d tree edges, total weight %.1f", string(rune('A'+g.Sim.Source)), len(p.MST), edgeWeightSum(p.MST))
+		if p.Trees > 1 {
+			status += fmt.Sprintf(" (forest of %d trees)", p.Trees)
+		}
+		switch {
+		case p.LastEdge.From == -1:
+			detail = fmt.Sprintf("Started a tree at %s", string(rune('A'+p.LastEdge.To)))
+		default:
+			detail = fmt.Sprintf("Added %s via edge %s-%s (%.1f)", string(rune('A'+p.LastEdge.To)),
+				string(rune('A'+p.LastEdge.From)), string(rune('A'+p.LastEdge.To)), p.LastEdge.Weight)
+		}
+		if p.Done() {
+			detail = "Done: " + detail
+		} else if edge, ok := p.Peek(); ok {
+			extra = fmt.Sprintf("Next: %s-%s (%.1f), the lightest of %d edges crossing the cut (dashed)",
+				string(rune('A'+edge.From)), string(rune('A'+edge.To)), edge.Weight, len(p.CutEdges()))
+		} else {
+			extra = "No edge crosses the cut: the next step starts a new tree"
+		}
+
+	case algorithms.ModeBoruvka:
+		b := g.Sim.GetBoruvka()
+		if b == nil {
+			return
+		}
+		status = fmt.Sprintf("Boruvka: phase %d, %d components, %d tree edges, total weight %.1f",
+			b.Phase, b.Sets.Components(), len(b.MST), edgeWeightSum(b.MST))
+		switch {
+		case b.Done() && len(b.MST) < len(g.Sim.Graph.Nodes)-1:
+			detail = "Done: no edge leaves any component, so the result is a minimum spanning forest"
+		case b.Done():
+			detail = "Done: minimum spanning tree complete"
+		case b.Phase > 0:
+			added := make([]string, 0, len(b.Chosen))
+			for _, i := range b.Chosen {
+				edge := b.Edges[i]
+				added = append(added, fmt.Sprintf("%s-%s (%.1f)", string(rune('A'+edge.From)), string(rune('A'+edge.To)), edge.Weight))
+			}
+			detail = "Last phase added " + strings.Join(added, ", ")
+		default:
+			detail = "Every component picks its cheapest outgoing edge in each phase"
+		}
+		extra = "Components share a color; tree edges green, edges added by the last phase orange"
+
+	case algorithms.ModeReverseDelete:
+		r := g.Sim.GetReverseDelete()
+		if r == nil {
+			return
+		}
+		status = fmt.Sprintf("Reverse-delete: %d of %d edges considered, %d operations", r.Index, len(r.Sorted), r.Operations)
+		switch {
+		case r.Done():
+			mst := r.MST()
+			detail = fmt.Sprintf("Done: %d edges remain, total weight %.1f", len(mst), edgeWeightSum(mst))
+		case r.Index > 0:
+			last := r.Sorted[r.Index-1]
+			detail = fmt.Sprintf("Edge %s-%s (%.1f) %s", string(rune('A'+last.From)), string(rune('A'+last.To)), last.Weight,
+				reverseDeleteDecision(r.Decisions[r.Index-1]))
+		default:
+			detail = "Edges are considered from heaviest to lightest"
+		}
+		extra = "An edge is deleted when its endpoints stay connected without it; kept green, deleted red dashed, next orange"
+		g.drawReverseDeletePanel(screen, r)
+
+	case algorithms.ModeMSTCompare:
+		if len(g.Sim.MSTComparison) == 0 {
+			return
+		}
+		status = fmt.Sprintf("MST comparison, Prim grown from %s", string(rune('A'+g.Sim.Source)))
+		detail = "Every algorithm finds the same minimum weight; with equal weights the chosen edges can differ"
+		extra = "Edges chosen by all algorithms green, by only some dashed orange and labelled with their initials"
+		g.drawMSTComparison(screen)
+
+	case algorithms.ModeTarjan:
+		t := g.Sim.GetTarjan()
+		if t == nil {
+			return
+		}
+		status = fmt.Sprintf("Tarjan: %d of %d nodes visited, %d components emitted", t.Counter, len(t.Index), len(t.SCCs))
+		switch t.Action {
+		case algorithms.TarjanVisit:
+			detail = fmt.Sprintf("Visited %s with index %d and pushed it", string(rune('A'+t.Current)), t.Index[t.Current])
+		case algorithms.TarjanEdge:
+			from, to := t.LastEdge[0], t.LastEdge[1]
+			detail = fmt.Sprintf("Edge %s-%s reaches %s on the stack: lowlink(%s) = %d", string(rune('A'+from)), string(rune('A'+to)),
+				string(rune('A'+to)), string(rune('A'+from)), t.Lowlink[from])
+		case algorithms.TarjanReturn:
+			detail = fmt.Sprintf("Returned from %s with lowlink %d", string(rune('A'+t.Current)), t.Lowlink[t.Current])
+		case algorithms.TarjanEmit:
+			detail = fmt.Sprintf("%s has lowlink = index, so it roots a component: {%s}", string(rune('A'+t.Current)),
+				formatNodeList(t.SCCs[len(t.SCCs)-1]))
+		}
+		if t.Done() {
+			detail = "Done: " + detail
+		}
+		extra = "Labels are index/lowlink; stacked nodes gray, emitted components colored, recursion path purple"
+		if g.Sim.Condensation != nil {
+			extra = condensationOrder(g.Sim.Condensation)
+			drawCondensationPanel(screen, g.Sim.Condensation, g.Sim.SCCs)
+		} else {
+			drawTarjanStack(screen, t)
+		}
+
+	case algorithms.ModeConnected:
+		status = fmt.Sprintf("%d connected components", len(g.Sim.Components))
+		detail = formatComponents(g.Sim.Components)
+		extra = "Each component has its own color"
+		if g.Sim.Graph.Directed {
+			extra += "; edge directions are ignored"
+		}
+
+	case algorithms.ModeBridges:
+		c := g.Sim.Connectivity
+		if c == nil {
+			return
+		}
+		status = fmt.Sprintf("%d bridges, %d articulation points", len(c.Bridges), len(c.ArticulationPoints))
+		bridges := make([]string, len(c.Bridges))
+		for i, bridge := range c.Bridges {
+			bridges[i] = string(rune('A'+bridge[0])) + "-" + string(rune('A'+bridge[1]))
+		}
+		detail = "Bridges (red): " + strings.Join(bridges, " ")
+		if len(bridges) == 0 {
+			detail = "No bridges: every edge lies on a cycle"
+		}
+		extra = "Cut vertices (square frames): " + formatNodeList(c.ArticulationPoints)
+
+	case algorithms.ModeBiconnected:
+		c := g.Sim.Connectivity
+		if c == nil {
+			return
+		}
+		status = fmt.Sprintf("%d biconnected components", len(c.Blocks))
+		detail = formatComponents(c.BlockNodes())
+		extra = "Each block's edges have their own color; cut vertices (square frames) join several blocks"
+
+	case algorithms.ModeTopological:
+		status = "Topological sort (DFS)"
+		if g.Sim.Cycle != nil {
+			detail = "No topological order exists: the graph has the cycle " + formatPath(g.Sim.Cycle)
+			extra = g.undirectedCycleHint()
+		} else {
+			detail = "Order: " + formatNodeList(g.Sim.TopOrder)
+		}
+
+	case algorithms.ModeKahn:
+		k := g.Sim.GetKahn()
+		if k == nil {
+			return
+		}
+		status = fmt.Sprintf("Kahn: %d of %d nodes output", len(k.Order), len(k.InDegree))
+		switch {
+		case k.Cycle != nil:
+			detail = fmt.Sprintf("Stuck: every remaining node has an incoming edge, e.g. the cycle %s", formatPath(k.Cycle))
+			extra = g.undirectedCycleHint()
+		case k.Current != -1:
+			lowered := make([]string, len(k.Decremented))
+			for i, v := range k.Decremented {
+				lowered[i] = fmt.Sprintf("%s(%d)", string(rune('A'+v)), k.InDegree[v])
+			}
+			detail = fmt.Sprintf("Output %s", string(rune('A'+k.Current)))
+			if len(lowered) > 0 {
+				detail += "; in-degrees lowered: " + strings.Join(lowered, " ")
+			}
+		default:
+			detail = "Nodes without incoming edges are queued first"
+		}
+		if k.Cycle == nil {
+			if k.Done() {
+				detail = "Done. Order: " + formatNodeList(k.Order)
+			} else {
+				extra = "Queue (in-degree zero): " + formatNodeList(k.Queue)
+			}
+		}
+		drawInDegreePanel(screen, k)
+
+	case algorithms.ModeKosaraju:
+		status = fmt.Sprintf("Kosaraju: %d strongly connected components", len(g.Sim.SCCs))
+		detail = formatComponents(g.Sim.SCCs)
+		extra = "Algorithms > Strongly Connected Components > Condensation DAG collapses each component"
+		if g.Sim.Condensation != nil {
+			extra = condensationOrder(g.Sim.Condensation)
+			drawCondensationPanel(screen, g.Sim.Condensation, g.Sim.SCCs)
+		}
+
+	case algorithms.ModeDFSTimes:
+		d := g.Sim.GetDFSTimes()
+		if d == nil {
+			return
+		}
+		status = fmt.Sprintf("DFS from %s with timestamps: time %d", string(rune('A'+g.Sim.Source)), d.Time)
+		switch d.Action {
+		case algorithms.DFSDiscover:
+			detail = fmt.Sprintf("Discovered %s at time %d", string(rune('A'+d.Current)), d.Discovery[d.Current])
+			if p := d.Parent[d.Current]; p != -1 {
+				detail += fmt.Sprintf(" via tree edge %s-%s", string(rune('A'+p)), string(rune('A'+d.Current)))
+			}
+		case algorithms.DFSExamine:
+			detail = fmt.Sprintf("Edge %s-%s is a %s edge", string(rune('A'+d.LastEdge.From)), string(rune('A'+d.LastEdge.To)), d.LastEdge.Class)
+		case algorithms.DFSFinish:
+			detail = fmt.Sprintf("Finished %s at time %d", string(rune('A'+d.Current)), d.Finish[d.Current])
+		}
+		extra = "Edges: tree purple solid, back red dashed, forward green dashed, cross blue dotted"
+		drawDFSTimeline(screen, d)
+
+	default:
+		status = g.Sim.Mode.String()
+	}
+
+	text.Draw(screen, status, basicfont.Face7x13, 20, 20, color.Black)
+	if detail != "" {
+		text.Draw(screen, detail, basicfont.Face7x13, 20, 40, color.Black)
+	}
+	if extra != "" {
+		text.Draw(screen, extra, basicfont.Face7x13, 20, 60, color.Black)
+	}
+}
+
+// drawAVLTree draws the tree of the tree mode
+// While an operation is replayed it shows the tree as of the current step
 func (g *Game) drawAVLTree(canvas *ebiten.Image) {
-	if g.Sim.GetAVLTree() == nil || g.Sim.GetAVLTree().Root == nil {
+	screenWidth, _ := ebiten.WindowSize()
+	tree := g.Sim.DisplayTree()
+	g.avlAnim.retarget(tree, screenWidth, g.StepDelay)
+	if tree == nil {
 		return
 	}
 
-	// Update node positions for visualization
-	screenWidth, _ := ebiten.WindowSize()
+	// Lay the tree out centered in the window; the nodes slide there from the previous layout
 	centerX := screenWidth / 2
 	startY := 100
 	levelHeight := 80
-
-	// Update positions
-	g.Sim.GetAVLTree().UpdatePositions(centerX, startY, levelHeight)
+	algorithms.UpdateTreePositions(tree, centerX, startY, levelHeight)
 
 	// Draw tree nodes and edges
-	g.drawAVLNode(canvas, g.Sim.GetAVLTree().Root)
+	g.drawAVLNode(canvas, tree, g.Sim.CurrentTreeStep())
 }
 
-// drawAVLNode recursively draws an AVL tree node and its children
-func (g *Game) drawAVLNode(canvas *ebiten.Image, node *algorithms.AVLNode) {
-	if node == nil {
-		return
+// drawAVLNode recursively draws a tree node and its children
+// Binary tree nodes are circles, red or black in a red-black tree; B-tree nodes are boxes of
+// keys; the node the replayed step concerns gets a thick ring in the color of the step
+func (g *Game) drawAVLNode(canvas *ebiten.Image, node algorithms.TreeNode, step *algorithms.TreeStep) {
+	// Draw edges to children first (so they appear behind nodes)
+	for _, child := range node.Children() {
+		if child != nil {
+			g.drawAVLEdge(canvas, node, child)
+			g.drawAVLNode(canvas, child, step)
+		}
 	}
 
-	// Draw edges to children first (so they appear behind nodes)
-	if node.Left != nil {
-		g.drawAVLEdge(canvas, node, node.Left)
-		g.drawAVLNode(canvas, node.Left)
+	keys := node.Keys()
+	ring, ringWidth := color.RGBA{0, 0, 0, 255}, 2
+	switch {
+	case step != nil && containsKey(keys, step.Key):
+		ring, ringWidth = treeStepColor(step.Kind), 5
+	case step == nil && g.Sim.GetAVLAction() == "search" && containsKey(keys, g.Sim.GetAVLValue()):
+		ring, ringWidth = treeStepColor(algorithms.StepFound), 5
 	}
-	if node.Right != nil {
-		g.drawAVLEdge(canvas, node, node.Right)
-		g.drawAVLNode(canvas, node.Right)
+	fill := color.RGBA{100, 149, 237, 255} // Cornflower blue
+	if rb, ok := node.(*algorithms.RBNode); ok {
+		fill = color.RGBA{40, 40, 40, 255}
+		if rb.Red {
+			fill = color.RGBA{200, 30, 30, 255}
+		}
 	}
 
-	// Draw node
-	nodeColor := color.RGBA{100, 149, 237, 255} // Cornflower blue
-	if g.Sim.GetAVLAction() == "search" && g.Sim.GetAVLValue() == node.Value {
-		nodeColor = color.RGBA{255, 69, 0, 255} // Red-orange for found node
+	// Apply canvas offset (no zoom scaling)
+	x, y := g.avlAnim.at(node)
+	x += g.CanvasOffsetX
+	y += g.CanvasOffsetY
+
+	annotationY := int(y) + 35 // Fixed offset of 35 pixels below a round node
+	if _, ok := node.(*algorithms.BTreeNode); ok {
+		// A box with a cell per key
+		const cell, height = algorithms.TreeKeyWidth, 30.0
+		width := cell * float64(max(len(keys), 1))
+		left, top := x-width/2, y-height/2
+		w := float64(ringWidth)
+		draw.DrawCachedRect(canvas, left-w, top-w, width+2*w, height+2*w, ring)
+		draw.DrawCachedRect(canvas, left, top, width, height, fill)
+		for i, key := range keys {
+			if i > 0 {
+				draw.DrawCachedLine(canvas, left+cell*float64(i), top, left+cell*float64(i), top+height, color.White)
+			}
+			keyText := fmt.Sprintf("%d", key)
+			bounds := text.BoundString(basicfont.Face7x13, keyText)
+			text.Draw(canvas, keyText, basicfont.Face7x13,
+				int(left+cell*float64(i)+cell/2)-bounds.Dx()/2, int(y)+bounds.Dy()/2, color.White)
+		}
+		annotationY = int(top+height) + 15
+	} else {
+		// Draw node circle with border (radius of 25 inside the ring)
+		draw.DrawCachedCircle(canvas, int(x), int(y), 25+ringWidth, ring)
+		draw.DrawCachedCircle(canvas, int(x), int(y), 25, fill)
+
+		// Draw node value
+		valueText := fmt.Sprintf("%d", keys[0])
+		valueBounds := text.BoundString(basicfont.Face7x13, valueText)
+		text.Draw(canvas, valueText, basicfont.Face7x13,
+			int(x)-valueBounds.Dx()/2,
+			int(y)+valueBounds.Dy()/2,
+			color.White)
 	}
 
-	// Apply canvas offset (no zoom scaling)
-	x := float64(node.Position.X) + g.CanvasOffsetX
-	y := float64(node.Position.Y) + g.CanvasOffsetY
-
-	// Draw node circle with border (fixed radius of 25)
-	draw.DrawCachedCircle(canvas, int(x), int(y), 25, nodeColor)
-	draw.DrawCachedCircle(canvas, int(x), int(y), 25, color.RGBA{0, 0, 0, 255}) // Black border
-
-	// Draw node value
-	valueText := fmt.Sprintf("%d", node.Value)
-	valueBounds := text.BoundString(basicfont.Face7x13, valueText)
-	text.Draw(canvas, valueText, basicfont.Face7x13,
-		int(x)-valueBounds.Dx()/2,
-		int(y)+valueBounds.Dy()/2,
-		color.White)
-
-	// Draw height below the node
-	heightText := fmt.Sprintf("h:%d", node.Height)
-	heightBounds := text.BoundString(basicfont.Face7x13, heightText)
-	text.Draw(canvas, heightText, basicfont.Face7x13,
-		int(x)-heightBounds.Dx()/2,
-		int(y)+35, // Fixed offset of 35 pixels below node
-		color.Black)
+	// Draw the height, color or priority below the node
+	if note := node.Annotation(); note != "" {
+		noteBounds := text.BoundString(basicfont.Face7x13, note)
+		text.Draw(canvas, note, basicfont.Face7x13, int(x)-noteBounds.Dx()/2, annotationY, color.Black)
+	}
 }
 
-// drawAVLEdge draws an edge between two AVL tree nodes
-func (g *Game) drawAVLEdge(canvas *ebiten.Image, from, to *algorithms.AVLNode) {
-	// Apply canvas offset (no zoom scaling)
-	x1 := float64(from.Position.X) + g.CanvasOffsetX
-	y1 := float64(from.Position.Y) + g.CanvasOffsetY
-	x2 := float64(to.Position.X) + g.CanvasOffsetX
-	y2 := float64(to.Position.Y) + g.CanvasOffsetY
+// containsKey reports whether key is one of keys
+func containsKey(keys []int, key int) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// drawAVLEdge draws an edge between two tree nodes
+func (g *Game) drawAVLEdge(canvas *ebiten.Image, from, to algorithms.TreeNode) {
+	// Both ends follow their nodes while they move
+	x1, y1 := g.avlAnim.at(from)
+	x2, y2 := g.avlAnim.at(to)
+	x1, y1 = x1+g.CanvasOffsetX, y1+g.CanvasOffsetY
+	x2, y2 = x2+g.CanvasOffsetX, y2+g.CanvasOffsetY
 
 	// Draw line
 	draw.DrawCachedLine(canvas, x1, y1, x2, y2, color.RGBA{0, 0, 0, 255})
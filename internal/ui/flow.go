@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// flowModes lists the stepped max-flow algorithms offered in the Max Flow menu
+var flowModes = []algorithms.TraversalMode{
+	algorithms.ModeEdmondsKarp,
+	algorithms.ModeDinic,
+}
+
+// isFlowMode reports whether the current mode is a max-flow algorithm
+func (g *Game) isFlowMode() bool {
+	for _, mode := range flowModes {
+		if g.Sim.Mode == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// startFlow starts a max-flow algorithm from source to the target node, which acts as the sink
+func (g *Game) startFlow(mode algorithms.TraversalMode, source int) {
+	if source < 0 || source >= len(g.Sim.Graph.Nodes) {
+		g.showMessage("Please select a source node first")
+		return
+	}
+	sink := g.TargetNode
+	if sink < 0 || sink == source {
+		g.showMessage("Max flow needs a sink: right-click another node > Set as Target Node")
+		return
+	}
+
+	switch mode {
+	case algorithms.ModeEdmondsKarp:
+		g.Sim.StartEdmondsKarp(source, sink)
+	case algorithms.ModeDinic:
+		g.Sim.StartDinic(source, sink)
+	default:
+		return
+	}
+	g.showMessage(fmt.Sprintf("%s from %s to %s. Edge weights are capacities.", mode,
+		string(rune('A'+source)), string(rune('A'+sink))))
+}
+
+// flowState returns the network, last augmenting path and minimum cut of the current max-flow mode
+// The network is nil outside the max-flow modes
+func (g *Game) flowState() (network *algorithms.FlowNetwork, path []int, sourceSide []bool) {
+	switch g.Sim.Mode {
+	case algorithms.ModeEdmondsKarp:
+		if e := g.Sim.GetEdmondsKarp(); e != nil {
+			return e.Network, e.Path, e.SourceSide
+		}
+	case algorithms.ModeDinic:
+		if d := g.Sim.GetDinic(); d != nil {
+			return d.Network, d.Path, d.SourceSide
+		}
+	}
+	return nil, nil, nil
+}
+
+// drawFlowEdges labels edges with flow/capacity, draws the last augmenting path and, once the flow
+// is maximum, the minimum cut; with ShowResidual it draws the residual graph instead
+func (g *Game) drawFlowEdges(canvas *ebiten.Image) {
+	network, path, sourceSide := g.flowState()
+	if network == nil {
+		return
+	}
+
+	labelColor := color.RGBA{0, 0, 160, 255}
+	if g.ShowResidual {
+		// Every arc that can still carry flow, offset to its left so opposite arcs stay apart
+		for _, arc := range network.Arcs {
+			if r := arc.Residual(); r > 1e-9 {
+				g.drawFlowArc(canvas, arc.From, arc.To, 5, 2, color.RGBA{120, 120, 120, 255}, fmt.Sprintf("%.1f", r), labelColor)
+			}
+		}
+	} else {
+		for i := 0; i < len(network.Arcs); i += 2 {
+			arc := network.Arcs[i]
+			label := fmt.Sprintf("%.1f/%.1f", arc.Flow, arc.Capacity)
+			switch {
+			case arc.Flow > 1e-9 && arc.Residual() <= 1e-9:
+				g.drawFlowArc(canvas, arc.From, arc.To, 5, 4, color.RGBA{25, 25, 112, 255}, label, labelColor) // Saturated
+			case arc.Flow > 1e-9:
+				g.drawFlowArc(canvas, arc.From, arc.To, 5, 3, color.RGBA{65, 105, 225, 255}, label, labelColor)
+			case g.Sim.Graph.Directed:
+				g.drawFlowArc(canvas, arc.From, arc.To, 0, 0, nil, label, color.RGBA{90, 90, 90, 255})
+			}
+		}
+	}
+
+	// The last augmenting path; reverse arcs cancel flow and are dashed
+	for _, i := range path {
+		arc := network.Arcs[i]
+		if i%2 == 0 {
+			g.drawThickEdge(canvas, arc.From, arc.To, 5, color.RGBA{255, 140, 0, 255})
+		} else {
+			g.drawDashedEdge(canvas, arc.From, arc.To, 5, color.RGBA{255, 140, 0, 255})
+		}
+	}
+
+	if sourceSide != nil {
+		for _, i := range network.CutArcs(sourceSide) {
+			arc := network.Arcs[i]
+			g.drawDashedEdge(canvas, arc.From, arc.To, 5, color.RGBA{220, 20, 60, 255})
+		}
+	}
+}
+
+// drawFlowArc draws the arc from a to b shifted offset pixels to its left, with an arrowhead at
+// the rim of b and a label near a; a nil line color draws only the label
+func (g *Game) drawFlowArc(canvas *ebiten.Image, a, b int, offset, thickness float64, lineColor color.Color, label string, labelColor color.Color) {
+	if a < 0 || b < 0 || a >= len(g.Sim.Graph.Nodes) || b >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+	na, nb := g.Sim.Graph.Nodes[a], g.Sim.Graph.Nodes[b]
+	x1, y1 := float64(na.X)+g.CanvasOffsetX, float64(na.Y)+g.CanvasOffsetY
+	x2, y2 := float64(nb.X)+g.CanvasOffsetX, float64(nb.Y)+g.CanvasOffsetY
+	length := math.Hypot(x2-x1, y2-y1)
+	if length <= 40 {
+		return // The nodes overlap
+	}
+	ux, uy := (x2-x1)/length, (y2-y1)/length
+	// Screen y points down, so (uy, -ux) is the left-hand side of the arc
+	x1, y1 = x1+uy*offset, y1-ux*offset
+	x2, y2 = x2+uy*offset, y2-ux*offset
+
+	if lineColor != nil {
+		tipX, tipY := x2-ux*20, y2-uy*20
+		draw.DrawCachedThickLine(canvas, x1+ux*20, y1+uy*20, tipX, tipY, thickness, lineColor)
+		draw.DrawCachedArrowHead(canvas, x1, y1, tipX, tipY, 10, thickness, lineColor)
+	}
+
+	// A third of the way along, on the arc's left, so the two directions of an edge do not collide
+	lx := x1 + (x2-x1)/3 + uy*12
+	ly := y1 + (y2-y1)/3 - ux*12
+	text.Draw(canvas, label, basicfont.Face7x13, int(lx)-len(label)*7/2, int(ly)+4, labelColor)
+}
+
+// drawDinicLevels labels every node in the current level graph with its BFS level
+func (g *Game) drawDinicLevels(canvas *ebiten.Image) {
+	d := g.Sim.GetDinic()
+	if d == nil || d.Done() {
+		return
+	}
+	for v, level := range d.Level {
+		if level >= 0 {
+			g.drawNodeText(canvas, v, fmt.Sprintf("L%d", level), 22, -14, color.RGBA{200, 0, 0, 255})
+		}
+	}
+}
+
+// flowStatus describes the progress of the current max-flow algorithm in up to three lines
+func (g *Game) flowStatus() (status, detail, extra string) {
+	network, path, sourceSide := g.flowState()
+	if network == nil {
+		return
+	}
+	source, sink := string(rune('A'+g.Sim.Source)), string(rune('A'+g.Sim.Target))
+
+	var flow float64
+	var augmentations int
+	switch g.Sim.Mode {
+	case algorithms.ModeEdmondsKarp:
+		e := g.Sim.GetEdmondsKarp()
+		flow, augmentations = e.MaxFlow, e.Augmentations
+		status = fmt.Sprintf("Edmonds-Karp %s -> %s: flow %.1f after %d augmenting paths", source, sink, flow, augmentations)
+	case algorithms.ModeDinic:
+		d := g.Sim.GetDinic()
+		flow, augmentations = d.MaxFlow, d.Augmentations
+		status = fmt.Sprintf("Dinic %s -> %s: flow %.1f after %d augmenting paths in %d phases", source, sink, flow, augmentations, d.Phase)
+		if path == nil && !d.Done() && d.Phase > 0 {
+			detail = fmt.Sprintf("Built level graph %d: the sink is at level %d", d.Phase, d.Level[g.Sim.Target])
+		}
+	}
+
+	if path != nil {
+		nodes := []int{network.Arcs[path[0]].From}
+		for _, i := range path {
+			nodes = append(nodes, network.Arcs[i].To)
+		}
+		detail = fmt.Sprintf("Augmented %s by %.1f", formatPath(nodes), g.augmentedAmount())
+	}
+
+	if sourceSide != nil {
+		sides := [2][]int{}
+		for v, onSource := range sourceSide {
+			if onSource {
+				sides[0] = append(sides[0], v)
+			} else {
+				sides[1] = append(sides[1], v)
+			}
+		}
+		capacity := 0.0
+		cut := []string{}
+		for _, i := range network.CutArcs(sourceSide) {
+			arc := network.Arcs[i]
+			capacity += arc.Capacity
+			cut = append(cut, string(rune('A'+arc.From))+"-"+string(rune('A'+arc.To)))
+		}
+		detail = fmt.Sprintf("Maximum flow %.1f = minimum cut {%s} | {%s}, capacity %.1f", flow,
+			formatNodeList(sides[0]), formatNodeList(sides[1]), capacity)
+		extra = "Cut edges (dashed red): " + strings.Join(cut, " ")
+		return
+	}
+
+	extra = "Labels are flow/capacity; the last augmenting path is orange, with reverse arcs dashed"
+	if g.ShowResidual {
+		extra = "Residual graph: every arc that can still carry flow, labelled with its residual capacity"
+	}
+	return
+}
+
+// augmentedAmount returns the flow pushed by the last step of the current max-flow mode
+func (g *Game) augmentedAmount() float64 {
+	switch g.Sim.Mode {
+	case algorithms.ModeEdmondsKarp:
+		return g.Sim.GetEdmondsKarp().Bottleneck
+	case algorithms.ModeDinic:
+		return g.Sim.GetDinic().Bottleneck
+	}
+	return 0
+}
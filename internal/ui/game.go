@@ -101,6 +101,7 @@ func (b *Button) Draw(screen *ebiten.Image, g *Game) {
 type Game struct {
 	Sim               *simulator.Simulator
 	StartNode         int
+	TargetNode        int // Target for source-to-target algorithms, -1 if none
 	MouseX            int
 	MouseY            int
 	lastMouseX        int // Track last mouse X position for optimization
@@ -122,6 +123,15 @@ type Game struct {
 	RemovingNode  bool
 	RemovingEdge  bool
 
+	ShowResidual bool // Max-flow modes draw the residual graph instead of flow/capacity labels
+	MetricAsSize bool // The graph metrics mode scales nodes by centrality instead of coloring them
+
+	// Maze painting
+	MazeBrush       mazeBrush // What the left button paints onto a maze cell
+	MazeTerrainCost float64   // Cost of entering a cell painted with the terrain brush
+	mazePainting    bool      // A mouse button is held down over the maze
+	mazeStroke      mazeBrush // Brush of the held button: MazeBrush for the left, erase for the right
+
 	// Grid features
 	ShowGrid   bool
 	SnapToGrid bool
@@ -169,6 +179,9 @@ type Game struct {
 	AVLInputValue int
 	AVLAction     string // "insert", "delete", "search"
 	AVLInputText  string // Text input for AVL value
+	InputNode     int    // Node whose heuristic value is being edited
+
+	avlAnim treeAnimation // Moves the tree nodes between the layouts of replayed steps
 
 	// Selection features
 	Selecting           bool
@@ -180,6 +193,11 @@ type Game struct {
 	SelectionDragStartX float64  // X position where dragging of selection started (canvas coords)
 	SelectionDragStartY float64  // Y position where dragging of selection started (canvas coords)
 
+	// Algorithm result highlighting
+	HighlightPath []int        // Node sequence drawn as a highlighted path on the canvas
+	MatrixPanel   *MatrixPanel // All-pairs distance / next-hop matrix
+	ListPanel     *ListPanel   // Paginated list of enumerated results such as cycles
+
 	// Performance optimization fields
 	lastFrameTime time.Time
 	frameCount    int
@@ -195,6 +213,7 @@ func NewGame(sim *simulator.Simulator) *Game {
 	g := &Game{
 		Sim:            sim,
 		StartNode:      0,
+		TargetNode:     -1, // No target selected initially
 		StepDelay:      30, // Default to 30 frames between steps (about 0.5 seconds at 60 FPS)
 		DraggingNode:   -1, // No node being dragged initially
 		EdgeStartNode:  -1, // No edge start node selected initially
@@ -208,6 +227,11 @@ func NewGame(sim *simulator.Simulator) *Game {
 		CanvasOffsetY:  0, // Initial canvas offset
 		CanvasDragging: false,
 		ShowHelp:       false, // Initialize help overlay as hidden
+		MatrixPanel:    NewMatrixPanel(),
+		ListPanel:      NewListPanel(),
+
+		// Terrain painted in the maze costs five times as much as open ground
+		MazeTerrainCost: 5,
 
 		// Initialize cached canvases
 		graphCanvas:       ebiten.NewImage(screenWidth, screenHeight),
@@ -232,14 +256,20 @@ func NewGame(sim *simulator.Simulator) *Game {
 func (g *Game) generateGraphStateHash() string {
 	// This is a simple fingerprint of the current graph state
 	// If this changes, we need to redraw the graph
-	h := fmt.Sprintf("n%d-e%d-c%d-v%d-o%f-%f-g%v",
+	h := fmt.Sprintf("n%d-e%d-c%d-v%d-o%f-%f-g%v-m%d-s%d-p%v-t%d-h%v-r%v",
 		len(g.Sim.Graph.Nodes),
 		len(g.Sim.Graph.Edges),
 		g.Sim.Current,
 		len(g.Sim.Visited),
 		g.CanvasOffsetX,
 		g.CanvasOffsetY,
-		g.ShowGrid)
+		g.ShowGrid,
+		g.Sim.Mode,
+		g.Sim.Step,
+		g.HighlightPath,
+		g.TargetNode,
+		g.Sim.HeuristicValues,
+		g.ShowResidual)
 
 	return h
 }
@@ -304,8 +334,9 @@ func (g *Game) createButtons() {
 			Action: func() {
 				if g.Sim.Mode == algorithms.ModeIdle {
 					g.Sim.StartAVL()
-					g.AutoStep = false // Disable auto-stepping in AVL mode
-					g.showMessage("AVL Tree mode started. Use Insert/Delete/Search buttons.")
+					g.AutoStep = false
+					g.avlAnim = treeAnimation{}
+					g.showMessage("AVL Tree mode started. Use Insert/Delete/Search, then Step or Auto to replay.")
 				} else if g.Sim.Mode == algorithms.ModeAVL {
 					g.showMessage("Already in AVL Tree mode. Use Insert/Delete/Search buttons.")
 				} else {
@@ -317,12 +348,12 @@ func (g *Game) createButtons() {
 			X: margin + 3*(buttonWidth+buttonSpacing), Y: bottomRowY, Width: buttonWidth, Height: buttonHeight,
 			Text: "Step", BgColor: greenBg, TextColor: whiteTxt, AnchorBottom: true,
 			Action: func() {
-				if g.Sim.Done {
+				if g.Sim.Done && g.Sim.Mode == algorithms.ModeAVL {
+					g.showMessage("Operation replayed. Insert, delete or search another value.")
+				} else if g.Sim.Done {
 					g.showMessage("Algorithm has completed. Reset to start over.")
 				} else if g.Sim.Mode == algorithms.ModeIdle {
 					g.showMessage("Please select an algorithm first.")
-				} else if g.Sim.Mode == algorithms.ModeAVL {
-					g.showMessage("Step not applicable in AVL Tree mode.")
 				} else {
 					g.Sim.Update()
 					if g.Sim.Done {
@@ -335,12 +366,12 @@ func (g *Game) createButtons() {
 			X: margin + 4*(buttonWidth+buttonSpacing), Y: bottomRowY, Width: buttonWidth, Height: buttonHeight,
 			Text: "Auto", BgColor: orangeBg, TextColor: whiteTxt, AnchorBottom: true,
 			Action: func() {
-				if g.Sim.Done {
+				if g.Sim.Done && g.Sim.Mode == algorithms.ModeAVL {
+					g.showMessage("Operation replayed. Insert, delete or search another value.")
+				} else if g.Sim.Done {
 					g.showMessage("Algorithm has completed. Reset to start over.")
 				} else if g.Sim.Mode == algorithms.ModeIdle {
 					g.showMessage("Please select an algorithm first.")
-				} else if g.Sim.Mode == algorithms.ModeAVL {
-					g.showMessage("Auto stepping not applicable in AVL Tree mode.")
 				} else {
 					g.AutoStep = !g.AutoStep
 					if g.AutoStep {
@@ -357,11 +388,23 @@ func (g *Game) createButtons() {
 			Action: func() {
 				g.Sim.Reset()
 				g.AutoStep = false
+				g.clearHighlights()
 				g.showMessage("Algorithm reset. Ready for new simulation.")
 			},
 		},
 	}
 
+	// The Algorithms button opens a categorized menu of the remaining algorithms
+	algorithmsButton := &Button{
+		X: margin + 6*(buttonWidth+buttonSpacing), Y: bottomRowY, Width: buttonWidth, Height: buttonHeight,
+		Text: "Algorithms", BgColor: purpleBg, TextColor: whiteTxt, AnchorBottom: true,
+	}
+	algorithmsButton.Action = func() {
+		btnX, btnY := g.getAdjustedButtonPosition(algorithmsButton)
+		g.openAlgorithmMenu(btnX, btnY)
+	}
+	buttons = append(buttons, algorithmsButton)
+
 	// Create middle row buttons - graph modification controls
 	middleRowButtons := []*Button{
 		{
@@ -548,6 +591,54 @@ func (g *Game) createButtons() {
 	g.Buttons = buttons
 }
 
+// clearHighlights removes algorithm result highlights from the canvas and panels
+func (g *Game) clearHighlights() {
+	g.HighlightPath = nil
+	g.MatrixPanel.ClearSelection()
+}
+
+// setTargetNode chooses the target node and refreshes any source-to-target result
+func (g *Game) setTargetNode(target int) {
+	g.TargetNode = target
+	if g.Sim.Mode == algorithms.ModeDijkstra {
+		g.updateTargetPath()
+	} else if g.Sim.Mode == algorithms.ModeAStar {
+		g.runAStar(g.Sim.Source)
+	} else if g.Sim.Mode == algorithms.ModeBFS {
+		// The path is drawn as soon as BFS discovers the target
+		if path := g.Sim.BFSPathTo(target); path != nil {
+			g.showMessage(fmt.Sprintf("Shortest path to %s: %d edges", string(rune('A'+target)), len(path)-1))
+		} else {
+			g.showMessage(string(rune('A'+target)) + " has not been reached yet")
+		}
+	} else if g.isFlowMode() {
+		g.startFlow(g.Sim.Mode, g.Sim.Source)
+	} else if g.isPathListMode() {
+		g.startPathList(g.Sim.Mode, g.Sim.Source)
+	} else if g.usesTargetNode() {
+		g.startSearch(g.Sim.Mode, g.Sim.Source)
+	} else {
+		g.showMessage("Target node set to " + string(rune('A'+target)))
+	}
+}
+
+// updateTargetPath highlights the shortest path from the start node to the target node
+func (g *Game) updateTargetPath() {
+	g.HighlightPath = nil
+	if g.TargetNode < 0 || g.TargetNode >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+
+	target := string(rune('A' + g.TargetNode))
+	path, cost := g.Sim.ShortestPathTo(g.TargetNode)
+	if path == nil {
+		g.showMessage(target + " is unreachable from " + string(rune('A'+g.Sim.Source)))
+		return
+	}
+	g.HighlightPath = path
+	g.showMessage(fmt.Sprintf("Shortest path to %s: cost %.1f", target, cost))
+}
+
 // showMessage displays a temporary message to the user
 func (g *Game) showMessage(msg string) {
 	g.Message = msg
@@ -663,14 +754,32 @@ func (g *Game) removeNode(index int) {
 		g.StartNode--
 	}
 
+	// Adjust target node if necessary
+	if g.TargetNode == index {
+		g.TargetNode = -1
+	} else if g.TargetNode > index {
+		g.TargetNode--
+	}
+
+	// Custom heuristic values are keyed by node index and no longer line up
+	g.Sim.CustomHeuristic = nil
+
 	// Mark canvas for redraw
 	g.canvasNeedsRedraw = true
 }
 
+// sameEdge reports whether edge joins a and b; in a directed graph it must also run from a to b
+func (g *Game) sameEdge(edge [2]int, a, b int) bool {
+	if edge[0] == a && edge[1] == b {
+		return true
+	}
+	return !g.Sim.Graph.Directed && edge[0] == b && edge[1] == a
+}
+
 func (g *Game) addEdge(a, b int) {
 	// Check if the edge already exists
 	for _, edge := range g.Sim.Graph.Edges {
-		if (edge[0] == a && edge[1] == b) || (edge[0] == b && edge[1] == a) {
+		if g.sameEdge(edge, a, b) {
 			return // Edge already exists
 		}
 	}
@@ -678,9 +787,11 @@ func (g *Game) addEdge(a, b int) {
 	// Add the new edge
 	g.Sim.Graph.Edges = append(g.Sim.Graph.Edges, [2]int{a, b})
 
-	// Update neighbors
-	g.Sim.Graph.Nodes[a].Neighbors = append(g.Sim.Graph.Nodes[a].Neighbors, b)
-	g.Sim.Graph.Nodes[b].Neighbors = append(g.Sim.Graph.Nodes[b].Neighbors, a)
+	// Update neighbors; a directed edge only leads from a to b
+	g.addToNeighbors(a, b)
+	if !g.Sim.Graph.Directed {
+		g.addToNeighbors(b, a)
+	}
 
 	// Mark canvas for redraw
 	g.canvasNeedsRedraw = true
@@ -690,7 +801,7 @@ func (g *Game) removeEdge(a, b int) {
 	// Find and remove the edge
 	edgeIndex := -1
 	for i, edge := range g.Sim.Graph.Edges {
-		if (edge[0] == a && edge[1] == b) || (edge[0] == b && edge[1] == a) {
+		if g.sameEdge(edge, a, b) {
 			edgeIndex = i
 			break
 		}
@@ -701,7 +812,9 @@ func (g *Game) removeEdge(a, b int) {
 
 		// Update node neighbors
 		g.removeFromNeighbors(a, b)
-		g.removeFromNeighbors(b, a)
+		if !g.Sim.Graph.Directed {
+			g.removeFromNeighbors(b, a)
+		}
 
 		// Mark canvas for redraw
 		g.canvasNeedsRedraw = true
@@ -712,17 +825,32 @@ func (g *Game) removeEdge(a, b int) {
 	}
 }
 
+// addToNeighbors appends neighbor to a node's neighbors with the default weight of 1, keeping
+// the weights in line with the neighbors they belong to
+func (g *Game) addToNeighbors(nodeIndex, neighbor int) {
+	node := &g.Sim.Graph.Nodes[nodeIndex]
+	if len(node.Weights) == len(node.Neighbors) {
+		node.Weights = append(node.Weights, 1.0)
+	}
+	node.Neighbors = append(node.Neighbors, neighbor)
+}
+
 func (g *Game) removeFromNeighbors(nodeIndex, neighborToRemove int) {
-	neighbors := g.Sim.Graph.Nodes[nodeIndex].Neighbors
+	node := &g.Sim.Graph.Nodes[nodeIndex]
 	newNeighbors := []int{}
+	newWeights := []float64{}
 
-	for _, n := range neighbors {
+	for j, n := range node.Neighbors {
 		if n != neighborToRemove {
 			newNeighbors = append(newNeighbors, n)
+			if j < len(node.Weights) {
+				newWeights = append(newWeights, node.Weights[j])
+			}
 		}
 	}
 
-	g.Sim.Graph.Nodes[nodeIndex].Neighbors = newNeighbors
+	node.Neighbors = newNeighbors
+	node.Weights = newWeights
 }
 
 // clearNodeEdges removes all edges connected to a specific node
@@ -774,17 +902,18 @@ func handleKeyboardInput(g *Game) {
 	if ebiten.IsKeyPressed(ebiten.KeyR) {
 		g.Sim.Reset()
 		g.AutoStep = false
+		g.clearHighlights()
 	}
 
 	// Toggle auto-step (A key)
-	if ebiten.IsKeyPressed(ebiten.KeyA) && !g.Sim.Done && g.Sim.Mode != algorithms.ModeIdle && g.Sim.Mode != algorithms.ModeAVL {
+	if ebiten.IsKeyPressed(ebiten.KeyA) && !g.Sim.Done && g.Sim.Mode != algorithms.ModeIdle {
 		g.AutoStep = !g.AutoStep
 		// Wait to avoid repeated toggles
 		time.Sleep(200 * time.Millisecond)
 	}
 
 	// Step key (space)
-	if ebiten.IsKeyPressed(ebiten.KeySpace) && !g.Sim.Done && g.Sim.Mode != algorithms.ModeIdle && g.Sim.Mode != algorithms.ModeAVL {
+	if ebiten.IsKeyPressed(ebiten.KeySpace) && !g.Sim.Done && g.Sim.Mode != algorithms.ModeIdle {
 		g.Sim.Update()
 		// Wait to avoid too-rapid stepping
 		time.Sleep(100 * time.Millisecond)
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"bfsdfs/internal/algorithms"
+)
+
+// runAStar runs A* from source towards the target node with the selected heuristic
+// Without a target the simulator enters A* mode and waits for one to be picked
+func (g *Game) runAStar(source int) {
+	if source < 0 || source >= len(g.Sim.Graph.Nodes) {
+		g.showMessage("Please select a start node first")
+		return
+	}
+
+	g.Sim.StartAStar(source, g.TargetNode)
+	g.HighlightPath = nil
+
+	heuristic := g.Sim.Heuristic.String()
+	if g.TargetNode < 0 {
+		g.showMessage(fmt.Sprintf("A* (%s) from %s. Click a node to pick a goal.", heuristic, string(rune('A'+source))))
+		return
+	}
+
+	goal := string(rune('A' + g.TargetNode))
+	if g.Sim.Path == nil {
+		g.showMessage(fmt.Sprintf("%s is unreachable from %s", goal, string(rune('A'+source))))
+		return
+	}
+	g.HighlightPath = g.Sim.Path
+	g.showMessage(fmt.Sprintf("A* (%s) to %s: cost %.1f, %d nodes expanded", heuristic, goal, g.Sim.PathCost, len(g.Sim.Order)))
+}
+
+// openHeuristicInput opens the value modal for a custom h value ("heuristic") or the weighted A* epsilon ("epsilon")
+func (g *Game) openHeuristicInput(action string, node int) {
+	g.AVLAction = action
+	g.InputNode = node
+	g.AVLInputText = ""
+	if action == "heuristic" {
+		if value, ok := g.Sim.CustomHeuristic[node]; ok {
+			g.AVLInputText = strconv.FormatFloat(value, 'g', -1, 64)
+		}
+	} else {
+		g.AVLInputText = strconv.FormatFloat(g.Sim.Epsilon, 'g', -1, 64)
+	}
+	g.ShowAVLInput = true
+}
+
+// submitHeuristicInput applies the value typed into the modal opened by openHeuristicInput
+func (g *Game) submitHeuristicInput() {
+	value, err := strconv.ParseFloat(g.AVLInputText, 64)
+	if err != nil || value < 0 {
+		g.showMessage("Invalid number")
+		g.AVLInputText = ""
+		return
+	}
+
+	switch g.AVLAction {
+	case "heuristic":
+		if g.InputNode < 0 || g.InputNode >= len(g.Sim.Graph.Nodes) {
+			break
+		}
+		if g.Sim.CustomHeuristic == nil {
+			g.Sim.CustomHeuristic = make(map[int]float64)
+		}
+		g.Sim.CustomHeuristic[g.InputNode] = value
+		g.showMessage(fmt.Sprintf("h(%s) = %g", string(rune('A'+g.InputNode)), value))
+	case "epsilon":
+		if value < 1 {
+			g.showMessage("Epsilon must be at least 1")
+			return
+		}
+		g.Sim.Epsilon = value
+		g.showMessage(fmt.Sprintf("Weighted A* epsilon = %g", value))
+	}
+	g.ShowAVLInput = false
+
+	// Rerun the current search so the new values take effect immediately
+	if g.Sim.Mode == algorithms.ModeAStar && g.Sim.Target >= 0 {
+		g.runAStar(g.Sim.Source)
+	}
+}
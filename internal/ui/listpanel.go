@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// ListPanel displays a long list of results one page at a time beside the canvas
+// Clicking a row selects it; the footer row turns the pages
+type ListPanel struct {
+	X, Y        int
+	RowHeight   int
+	TitleHeight int
+	PageSize    int // Rows per page
+	Page        int
+	width       int // Panel width at the last draw, used for hit-testing
+	count       int // Number of items at the last draw
+}
+
+// NewListPanel creates a new list panel
+func NewListPanel() *ListPanel {
+	return &ListPanel{
+		Y:           10,
+		RowHeight:   16,
+		TitleHeight: 22,
+		PageSize:    15,
+	}
+}
+
+// Pages returns the number of pages for the items at the last draw
+func (p *ListPanel) Pages() int {
+	return (p.count + p.PageSize - 1) / p.PageSize
+}
+
+// Height returns the panel height: a full page of rows, plus the footer when there are several pages
+func (p *ListPanel) Height() int {
+	rows := p.count
+	if rows > p.PageSize {
+		rows = p.PageSize + 1
+	}
+	return p.TitleHeight + rows*p.RowHeight + 5
+}
+
+// ShowItem turns to the page holding the given item
+func (p *ListPanel) ShowItem(index int) {
+	if index >= 0 {
+		p.Page = index / p.PageSize
+	}
+}
+
+// Contains reports whether a screen point lies within the panel as last drawn
+func (p *ListPanel) Contains(x, y int) bool {
+	if p.count == 0 {
+		return false
+	}
+	return x >= p.X && x <= p.X+p.width && y >= p.Y && y <= p.Y+p.Height()
+}
+
+// HandleClick processes a click on the panel
+// Returns the index of the clicked item, or -1 if the click hit the title or turned the page
+func (p *ListPanel) HandleClick(x, y int) int {
+	if !p.Contains(x, y) {
+		return -1
+	}
+	row := (y - p.Y - p.TitleHeight) / p.RowHeight
+	if y < p.Y+p.TitleHeight || row < 0 {
+		return -1
+	}
+
+	if row == p.PageSize {
+		// Footer: the left half goes back, the right half forward
+		if x < p.X+p.width/2 {
+			if p.Page > 0 {
+				p.Page--
+			}
+		} else if p.Page < p.Pages()-1 {
+			p.Page++
+		}
+		return -1
+	}
+
+	index := p.Page*p.PageSize + row
+	if row > p.PageSize || index >= p.count {
+		return -1
+	}
+	return index
+}
+
+// Draw renders the current page anchored to the top-right corner of the screen
+// selected is the item index to tint, or -1 for none
+func (p *ListPanel) Draw(screen *ebiten.Image, title string, items []string, selected int) {
+	const charWidth = 7
+	p.count = len(items)
+	if p.count == 0 {
+		return
+	}
+	if p.Page >= p.Pages() {
+		p.Page = p.Pages() - 1
+	}
+
+	first := p.Page * p.PageSize
+	last := first + p.PageSize
+	if last > len(items) {
+		last = len(items)
+	}
+
+	p.width = 22*charWidth + 10 // Room for the footer
+	if w := len(title)*charWidth + 10; w > p.width {
+		p.width = w
+	}
+	for _, item := range items[first:last] {
+		if w := len(item)*charWidth + 10; w > p.width {
+			p.width = w
+		}
+	}
+	p.X = screen.Bounds().Dx() - p.width - 20
+
+	textColor := color.RGBA{220, 220, 220, 255}
+	draw.DrawCachedRect(screen, float64(p.X), float64(p.Y), float64(p.width), float64(p.Height()), color.RGBA{40, 40, 40, 230})
+	text.Draw(screen, title, basicfont.Face7x13, p.X+5, p.Y+15, textColor)
+
+	top := p.Y + p.TitleHeight
+	for i := first; i < last; i++ {
+		if i == selected {
+			draw.DrawCachedRect(screen, float64(p.X+3), float64(top), float64(p.width-6), float64(p.RowHeight-1), color.RGBA{70, 90, 160, 255})
+		}
+		text.Draw(screen, items[i], basicfont.Face7x13, p.X+5, top+12, textColor)
+		top += p.RowHeight
+	}
+
+	if p.Pages() > 1 {
+		top = p.Y + p.TitleHeight + p.PageSize*p.RowHeight
+		footerColor := color.RGBA{180, 180, 255, 255}
+		text.Draw(screen, "< Prev", basicfont.Face7x13, p.X+5, top+12, footerColor)
+		pageText := fmt.Sprintf("%d/%d", p.Page+1, p.Pages())
+		text.Draw(screen, pageText, basicfont.Face7x13, p.X+p.width/2-len(pageText)*charWidth/2, top+12, textColor)
+		text.Draw(screen, "Next >", basicfont.Face7x13, p.X+p.width-5-6*charWidth, top+12, footerColor)
+	}
+}
+
+// listPanelShown reports whether the current mode lists its results in the list panel
+func (g *Game) listPanelShown() bool {
+	return g.Sim.Mode == algorithms.ModeCycleEnumeration || g.isPathListMode()
+}
+
+// handleListPanelClick highlights the clicked cycle or path, or turns the page
+func (g *Game) handleListPanelClick() {
+	index := g.ListPanel.HandleClick(g.MouseX, g.MouseY)
+	if index == -1 {
+		return
+	}
+	if g.Sim.Mode == algorithms.ModeCycleEnumeration {
+		g.Sim.SelectCycle(index)
+	} else {
+		g.Sim.SelectPath(index)
+	}
+	g.canvasNeedsRedraw = true
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// matchedColor is the color of matched edges
+var matchedColor = color.RGBA{255, 140, 0, 255}
+
+// isMatchingMode reports whether the current mode checks bipartiteness or matches its sides
+func (g *Game) isMatchingMode() bool {
+	switch g.Sim.Mode {
+	case algorithms.ModeBipartite, algorithms.ModeHopcroftKarp, algorithms.ModeHungarian:
+		return true
+	}
+	return false
+}
+
+// drawMatchingEdges draws the odd cycle that rules out a bipartition, or the matched edges
+func (g *Game) drawMatchingEdges(canvas *ebiten.Image) {
+	if g.Sim.Cycle != nil {
+		cycleColor := color.RGBA{220, 20, 60, 255}
+		g.drawPath(canvas, g.Sim.Cycle, 5, cycleColor)
+		for i := 0; i+1 < len(g.Sim.Cycle); i++ {
+			g.drawNodeRing(canvas, g.Sim.Cycle[i], cycleColor)
+		}
+		return
+	}
+	for _, pair := range matchedPairs(g.Sim.Matching, g.Sim.Partition) {
+		g.drawThickEdge(canvas, pair[0], pair[1], 5, matchedColor)
+	}
+}
+
+// matchedPairs returns the matched edges, each as (left node, right node) in left-node order
+func matchedPairs(matching, partition []int) [][2]int {
+	pairs := [][2]int{}
+	for v, partner := range matching {
+		if partner != -1 && partition[v] == 0 {
+			pairs = append(pairs, [2]int{v, partner})
+		}
+	}
+	return pairs
+}
+
+// matchingStatus describes the bipartition or matching of the current mode in up to three lines
+func (g *Game) matchingStatus() (status, detail, extra string) {
+	if g.Sim.Cycle != nil {
+		status = fmt.Sprintf("%s: the graph is not bipartite", g.Sim.Mode)
+		detail = fmt.Sprintf("Odd cycle %s (%d edges) cannot be 2-colored", formatPath(g.Sim.Cycle), len(g.Sim.Cycle)-1)
+		extra = "Remove an edge of the red cycle, or use a graph whose cycles are all even"
+		return
+	}
+	if g.Sim.Partition == nil {
+		return
+	}
+	sides := [2][]int{}
+	for v, side := range g.Sim.Partition {
+		sides[side] = append(sides[side], v)
+	}
+	detail = fmt.Sprintf("Sides {%s} | {%s}", formatNodeList(sides[0]), formatNodeList(sides[1]))
+
+	pairs := matchedPairs(g.Sim.Matching, g.Sim.Partition)
+	matched := make([]string, len(pairs))
+	for i, pair := range pairs {
+		matched[i] = string(rune('A'+pair[0])) + "-" + string(rune('A'+pair[1]))
+	}
+	unmatched := []int{}
+	for v, partner := range g.Sim.Matching {
+		if partner == -1 {
+			unmatched = append(unmatched, v)
+		}
+	}
+
+	switch g.Sim.Mode {
+	case algorithms.ModeBipartite:
+		status = "The graph is bipartite"
+		extra = "Every edge joins a teal node to an orchid one"
+		if g.Sim.Graph.Directed {
+			extra += "; edge directions are ignored"
+		}
+		return
+	case algorithms.ModeHopcroftKarp:
+		status = fmt.Sprintf("Hopcroft-Karp: maximum matching of %d edges after %d phases", len(pairs), g.Sim.MatchingPhases)
+	case algorithms.ModeHungarian:
+		status = fmt.Sprintf("Hungarian: %d pairs assigned with total weight %.1f", len(pairs), g.Sim.MatchingCost)
+	}
+	detail += "; matched (orange): " + strings.Join(matched, " ")
+	extra = "Unmatched: " + formatNodeList(unmatched)
+	return
+}
+
+// drawAssignmentPanel lists every teal node with its partner and the weight of their edge
+func (g *Game) drawAssignmentPanel(screen *ebiten.Image) {
+	if g.Sim.Matching == nil {
+		return
+	}
+	header := []string{"Node", "Partner", "Weight"}
+	rows := [][]string{}
+	for v, side := range g.Sim.Partition {
+		if side != 0 {
+			continue
+		}
+		partner, weight := "-", "-"
+		if p := g.Sim.Matching[v]; p != -1 {
+			partner = string(rune('A' + p))
+			if w, ok := g.Sim.Graph.EdgeWeight(v, p); ok {
+				weight = fmt.Sprintf("%.1f", w)
+			} else if w, ok := g.Sim.Graph.EdgeWeight(p, v); ok {
+				weight = fmt.Sprintf("%.1f", w)
+			}
+		}
+		rows = append(rows, []string{string(rune('A' + v)), partner, weight})
+	}
+	drawTablePanel(screen, g.Sim.Mode.String(), header, rows, -1)
+}
+
+// showMatchingMessage shows msg, or explains that the graph has no bipartition
+func (g *Game) showMatchingMessage(msg string) {
+	if g.Sim.Cycle != nil {
+		g.showMessage("Not bipartite: the odd cycle is red")
+		return
+	}
+	g.showMessage(msg)
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// MatrixPanel displays an all-pairs distance or next-hop matrix beside the canvas
+type MatrixPanel struct {
+	X, Y                  int
+	CellWidth, CellHeight int
+	LabelWidth            int
+	TitleHeight           int
+	ShowNext              bool // Show the next-hop matrix instead of distances
+	SelectedRow           int  // Row of the clicked cell, -1 if none
+	SelectedCol           int  // Column of the clicked cell, -1 if none
+	size                  int  // Matrix size at the last draw, used for hit-testing
+}
+
+// NewMatrixPanel creates a new matrix panel
+func NewMatrixPanel() *MatrixPanel {
+	return &MatrixPanel{
+		Y:           10,
+		CellWidth:   36,
+		CellHeight:  16,
+		LabelWidth:  20,
+		TitleHeight: 22,
+		SelectedRow: -1,
+		SelectedCol: -1,
+	}
+}
+
+// Width returns the panel width for an n x n matrix
+func (p *MatrixPanel) Width(n int) int {
+	return p.LabelWidth + n*p.CellWidth + 10
+}
+
+// Height returns the panel height for an n x n matrix
+func (p *MatrixPanel) Height(n int) int {
+	return p.TitleHeight + (n+1)*p.CellHeight + 5
+}
+
+// ClearSelection forgets the selected cell
+func (p *MatrixPanel) ClearSelection() {
+	p.SelectedRow = -1
+	p.SelectedCol = -1
+}
+
+// Contains reports whether a screen point lies within the panel as last drawn
+func (p *MatrixPanel) Contains(x, y int) bool {
+	if p.size == 0 {
+		return false
+	}
+	return x >= p.X && x <= p.X+p.Width(p.size) && y >= p.Y && y <= p.Y+p.Height(p.size)
+}
+
+// HandleClick processes a click on the panel
+// Clicking the title toggles between distances and next hops
+// Returns the clicked cell, or -1, -1 if no cell was clicked
+func (p *MatrixPanel) HandleClick(x, y int) (int, int) {
+	if !p.Contains(x, y) {
+		return -1, -1
+	}
+
+	if y < p.Y+p.TitleHeight {
+		p.ShowNext = !p.ShowNext
+		return -1, -1
+	}
+
+	cellsX := p.X + 5 + p.LabelWidth
+	cellsY := p.Y + p.TitleHeight + p.CellHeight
+	if x < cellsX || y < cellsY {
+		return -1, -1
+	}
+
+	row := (y - cellsY) / p.CellHeight
+	col := (x - cellsX) / p.CellWidth
+	if row >= p.size || col >= p.size {
+		return -1, -1
+	}
+
+	p.SelectedRow = row
+	p.SelectedCol = col
+	return row, col
+}
+
+// Draw renders the matrix anchored to the top-right corner of the screen
+// focusRow/focusCol tint a row and column (-1 for none); updated marks recently improved cells
+func (p *MatrixPanel) Draw(screen *ebiten.Image, title string, result *algorithms.AllPairsResult, focusRow, focusCol int, updated [][]bool) {
+	n := len(result.Dist)
+	p.size = n
+	if n == 0 {
+		return
+	}
+
+	width := p.Width(n)
+	height := p.Height(n)
+	p.X = screen.Bounds().Dx() - width - 20
+
+	// Panel background, matching the context menu style
+	draw.DrawCachedRect(screen, float64(p.X), float64(p.Y), float64(width), float64(height), color.RGBA{40, 40, 40, 230})
+
+	// Title with the current view; clicking it toggles the view
+	view := "Distances"
+	if p.ShowNext {
+		view = "Next hops"
+	}
+	titleText := fmt.Sprintf("%s - %s (click to toggle)", title, view)
+	text.Draw(screen, titleText, basicfont.Face7x13, p.X+5, p.Y+15, color.RGBA{220, 220, 220, 255})
+
+	cellsX := p.X + 5 + p.LabelWidth
+	cellsY := p.Y + p.TitleHeight + p.CellHeight
+	headerColor := color.RGBA{180, 180, 255, 255}
+
+	// Column and row headers
+	for i := 0; i < n; i++ {
+		label := string(rune('A' + i))
+		text.Draw(screen, label, basicfont.Face7x13, cellsX+i*p.CellWidth+p.CellWidth/2-3, cellsY-4, headerColor)
+		text.Draw(screen, label, basicfont.Face7x13, p.X+8, cellsY+i*p.CellHeight+12, headerColor)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			x := cellsX + j*p.CellWidth
+			y := cellsY + i*p.CellHeight
+
+			// Cell tint: selection beats recent updates beats focus row/column
+			var tint color.RGBA
+			switch {
+			case i == p.SelectedRow && j == p.SelectedCol:
+				tint = color.RGBA{70, 90, 160, 255}
+			case updated != nil && i < len(updated) && j < len(updated[i]) && updated[i][j]:
+				tint = color.RGBA{50, 130, 50, 255}
+			case i == focusRow || j == focusCol:
+				tint = color.RGBA{110, 80, 30, 255}
+			}
+			if tint.A > 0 {
+				draw.DrawCachedRect(screen, float64(x), float64(y), float64(p.CellWidth-1), float64(p.CellHeight-1), tint)
+			}
+
+			var cell string
+			if p.ShowNext {
+				cell = "-"
+				if next := result.Next[i][j]; next >= 0 {
+					cell = string(rune('A' + next))
+				}
+			} else {
+				cell = "-"
+				if d := result.Dist[i][j]; !math.IsInf(d, 1) {
+					cell = fmt.Sprintf("%.1f", d)
+				}
+			}
+			text.Draw(screen, cell, basicfont.Face7x13, x+3, y+12, color.RGBA{220, 220, 220, 255})
+		}
+	}
+}
+
+// handleMatrixClick highlights the reconstructed path for the clicked matrix cell
+func (g *Game) handleMatrixClick() {
+	result := g.Sim.GetAllPairs()
+	row, col := g.MatrixPanel.HandleClick(g.MouseX, g.MouseY)
+	if result == nil || row == -1 {
+		return
+	}
+
+	from := string(rune('A' + row))
+	to := string(rune('A' + col))
+	path := algorithms.ReconstructPath(result.Next, row, col)
+	if path == nil {
+		g.HighlightPath = nil
+		g.showMessage(fmt.Sprintf("No path from %s to %s yet", from, to))
+		return
+	}
+
+	g.HighlightPath = path
+	g.showMessage(fmt.Sprintf("Path %s to %s: cost %.1f", from, to, result.Dist[row][col]))
+}
@@ -0,0 +1,336 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/internal/graph"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// mazeBrush is what a click paints onto a maze cell
+type mazeBrush int
+
+const (
+	brushWall mazeBrush = iota
+	brushTerrain
+	brushErase
+	brushStart
+	brushGoal
+)
+
+// mazeBrushes lists every brush, in menu order
+var mazeBrushes = []mazeBrush{brushWall, brushTerrain, brushErase, brushStart, brushGoal}
+
+// String returns the brush name shown in the menu and status line
+func (b mazeBrush) String() string {
+	switch b {
+	case brushTerrain:
+		return "Terrain"
+	case brushErase:
+		return "Erase"
+	case brushStart:
+		return "Start"
+	case brushGoal:
+		return "Goal"
+	}
+	return "Wall"
+}
+
+// mazeSearches lists the searches that run on the maze, in menu order
+var mazeSearches = []algorithms.TraversalMode{
+	algorithms.ModeBFS,
+	algorithms.ModeDFS,
+	algorithms.ModeDijkstra,
+	algorithms.ModeAStar,
+}
+
+// openMaze covers the grid canvas with a cell map of the grid's cell size
+// The size is odd so the maze generators can use every row and column
+func (g *Game) openMaze() {
+	if g.Sim.Maze != nil {
+		g.showMessage("The maze grid is already open")
+		return
+	}
+	if g.Sim.Mode != algorithms.ModeIdle {
+		g.showMessage("Reset first to open the maze grid.")
+		return
+	}
+
+	cells := 1000 / g.GridConfig.CellSize // The grid canvas is 1000 pixels square
+	if cells%2 == 0 {
+		cells--
+	}
+	g.AutoStep = false
+	g.clearHighlights()
+	g.Sim.OpenMaze(graph.NewGridMap(cells, cells, g.GridConfig.CellSize))
+	g.canvasNeedsRedraw = true
+	g.showMessage("Paint walls with the left button, erase with the right")
+}
+
+// closeMaze leaves the maze mode and brings back the drawn graph
+func (g *Game) closeMaze() {
+	g.AutoStep = false
+	g.mazePainting = false
+	g.Sim.CloseMaze()
+	g.clearHighlights()
+	g.canvasNeedsRedraw = true
+	g.showMessage("Back to the graph")
+}
+
+// requireMaze reports whether the maze grid is open, telling the user how to open it otherwise
+func (g *Game) requireMaze() bool {
+	if g.Sim.Maze == nil {
+		g.showMessage("Open the maze grid first: Algorithms > Maze > Open Maze Grid")
+		return false
+	}
+	return true
+}
+
+// startMazeSearch starts a stepped search from the maze start to its goal
+func (g *Game) startMazeSearch(kind algorithms.TraversalMode) {
+	if !g.requireMaze() {
+		return
+	}
+	g.AutoStep = false
+	g.Sim.StartMazeSearch(kind)
+	g.canvasNeedsRedraw = true
+	g.showMessage(kind.String() + " started on the maze. Press Step or Auto.")
+}
+
+// generateMaze replaces the maze with a new one from the chosen generator
+func (g *Game) generateMaze(kind algorithms.MazeKind) {
+	if !g.requireMaze() {
+		return
+	}
+	g.AutoStep = false
+	maze := g.Sim.Maze
+	maze.Generate(kind, rand.New(rand.NewSource(time.Now().UnixNano())))
+	g.Sim.OpenMaze(maze)
+	g.canvasNeedsRedraw = true
+	g.showMessage(kind.String() + " maze generated")
+}
+
+// setMazeDiagonal switches the maze between 4- and 8-connectivity
+func (g *Game) setMazeDiagonal(diagonal bool) {
+	if !g.requireMaze() {
+		return
+	}
+	g.AutoStep = false
+	maze := g.Sim.Maze
+	maze.Diagonal = diagonal
+	g.Sim.OpenMaze(maze)
+	g.canvasNeedsRedraw = true
+	if diagonal {
+		g.showMessage("Cells connect to all 8 neighbors; diagonals cost √2 times more")
+	} else {
+		g.showMessage("Cells connect to their 4 side neighbors")
+	}
+}
+
+// clearMaze removes every wall and terrain cost
+func (g *Game) clearMaze() {
+	if !g.requireMaze() {
+		return
+	}
+	g.AutoStep = false
+	maze := g.Sim.Maze
+	maze.Clear()
+	g.Sim.OpenMaze(maze)
+	g.canvasNeedsRedraw = true
+	g.showMessage("Maze cleared")
+}
+
+// mazeCellAt returns the maze cell under a screen position, -1 if there is none
+func (g *Game) mazeCellAt(screenX, screenY int) int {
+	return g.Sim.Maze.CellAt(screenX-int(g.CanvasOffsetX), screenY-int(g.CanvasOffsetY))
+}
+
+// paintMazeCell applies a brush to the cell under a screen position
+// Any change clears the running search, since the graph it searched no longer exists
+func (g *Game) paintMazeCell(screenX, screenY int, brush mazeBrush) {
+	maze := g.Sim.Maze
+	cell := g.mazeCellAt(screenX, screenY)
+	if cell == -1 {
+		return
+	}
+
+	wall, cost := maze.Walls[cell], maze.Costs[cell]
+	start, goal := maze.Start, maze.Goal
+	switch brush {
+	case brushWall:
+		if cell != maze.Start && cell != maze.Goal {
+			wall, cost = true, 1
+		}
+	case brushTerrain:
+		wall, cost = false, g.MazeTerrainCost
+	case brushErase:
+		wall, cost = false, 1
+	case brushStart:
+		if cell != maze.Goal {
+			wall, start = false, cell
+		}
+	case brushGoal:
+		if cell != maze.Start {
+			wall, goal = false, cell
+		}
+	}
+	if wall == maze.Walls[cell] && cost == maze.Costs[cell] && start == maze.Start && goal == maze.Goal {
+		return
+	}
+
+	maze.Walls[cell], maze.Costs[cell] = wall, cost
+	maze.Start, maze.Goal = start, goal
+	g.AutoStep = false
+	g.Sim.OpenMaze(maze)
+	g.canvasNeedsRedraw = true
+}
+
+// mazeStepsPerFrame returns how many search steps auto-stepping runs each frame on the maze
+// Mazes have hundreds of cells, so the speed slider scales from 1 to 11 steps a frame
+func (g *Game) mazeStepsPerFrame() int {
+	return 1 + (50-g.StepDelay)/4
+}
+
+// terrainColor shades open ground from light to dark brown as its cost rises to 10
+func terrainColor(cost float64) color.RGBA {
+	t := (cost - 1) / 9
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+	return color.RGBA{blend(250, 150), blend(250, 100), blend(250, 50), 255}
+}
+
+// drawMaze draws the cells colored by their state, the search progress and the path found
+func (g *Game) drawMaze(canvas *ebiten.Image) {
+	maze := g.Sim.Maze
+	size := float64(maze.CellSize)
+	var frontier map[int]bool
+	if search := g.Sim.GetMazeSearch(); search != nil {
+		frontier = search.Frontier()
+	}
+
+	for cell := range maze.Walls {
+		x := float64((cell%maze.Cols)*maze.CellSize) + g.CanvasOffsetX
+		y := float64((cell/maze.Cols)*maze.CellSize) + g.CanvasOffsetY
+
+		clr := terrainColor(maze.Costs[cell])
+		switch {
+		case maze.Walls[cell]:
+			clr = color.RGBA{50, 50, 60, 255}
+		case cell == maze.Start:
+			clr = color.RGBA{50, 205, 50, 255}
+		case cell == maze.Goal:
+			clr = color.RGBA{220, 20, 60, 255}
+		case cell == g.Sim.Current && !g.Sim.Done:
+			clr = color.RGBA{255, 140, 0, 255}
+		case frontier[cell]:
+			clr = color.RGBA{255, 215, 0, 255}
+		case g.Sim.Visited[cell]:
+			clr = color.RGBA{150, 200, 240, 255}
+		}
+		draw.DrawCachedRect(canvas, x, y, size, size, clr)
+
+		// Terrain keeps its cost visible under the search colors
+		if !maze.Walls[cell] && maze.Costs[cell] != 1 && maze.CellSize >= 16 {
+			label := strconv.FormatFloat(maze.Costs[cell], 'g', 2, 64)
+			text.Draw(canvas, label, basicfont.Face7x13, int(x)+2, int(y)+maze.CellSize/2+4, color.RGBA{60, 40, 20, 255})
+		}
+	}
+
+	// Cell borders, so single cells can be told apart while painting
+	lineColor := color.RGBA{200, 200, 200, 255}
+	width, height := float64(maze.Cols*maze.CellSize), float64(maze.Rows*maze.CellSize)
+	for col := 0; col <= maze.Cols; col++ {
+		x := float64(col*maze.CellSize) + g.CanvasOffsetX
+		draw.DrawCachedLine(canvas, x, g.CanvasOffsetY, x, g.CanvasOffsetY+height, lineColor)
+	}
+	for row := 0; row <= maze.Rows; row++ {
+		y := float64(row*maze.CellSize) + g.CanvasOffsetY
+		draw.DrawCachedLine(canvas, g.CanvasOffsetX, y, g.CanvasOffsetX+width, y, lineColor)
+	}
+
+	g.drawPath(canvas, g.Sim.Path, 4, color.RGBA{0, 0, 200, 255})
+}
+
+// mazeStatus describes the maze and the running search in up to three lines
+func (g *Game) mazeStatus() (status, detail, extra string) {
+	maze := g.Sim.Maze
+	connectivity := "4-connected"
+	if maze.Diagonal {
+		connectivity = "8-connected"
+	}
+	search := g.Sim.GetMazeSearch()
+	if search == nil {
+		status = fmt.Sprintf("Maze %dx%d (%s), brush: %s", maze.Cols, maze.Rows, connectivity, g.MazeBrush)
+		if g.MazeBrush == brushTerrain {
+			status += fmt.Sprintf(" (cost %g)", g.MazeTerrainCost)
+		}
+		detail = "Left button paints, right button erases; start is green, goal red"
+		extra = "Algorithms > Maze runs a search, generates a maze or picks another brush"
+		return
+	}
+
+	kind := g.Sim.MazeSearch
+	status = fmt.Sprintf("%s on the %s maze: %d cells expanded, %d on the frontier",
+		kind, connectivity, len(search.Order), len(search.Frontier()))
+	if kind == algorithms.ModeAStar {
+		status += fmt.Sprintf(", heuristic: %s", g.Sim.MazeHeuristic())
+	}
+
+	switch {
+	case !g.Sim.Done:
+		detail = "Searching: expanded cells are blue, the frontier yellow, the current cell orange"
+	case g.Sim.Path == nil:
+		detail = "The goal cannot be reached from the start"
+	default:
+		detail = fmt.Sprintf("Path of %d moves, cost %.1f", len(g.Sim.Path)-1, g.Sim.PathCost)
+	}
+
+	switch kind {
+	case algorithms.ModeBFS:
+		extra = "BFS expands cells in order of moves from the start; it ignores terrain costs"
+	case algorithms.ModeDFS:
+		extra = "DFS follows one corridor as far as it leads; its path is rarely the shortest"
+	case algorithms.ModeDijkstra:
+		extra = "Dijkstra expands cells in order of path cost, detouring around terrain when that is cheaper"
+	default:
+		extra = "A* adds an estimate of the remaining cost, so it expands fewer cells than Dijkstra"
+	}
+	return
+}
+
+// openTerrainCostInput opens the value modal for the cost the terrain brush paints
+func (g *Game) openTerrainCostInput() {
+	g.AVLAction = "terrain cost"
+	g.AVLInputText = strconv.FormatFloat(g.MazeTerrainCost, 'g', -1, 64)
+	g.ShowAVLInput = true
+}
+
+// submitTerrainCostInput applies the terrain cost typed into the modal
+func (g *Game) submitTerrainCostInput() {
+	cost, err := strconv.ParseFloat(g.AVLInputText, 64)
+	if err != nil || cost <= 0 {
+		g.showMessage("Invalid terrain cost")
+		g.AVLInputText = ""
+		return
+	}
+
+	g.MazeTerrainCost = cost
+	g.MazeBrush = brushTerrain
+	g.ShowAVLInput = false
+	g.showMessage(fmt.Sprintf("The terrain brush paints cells that cost %g to enter", cost))
+}
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// showCentrality opens the graph metrics with nodes scored by the given centrality
+// Switching between centralities is allowed while the metrics are shown
+func (g *Game) showCentrality(kind algorithms.Centrality) {
+	if g.Sim.Mode != algorithms.ModeIdle && g.Sim.Mode != algorithms.ModeMetrics {
+		g.showMessage("Reset first to start another algorithm.")
+		return
+	}
+	if len(g.Sim.Graph.Nodes) == 0 {
+		g.showMessage("Add some nodes first.")
+		return
+	}
+
+	g.AutoStep = false
+	g.clearHighlights()
+	g.Sim.CentralityKind = kind
+	g.Sim.StartMetrics()
+	g.canvasNeedsRedraw = true
+
+	display := "color"
+	if g.MetricAsSize {
+		display = "size"
+	}
+	g.showMessage(fmt.Sprintf("Node %s shows %s centrality", display, kind))
+}
+
+// centralityShare returns a node's centrality relative to the highest score, in [0, 1]
+func (g *Game) centralityShare(node int) (float64, bool) {
+	scores := g.Sim.Centrality
+	if g.Sim.Mode != algorithms.ModeMetrics || node < 0 || node >= len(scores) {
+		return 0, false
+	}
+	highest := 0.0
+	for _, score := range scores {
+		if score > highest {
+			highest = score
+		}
+	}
+	if highest == 0 {
+		return 0, true
+	}
+	return scores[node] / highest, true
+}
+
+// nodeRadius returns the radius a node is drawn with: 20, or 12 to 30 by centrality when the
+// graph metrics scale node size
+func (g *Game) nodeRadius(node int) int {
+	if !g.MetricAsSize {
+		return 20
+	}
+	if share, ok := g.centralityShare(node); ok {
+		return 12 + int(share*18)
+	}
+	return 20
+}
+
+// formatCentrality renders a centrality score with the precision its scale needs
+func formatCentrality(kind algorithms.Centrality, score float64) string {
+	switch kind {
+	case algorithms.CentralityDegree:
+		return fmt.Sprintf("%.0f", score)
+	case algorithms.CentralityBetweenness:
+		return fmt.Sprintf("%.1f", score)
+	}
+	return fmt.Sprintf("%.3f", score)
+}
+
+// drawCentralityLabels labels every node with its score beside its rim
+func (g *Game) drawCentralityLabels(canvas *ebiten.Image) {
+	for v, score := range g.Sim.Centrality {
+		g.drawNodeText(canvas, v, formatCentrality(g.Sim.CentralityKind, score), g.nodeRadius(v)+2, -g.nodeRadius(v)+6, color.RGBA{0, 0, 200, 255})
+	}
+}
+
+// rankedNodes returns the nodes by decreasing centrality, ties by index
+func (g *Game) rankedNodes() []int {
+	nodes := make([]int, len(g.Sim.Centrality))
+	for v := range nodes {
+		nodes[v] = v
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return g.Sim.Centrality[nodes[i]] > g.Sim.Centrality[nodes[j]]
+	})
+	return nodes
+}
+
+// metricsStatus summarizes the graph statistics and the most central nodes in up to three lines
+func (g *Game) metricsStatus() (status, detail, extra string) {
+	stats := g.Sim.Stats
+	if stats == nil {
+		return
+	}
+	status = fmt.Sprintf("Graph metrics: %d nodes, %d edges, density %.2f, %d components",
+		stats.Nodes, stats.Edges, stats.Density, stats.Components)
+	detail = fmt.Sprintf("Diameter %d, radius %s, girth %s, clustering %.2f",
+		stats.Diameter, formatRadius(stats.Radius), formatGirth(stats.Girth), stats.Clustering)
+	if !stats.Connected {
+		detail += " (not every node reaches every other: the diameter spans the pairs that do)"
+	}
+
+	display := "color"
+	if g.MetricAsSize {
+		display = "size"
+	}
+	top := []string{}
+	for _, v := range g.rankedNodes() {
+		if len(top) == 3 {
+			break
+		}
+		top = append(top, fmt.Sprintf("%s (%s)", nodeName(v), formatCentrality(g.Sim.CentralityKind, g.Sim.Centrality[v])))
+	}
+	extra = fmt.Sprintf("Node %s shows %s centrality; most central: %s", display, g.Sim.CentralityKind, strings.Join(top, ", "))
+	return
+}
+
+// formatGirth renders the shortest cycle length, which is absent in a forest or DAG
+func formatGirth(girth int) string {
+	if girth == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%d", girth)
+}
+
+// formatRadius renders the radius, which is infinite when no node reaches every other
+func formatRadius(radius int) string {
+	if radius == -1 {
+		return "infinite"
+	}
+	return fmt.Sprintf("%d", radius)
+}
+
+// drawMetricsPanel lists the graph statistics and the ten most central nodes
+func (g *Game) drawMetricsPanel(screen *ebiten.Image) {
+	stats := g.Sim.Stats
+	if stats == nil {
+		return
+	}
+	degrees := []string{}
+	for d, count := range stats.DegreeCounts {
+		if count > 0 {
+			degrees = append(degrees, fmt.Sprintf("%d:%d", d, count))
+		}
+	}
+
+	header := []string{"Statistic", "Value"}
+	rows := [][]string{
+		{"Nodes", fmt.Sprintf("%d", stats.Nodes)},
+		{"Edges", fmt.Sprintf("%d", stats.Edges)},
+		{"Density", fmt.Sprintf("%.3f", stats.Density)},
+		{"Degrees (deg:nodes)", strings.Join(degrees, " ")},
+		{"Components", fmt.Sprintf("%d", stats.Components)},
+		{"Diameter", fmt.Sprintf("%d", stats.Diameter)},
+		{"Radius", formatRadius(stats.Radius)},
+		{"Girth", formatGirth(stats.Girth)},
+		{"Clustering", fmt.Sprintf("%.3f", stats.Clustering)},
+		{"", ""},
+		{"Node", g.Sim.CentralityKind.String()},
+	}
+	for i, v := range g.rankedNodes() {
+		if i == 10 {
+			break
+		}
+		rows = append(rows, []string{nodeName(v), formatCentrality(g.Sim.CentralityKind, g.Sim.Centrality[v])})
+	}
+	highlight := -1
+	if len(g.Sim.Centrality) > 0 {
+		highlight = 11 // The most central node
+	}
+	drawTablePanel(screen, "Graph metrics", header, rows, highlight)
+}
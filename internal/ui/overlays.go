@@ -0,0 +1,748 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// drawEdgeOverlays draws algorithm results that belong between the edges and the nodes
+func (g *Game) drawEdgeOverlays(canvas *ebiten.Image) {
+	switch g.Sim.Mode {
+	case algorithms.ModeDijkstra:
+		g.drawShortestPathTree(canvas)
+	case algorithms.ModeBidirectional:
+		g.drawBidirectionalFrontiers(canvas)
+	case algorithms.ModeDepthLimited, algorithms.ModeIDDFS:
+		g.drawDepthLimitedTree(canvas)
+	case algorithms.ModeUCS:
+		g.drawUCSFrontier(canvas)
+	case algorithms.ModeDFSTimes:
+		g.drawClassifiedEdges(canvas)
+	case algorithms.ModeBFS:
+		g.drawBFSTree(canvas)
+	case algorithms.ModeKruskal:
+		g.drawKruskalEdges(canvas)
+	case algorithms.ModePrim:
+		g.drawPrimCut(canvas)
+	case algorithms.ModeBoruvka:
+		g.drawBoruvkaEdges(canvas)
+	case algorithms.ModeReverseDelete:
+		g.drawReverseDeleteEdges(canvas)
+	case algorithms.ModeMSTCompare:
+		g.drawMSTDifferences(canvas)
+	case algorithms.ModeTarjan:
+		g.drawTarjanEdges(canvas)
+	case algorithms.ModeTopological, algorithms.ModeKahn:
+		g.drawTopologicalEdges(canvas)
+	case algorithms.ModeBridges, algorithms.ModeBiconnected:
+		g.drawConnectivityEdges(canvas)
+	case algorithms.ModeEdmondsKarp, algorithms.ModeDinic:
+		g.drawFlowEdges(canvas)
+	case algorithms.ModeBipartite, algorithms.ModeHopcroftKarp, algorithms.ModeHungarian:
+		g.drawMatchingEdges(canvas)
+	case algorithms.ModeGreedyColoring, algorithms.ModeWelshPowell, algorithms.ModeDSatur, algorithms.ModeExactColoring:
+		g.drawColoringRing(canvas)
+	case algorithms.ModeEuler:
+		g.drawEulerEdges(canvas)
+	case algorithms.ModeHamiltonianPath, algorithms.ModeHamiltonianCycle:
+		g.drawHamiltonianPath(canvas)
+	case algorithms.ModeNearestNeighborTSP, algorithms.ModeTwoOpt, algorithms.ModeMSTTour, algorithms.ModeHeldKarp, algorithms.ModeTSPCompare:
+		g.drawTourEdges(canvas)
+	case algorithms.ModeCycleDetect, algorithms.ModeCycleEnumeration:
+		g.drawCycleEdges(canvas)
+	}
+
+	// Paths found by the stepped searches
+	if g.usesTargetNode() && g.Sim.Mode != algorithms.ModeAStar {
+		g.drawPath(canvas, g.Sim.Path, 5, color.RGBA{255, 140, 0, 255})
+	}
+
+	// The highlighted path is drawn last so it stays visible above other overlays
+	g.drawHighlightPath(canvas)
+}
+
+// drawNodeOverlays draws per-node algorithm annotations on top of the nodes
+func (g *Game) drawNodeOverlays(canvas *ebiten.Image) {
+	switch g.Sim.Mode {
+	case algorithms.ModeDijkstra:
+		g.drawDistanceLabels(canvas)
+	case algorithms.ModeAStar:
+		g.drawHeuristicLabels(canvas)
+	case algorithms.ModeBidirectional:
+		g.drawBidirectionalLabels(canvas)
+	case algorithms.ModeDepthLimited, algorithms.ModeIDDFS:
+		g.drawDepthLabels(canvas)
+	case algorithms.ModeUCS:
+		g.drawCostLabels(canvas)
+	case algorithms.ModeDFSTimes:
+		g.drawTimestampLabels(canvas)
+	case algorithms.ModeBFS:
+		g.drawLevelLabels(canvas)
+	case algorithms.ModeTarjan:
+		g.drawLowlinkLabels(canvas)
+	case algorithms.ModeTopological, algorithms.ModeKahn:
+		g.drawTopologicalLabels(canvas)
+	case algorithms.ModeDinic:
+		g.drawDinicLevels(canvas)
+	case algorithms.ModeGreedyColoring, algorithms.ModeWelshPowell, algorithms.ModeDSatur, algorithms.ModeExactColoring:
+		g.drawColoringLabels(canvas)
+	case algorithms.ModeEuler:
+		g.drawEulerLabels(canvas)
+	case algorithms.ModeHamiltonianPath, algorithms.ModeHamiltonianCycle:
+		g.drawHamiltonianLabels(canvas)
+	case algorithms.ModeNearestNeighborTSP, algorithms.ModeTwoOpt, algorithms.ModeMSTTour, algorithms.ModeHeldKarp, algorithms.ModeTSPCompare:
+		g.drawTourLabels(canvas)
+	case algorithms.ModeMetrics:
+		g.drawCentralityLabels(canvas)
+	case algorithms.ModeKShortestPaths, algorithms.ModeAllSimplePaths:
+		g.drawPathCost(canvas)
+	case algorithms.ModeBridges, algorithms.ModeBiconnected:
+		if g.Sim.Connectivity != nil {
+			for _, v := range g.Sim.Connectivity.ArticulationPoints {
+				g.drawSquareFrame(canvas, v, color.RGBA{139, 0, 0, 255})
+			}
+		}
+	}
+}
+
+// nodeColorOverride returns an algorithm-specific fill color for a node, if the current mode uses one
+func (g *Game) nodeColorOverride(node int) (color.RGBA, bool) {
+	switch g.Sim.Mode {
+	case algorithms.ModeBidirectional:
+		b := g.Sim.GetBidirectional()
+		if b == nil {
+			break
+		}
+		if node == b.Meeting {
+			return meetingColor, true
+		}
+		if _, ok := b.Dist[0][node]; ok {
+			return forwardColor, true
+		}
+		if _, ok := b.Dist[1][node]; ok {
+			return backwardColor, true
+		}
+	case algorithms.ModeMetrics:
+		// Central nodes shade toward red unless centrality drives the node size
+		if share, ok := g.centralityShare(node); ok && !g.MetricAsSize {
+			return heatColor(share), true
+		}
+	case algorithms.ModeBFS:
+		// Visited nodes take the color of their BFS level
+		if level, ok := g.Sim.Levels[node]; ok && g.Sim.Visited[node] {
+			return paletteColor(level), true
+		}
+	case algorithms.ModeKruskal:
+		if k := g.Sim.GetKruskal(); k != nil {
+			return componentColor(k.Sets, node)
+		}
+	case algorithms.ModeBoruvka:
+		if b := g.Sim.GetBoruvka(); b != nil {
+			return componentColor(b.Sets, node)
+		}
+	case algorithms.ModePrim:
+		// Every tree of the spanning forest gets its own color
+		if p := g.Sim.GetPrim(); p != nil && node < len(p.TreeOf) && p.TreeOf[node] != -1 {
+			return paletteColor(p.TreeOf[node]), true
+		}
+	case algorithms.ModeDFSTimes:
+		d := g.Sim.GetDFSTimes()
+		if d == nil || node >= len(d.Discovery) {
+			break
+		}
+		if d.Finish[node] > 0 {
+			return color.RGBA{50, 50, 50, 255}, true // Black: finished
+		}
+		if d.Discovery[node] > 0 {
+			return color.RGBA{150, 150, 150, 255}, true // Gray: discovered, still on the stack
+		}
+	case algorithms.ModeTarjan:
+		t := g.Sim.GetTarjan()
+		if t == nil || node >= len(t.Component) {
+			break
+		}
+		if t.Component[node] >= 0 {
+			return paletteColor(t.Component[node]), true
+		}
+		if t.OnStack[node] {
+			return color.RGBA{150, 150, 150, 255}, true // Gray: waiting on the component stack
+		}
+	case algorithms.ModeEdmondsKarp, algorithms.ModeDinic:
+		// Minimum cut sides, once the flow is maximum
+		if _, _, sourceSide := g.flowState(); node < len(sourceSide) {
+			if sourceSide[node] {
+				return forwardColor, true
+			}
+			return backwardColor, true
+		}
+	case algorithms.ModeBipartite, algorithms.ModeHopcroftKarp, algorithms.ModeHungarian:
+		// The two sides of the bipartition
+		if node < len(g.Sim.Partition) {
+			if g.Sim.Partition[node] == 0 {
+				return forwardColor, true
+			}
+			return backwardColor, true
+		}
+	case algorithms.ModeConnected:
+		for i, component := range g.Sim.Components {
+			for _, v := range component {
+				if v == node {
+					return paletteColor(i), true
+				}
+			}
+		}
+	case algorithms.ModeBiconnected:
+		// Nodes inside a single block take its color; cut vertices belong to several
+		if g.Sim.Connectivity == nil {
+			break
+		}
+		for _, v := range g.Sim.Connectivity.ArticulationPoints {
+			if v == node {
+				return color.RGBA{}, false
+			}
+		}
+		for i, nodes := range g.Sim.Connectivity.BlockNodes() {
+			for _, v := range nodes {
+				if v == node {
+					return paletteColor(i), true
+				}
+			}
+		}
+	case algorithms.ModeKosaraju:
+		for i, scc := range g.Sim.SCCs {
+			for _, v := range scc {
+				if v == node {
+					return paletteColor(i), true
+				}
+			}
+		}
+	}
+	return color.RGBA{}, false
+}
+
+// Colors of the two bidirectional search frontiers
+var (
+	forwardColor  = color.RGBA{0, 160, 160, 255}   // Teal for the search from the source
+	backwardColor = color.RGBA{200, 90, 200, 255}  // Orchid for the search from the target
+	meetingColor  = color.RGBA{255, 200, 0, 255}   // Gold for the node where they meet
+	frontierColor = color.RGBA{255, 215, 100, 255} // Light gold ring for nodes waiting to be expanded
+)
+
+// drawHighlightPath draws g.HighlightPath as a thick line through its nodes
+func (g *Game) drawHighlightPath(canvas *ebiten.Image) {
+	g.drawPath(canvas, g.HighlightPath, 5, color.RGBA{255, 140, 0, 255}) // Dark orange
+}
+
+// drawPath draws a thick line through a sequence of nodes
+func (g *Game) drawPath(canvas *ebiten.Image, path []int, thickness float64, clr color.Color) {
+	for i := 0; i+1 < len(path); i++ {
+		g.drawThickEdge(canvas, path[i], path[i+1], thickness, clr)
+	}
+}
+
+// drawNodeRing draws a ring behind a node; call it before the nodes are drawn so the node covers the center
+func (g *Game) drawNodeRing(canvas *ebiten.Image, node int, clr color.Color) {
+	if node < 0 || node >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+	n := g.Sim.Graph.Nodes[node]
+	draw.DrawCachedCircle(canvas, n.X+int(g.CanvasOffsetX), n.Y+int(g.CanvasOffsetY), 25, clr)
+}
+
+// drawThickEdge draws a thick line between two nodes, ignoring invalid indices
+func (g *Game) drawThickEdge(canvas *ebiten.Image, a, b int, thickness float64, clr color.Color) {
+	if a < 0 || b < 0 || a >= len(g.Sim.Graph.Nodes) || b >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+	from := g.Sim.Graph.Nodes[a]
+	to := g.Sim.Graph.Nodes[b]
+	draw.DrawCachedThickLine(canvas,
+		float64(from.X)+g.CanvasOffsetX, float64(from.Y)+g.CanvasOffsetY,
+		float64(to.X)+g.CanvasOffsetX, float64(to.Y)+g.CanvasOffsetY,
+		thickness, clr)
+}
+
+// drawNodeText draws a small annotation next to a node
+func (g *Game) drawNodeText(canvas *ebiten.Image, node int, label string, dx, dy int, clr color.Color) {
+	if node < 0 || node >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+	n := g.Sim.Graph.Nodes[node]
+	text.Draw(canvas, label, basicfont.Face7x13, n.X+int(g.CanvasOffsetX)+dx, n.Y+int(g.CanvasOffsetY)+dy, clr)
+}
+
+// drawShortestPathTree draws every predecessor edge found by Dijkstra
+func (g *Game) drawShortestPathTree(canvas *ebiten.Image) {
+	treeColor := color.RGBA{130, 60, 180, 255} // Purple
+	for v, p := range g.Sim.Predecessors {
+		if p != -1 {
+			g.drawThickEdge(canvas, p, v, 3, treeColor)
+		}
+	}
+}
+
+// drawDistanceLabels draws the Dijkstra distance next to every reachable node
+func (g *Game) drawDistanceLabels(canvas *ebiten.Image) {
+	for v, dist := range g.Sim.ShortestPaths {
+		if !math.IsInf(dist, 1) {
+			g.drawNodeText(canvas, v, fmt.Sprintf("%.1f", dist), 22, -14, color.RGBA{200, 0, 0, 255})
+		}
+	}
+}
+
+// drawHeuristicLabels draws h(v) next to every node and the order in which A* expanded it
+func (g *Game) drawHeuristicLabels(canvas *ebiten.Image) {
+	for v, h := range g.Sim.HeuristicValues {
+		g.drawNodeText(canvas, v, fmt.Sprintf("h=%.1f", h), 22, -14, color.RGBA{0, 0, 160, 255})
+	}
+	for i, v := range g.Sim.Order {
+		g.drawNodeText(canvas, v, fmt.Sprintf("#%d", i+1), 22, 22, color.RGBA{200, 0, 0, 255})
+	}
+}
+
+// drawBidirectionalFrontiers draws both search trees and rings around the nodes each side will expand next
+func (g *Game) drawBidirectionalFrontiers(canvas *ebiten.Image) {
+	b := g.Sim.GetBidirectional()
+	if b == nil {
+		return
+	}
+
+	sideColors := [2]color.RGBA{forwardColor, backwardColor}
+	for side := 0; side < 2; side++ {
+		for v, p := range b.Parent[side] {
+			g.drawThickEdge(canvas, p, v, 3, sideColors[side])
+		}
+		for _, v := range b.Frontier[side] {
+			g.drawNodeRing(canvas, v, sideColors[side])
+		}
+		for _, v := range b.Next[side] {
+			g.drawNodeRing(canvas, v, sideColors[side])
+		}
+	}
+}
+
+// drawBidirectionalLabels draws each node's BFS distance from the source and from the target
+func (g *Game) drawBidirectionalLabels(canvas *ebiten.Image) {
+	b := g.Sim.GetBidirectional()
+	if b == nil {
+		return
+	}
+	for v, d := range b.Dist[0] {
+		g.drawNodeText(canvas, v, fmt.Sprintf("s%d", d), 22, -14, forwardColor)
+	}
+	for v, d := range b.Dist[1] {
+		g.drawNodeText(canvas, v, fmt.Sprintf("t%d", d), 22, 22, backwardColor)
+	}
+}
+
+// depthLimitedStepper returns the depth-limited search of the current mode, including the active IDDFS iteration
+func (g *Game) depthLimitedStepper() *algorithms.DepthLimitedStepper {
+	switch g.Sim.Mode {
+	case algorithms.ModeDepthLimited:
+		return g.Sim.GetDepthLimited()
+	case algorithms.ModeIDDFS:
+		if it := g.Sim.GetIDDFS(); it != nil {
+			return it.DepthLimitedStepper
+		}
+	}
+	return nil
+}
+
+// drawDepthLimitedTree draws the search tree of the current depth-limited search and rings its stack
+func (g *Game) drawDepthLimitedTree(canvas *ebiten.Image) {
+	d := g.depthLimitedStepper()
+	if d == nil {
+		return
+	}
+	for v, p := range d.Parent {
+		g.drawThickEdge(canvas, p, v, 3, color.RGBA{130, 60, 180, 255})
+	}
+	for _, frame := range d.Stack {
+		if frame.Depth == d.Depth[frame.Node] {
+			g.drawNodeRing(canvas, frame.Node, frontierColor)
+		}
+	}
+}
+
+// drawDepthLabels draws the depth at which the current iteration reached each node
+func (g *Game) drawDepthLabels(canvas *ebiten.Image) {
+	d := g.depthLimitedStepper()
+	if d == nil {
+		return
+	}
+	for v, depth := range d.Depth {
+		clr := color.RGBA{0, 0, 160, 255}
+		if depth == d.Limit {
+			clr = color.RGBA{200, 0, 0, 255} // Nodes on the limit are not expanded further
+		}
+		g.drawNodeText(canvas, v, fmt.Sprintf("d=%d", depth), 22, -14, clr)
+	}
+}
+
+// drawUCSFrontier draws the uniform-cost search tree and rings the nodes on the frontier
+func (g *Game) drawUCSFrontier(canvas *ebiten.Image) {
+	u := g.Sim.GetUCS()
+	if u == nil {
+		return
+	}
+	for v, p := range u.Parent {
+		g.drawThickEdge(canvas, p, v, 3, color.RGBA{130, 60, 180, 255})
+	}
+	for v := range u.Frontier() {
+		g.drawNodeRing(canvas, v, frontierColor)
+	}
+}
+
+// drawCostLabels draws the best known path cost of every reached node
+func (g *Game) drawCostLabels(canvas *ebiten.Image) {
+	u := g.Sim.GetUCS()
+	if u == nil {
+		return
+	}
+	for v, cost := range u.Dist {
+		g.drawNodeText(canvas, v, fmt.Sprintf("g=%.1f", cost), 22, -14, color.RGBA{200, 0, 0, 255})
+	}
+}
+
+// edgeClassColors gives every DFS edge class its own color; back, forward and cross edges are also dashed
+var edgeClassColors = map[algorithms.EdgeClass]color.RGBA{
+	algorithms.EdgeTree:    {130, 60, 180, 255}, // Purple, solid
+	algorithms.EdgeBack:    {220, 20, 60, 255},  // Crimson, long dashes
+	algorithms.EdgeForward: {34, 139, 34, 255},  // Green, medium dashes
+	algorithms.EdgeCross:   {30, 110, 230, 255}, // Blue, dots
+}
+
+// drawClassifiedEdges draws every edge the DFS has classified in the style of its class
+func (g *Game) drawClassifiedEdges(canvas *ebiten.Image) {
+	d := g.Sim.GetDFSTimes()
+	if d == nil {
+		return
+	}
+
+	// The last step classified an edge unless it finished a node or started a new tree
+	lastClassified := d.Action == algorithms.DFSExamine ||
+		(d.Action == algorithms.DFSDiscover && d.Parent[d.Current] != -1)
+
+	for i, edge := range d.Edges {
+		if edge.From >= len(g.Sim.Graph.Nodes) || edge.To >= len(g.Sim.Graph.Nodes) {
+			continue
+		}
+		thickness := 3.0
+		if lastClassified && i == len(d.Edges)-1 {
+			thickness = 5 // Emphasize the edge classified by the last step
+		}
+
+		from := g.Sim.Graph.Nodes[edge.From]
+		to := g.Sim.Graph.Nodes[edge.To]
+		x0, y0 := float64(from.X)+g.CanvasOffsetX, float64(from.Y)+g.CanvasOffsetY
+		x1, y1 := float64(to.X)+g.CanvasOffsetX, float64(to.Y)+g.CanvasOffsetY
+		clr := edgeClassColors[edge.Class]
+
+		switch edge.Class {
+		case algorithms.EdgeTree:
+			draw.DrawCachedThickLine(canvas, x0, y0, x1, y1, thickness, clr)
+		case algorithms.EdgeBack:
+			draw.DrawCachedDashedLine(canvas, x0, y0, x1, y1, thickness, 12, 6, clr)
+		case algorithms.EdgeForward:
+			draw.DrawCachedDashedLine(canvas, x0, y0, x1, y1, thickness, 6, 6, clr)
+		case algorithms.EdgeCross:
+			draw.DrawCachedDashedLine(canvas, x0, y0, x1, y1, thickness, 2, 5, clr)
+		}
+	}
+}
+
+// drawTimestampLabels draws "discovery/finish" next to every discovered node
+func (g *Game) drawTimestampLabels(canvas *ebiten.Image) {
+	d := g.Sim.GetDFSTimes()
+	if d == nil {
+		return
+	}
+	for v := range d.Discovery {
+		if d.Discovery[v] == 0 {
+			continue
+		}
+		label := fmt.Sprintf("%d/", d.Discovery[v])
+		if d.Finish[v] > 0 {
+			label += fmt.Sprintf("%d", d.Finish[v])
+		}
+		g.drawNodeText(canvas, v, label, 22, -14, color.RGBA{200, 0, 0, 255})
+	}
+}
+
+// drawLowlinkLabels labels every visited node with its Tarjan index and lowlink
+func (g *Game) drawLowlinkLabels(canvas *ebiten.Image) {
+	t := g.Sim.GetTarjan()
+	if t == nil {
+		return
+	}
+	for v := range t.Index {
+		if t.Index[v] >= 0 {
+			g.drawNodeText(canvas, v, fmt.Sprintf("%d/%d", t.Index[v], t.Lowlink[v]), 22, -14, color.RGBA{200, 0, 0, 255})
+		}
+	}
+}
+
+// drawTarjanEdges draws the active recursion path and the edge examined by the last step
+func (g *Game) drawTarjanEdges(canvas *ebiten.Image) {
+	t := g.Sim.GetTarjan()
+	if t == nil {
+		return
+	}
+	for i := 1; i < len(t.CallStack); i++ {
+		g.drawThickEdge(canvas, t.CallStack[i-1], t.CallStack[i], 3, color.RGBA{130, 60, 180, 255})
+	}
+	if t.Action == algorithms.TarjanEdge {
+		g.drawThickEdge(canvas, t.LastEdge[0], t.LastEdge[1], 5, color.RGBA{255, 140, 0, 255})
+	}
+}
+
+// drawTopologicalEdges draws the edges whose in-degree update the last Kahn step made, rings the
+// Kahn queue and draws a witness cycle in red when the sort failed
+func (g *Game) drawTopologicalEdges(canvas *ebiten.Image) {
+	if k := g.Sim.GetKahn(); k != nil {
+		for _, next := range k.Decremented {
+			g.drawThickEdge(canvas, k.Current, next, 5, color.RGBA{255, 140, 0, 255})
+		}
+		for _, v := range k.Queue {
+			g.drawNodeRing(canvas, v, frontierColor)
+		}
+	}
+
+	cycleColor := color.RGBA{220, 20, 60, 255}
+	g.drawPath(canvas, g.Sim.Cycle, 5, cycleColor)
+	for i := 0; i+1 < len(g.Sim.Cycle); i++ {
+		g.drawNodeRing(canvas, g.Sim.Cycle[i], cycleColor)
+	}
+}
+
+// drawTopologicalLabels labels nodes with their position in the order, and nodes Kahn has not
+// output yet with their remaining in-degree
+func (g *Game) drawTopologicalLabels(canvas *ebiten.Image) {
+	order := g.Sim.TopOrder
+	k := g.Sim.GetKahn()
+	if k != nil {
+		order = k.Order
+		for v, degree := range k.InDegree {
+			if !k.Output[v] {
+				g.drawNodeText(canvas, v, fmt.Sprintf("in:%d", degree), 22, -14, color.RGBA{200, 0, 0, 255})
+			}
+		}
+	}
+	for i, v := range order {
+		g.drawNodeText(canvas, v, fmt.Sprintf("#%d", i+1), 22, -14, color.RGBA{0, 0, 200, 255})
+	}
+}
+
+// drawConnectivityEdges draws bridges in red, or every biconnected component's edges in its own color
+func (g *Game) drawConnectivityEdges(canvas *ebiten.Image) {
+	c := g.Sim.Connectivity
+	if c == nil {
+		return
+	}
+	if g.Sim.Mode == algorithms.ModeBiconnected {
+		for i, block := range c.Blocks {
+			for _, edge := range block {
+				g.drawThickEdge(canvas, edge[0], edge[1], 4, paletteColor(i))
+			}
+		}
+		return
+	}
+	for _, bridge := range c.Bridges {
+		g.drawThickEdge(canvas, bridge[0], bridge[1], 5, color.RGBA{220, 20, 60, 255})
+	}
+}
+
+// drawSquareFrame draws a square outline around a node, marking it with a different shape
+func (g *Game) drawSquareFrame(canvas *ebiten.Image, node int, clr color.Color) {
+	if node < 0 || node >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+	n := g.Sim.Graph.Nodes[node]
+	const half = 25.0
+	x := float64(n.X) + g.CanvasOffsetX
+	y := float64(n.Y) + g.CanvasOffsetY
+	corners := [][2]float64{{x - half, y - half}, {x + half, y - half}, {x + half, y + half}, {x - half, y + half}}
+	for i, a := range corners {
+		b := corners[(i+1)%len(corners)]
+		draw.DrawCachedThickLine(canvas, a[0], a[1], b[0], b[1], 3, clr)
+	}
+}
+
+// drawBFSTree draws the BFS tree edges, rings the queued nodes and draws the path to the target
+func (g *Game) drawBFSTree(canvas *ebiten.Image) {
+	for v, p := range g.Sim.Parents {
+		g.drawThickEdge(canvas, p, v, 3, color.RGBA{130, 60, 180, 255})
+	}
+	for _, v := range g.Sim.Queue {
+		if !g.Sim.Visited[v] {
+			g.drawNodeRing(canvas, v, frontierColor)
+		}
+	}
+	g.drawPath(canvas, g.Sim.BFSPathTo(g.TargetNode), 5, color.RGBA{255, 140, 0, 255})
+}
+
+// drawLevelLabels draws the BFS level of every discovered node
+func (g *Game) drawLevelLabels(canvas *ebiten.Image) {
+	for v, level := range g.Sim.Levels {
+		g.drawNodeText(canvas, v, fmt.Sprintf("L%d", level), 22, -14, color.RGBA{200, 0, 0, 255})
+	}
+}
+
+// componentColor colors a node by its union-find component; singleton nodes keep their normal color
+func componentColor(sets *algorithms.DisjointSet, node int) (color.RGBA, bool) {
+	if node >= sets.Size() {
+		return color.RGBA{}, false
+	}
+	root := sets.Find(node)
+	for i, component := range sets.Components() {
+		if sets.Find(component[0]) == root {
+			return paletteColor(i), true
+		}
+	}
+	return color.RGBA{}, false
+}
+
+// drawKruskalEdges draws accepted edges solid, rejected edges dashed and the edge under consideration in orange
+func (g *Game) drawKruskalEdges(canvas *ebiten.Image) {
+	k := g.Sim.GetKruskal()
+	if k == nil {
+		return
+	}
+
+	for i, edge := range k.Sorted {
+		switch k.Decisions[i] {
+		case algorithms.EdgeAccepted:
+			g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{34, 139, 34, 255})
+		case algorithms.EdgeRejected:
+			g.drawDashedEdge(canvas, edge.From, edge.To, 3, color.RGBA{220, 20, 60, 255})
+		}
+	}
+
+	// The edge the next step will consider
+	if !k.Done() {
+		edge := k.Sorted[k.Index]
+		g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{255, 140, 0, 255})
+	}
+}
+
+// drawPrimCut draws the tree edges, the edges crossing the cut and the lightest crossing edge
+func (g *Game) drawPrimCut(canvas *ebiten.Image) {
+	p := g.Sim.GetPrim()
+	if p == nil {
+		return
+	}
+
+	for _, edge := range p.CutEdges() {
+		g.drawDashedEdge(canvas, edge.From, edge.To, 3, color.RGBA{255, 190, 80, 255})
+	}
+	for _, edge := range p.MST {
+		g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{34, 139, 34, 255})
+	}
+	if edge, ok := p.Peek(); ok {
+		g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{255, 140, 0, 255})
+	}
+}
+
+// drawBoruvkaEdges draws the forest so far, with the edges added by the last phase in orange
+func (g *Game) drawBoruvkaEdges(canvas *ebiten.Image) {
+	b := g.Sim.GetBoruvka()
+	if b == nil {
+		return
+	}
+	for _, edge := range b.MST {
+		g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{34, 139, 34, 255})
+	}
+	for _, i := range b.Chosen {
+		edge := b.Edges[i]
+		g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{255, 140, 0, 255})
+	}
+}
+
+// drawReverseDeleteEdges draws kept edges solid, deleted edges dashed and the next edge in orange
+func (g *Game) drawReverseDeleteEdges(canvas *ebiten.Image) {
+	r := g.Sim.GetReverseDelete()
+	if r == nil {
+		return
+	}
+	for i, edge := range r.Sorted {
+		switch r.Decisions[i] {
+		case algorithms.EdgeAccepted:
+			g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{34, 139, 34, 255})
+		case algorithms.EdgeRejected:
+			g.drawDashedEdge(canvas, edge.From, edge.To, 3, color.RGBA{220, 20, 60, 255})
+		}
+	}
+	if !r.Done() {
+		edge := r.Sorted[r.Index]
+		g.drawThickEdge(canvas, edge.From, edge.To, 5, color.RGBA{255, 140, 0, 255})
+	}
+}
+
+// undirectedKey identifies an undirected edge regardless of its direction
+func undirectedKey(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// drawMSTDifferences draws edges every MST algorithm chose in green and edges only some chose
+// dashed in orange, labelled with the initials of the algorithms that chose them
+func (g *Game) drawMSTDifferences(canvas *ebiten.Image) {
+	chosenBy := make(map[[2]int]string)
+	order := [][2]int{}
+	for _, stats := range g.Sim.MSTComparison {
+		for _, edge := range stats.Edges {
+			key := undirectedKey(edge.From, edge.To)
+			if _, seen := chosenBy[key]; !seen {
+				order = append(order, key)
+			}
+			chosenBy[key] += stats.Algorithm[:1]
+		}
+	}
+
+	for _, key := range order {
+		if len(chosenBy[key]) == len(g.Sim.MSTComparison) {
+			g.drawThickEdge(canvas, key[0], key[1], 5, color.RGBA{34, 139, 34, 255})
+			continue
+		}
+		g.drawDashedEdge(canvas, key[0], key[1], 5, color.RGBA{255, 140, 0, 255})
+		a, b := g.Sim.Graph.Nodes[key[0]], g.Sim.Graph.Nodes[key[1]]
+		x := (a.X+b.X)/2 + int(g.CanvasOffsetX) - 10
+		y := (a.Y+b.Y)/2 + int(g.CanvasOffsetY) + 14
+		text.Draw(canvas, chosenBy[key], basicfont.Face7x13, x, y, color.RGBA{200, 90, 0, 255})
+	}
+}
+
+// drawDashedEdge draws a dashed line between two nodes, ignoring invalid indices
+func (g *Game) drawDashedEdge(canvas *ebiten.Image, a, b int, thickness float64, clr color.Color) {
+	if a < 0 || b < 0 || a >= len(g.Sim.Graph.Nodes) || b >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+	from := g.Sim.Graph.Nodes[a]
+	to := g.Sim.Graph.Nodes[b]
+	draw.DrawCachedDashedLine(canvas,
+		float64(from.X)+g.CanvasOffsetX, float64(from.Y)+g.CanvasOffsetY,
+		float64(to.X)+g.CanvasOffsetX, float64(to.Y)+g.CanvasOffsetY,
+		thickness, 8, 6, clr)
+}
+
+// formatPath renders a node sequence as letters joined by arrows
+func formatPath(path []int) string {
+	str := ""
+	for i, v := range path {
+		if i > 0 {
+			str += " > "
+		}
+		str += string(rune('A' + v))
+	}
+	return str
+}
@@ -0,0 +1,38 @@
+package ui
+
+import "image/color"
+
+// palette holds clearly distinguishable node colors for levels, components and colorings
+var palette = []color.RGBA{
+	{50, 205, 50, 255},   // Lime green
+	{255, 165, 0, 255},   // Orange
+	{138, 43, 226, 255},  // Blue violet
+	{0, 191, 255, 255},   // Deep sky blue
+	{220, 20, 60, 255},   // Crimson
+	{255, 215, 0, 255},   // Gold
+	{0, 128, 128, 255},   // Teal
+	{255, 105, 180, 255}, // Hot pink
+	{139, 69, 19, 255},   // Saddle brown
+	{112, 128, 144, 255}, // Slate gray
+}
+
+// paletteColor returns the i-th palette color, cycling when there are more indices than colors
+func paletteColor(i int) color.RGBA {
+	if i < 0 {
+		i = -i
+	}
+	return palette[i%len(palette)]
+}
+
+// heatColor blends from the default node blue at t = 0 to crimson at t = 1
+func heatColor(t float64) color.RGBA {
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+	return color.RGBA{blend(70, 220), blend(130, 20), blend(180, 60), 255}
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// pathListModes lists the source-to-target path enumerations offered in the Paths menu
+var pathListModes = []algorithms.TraversalMode{
+	algorithms.ModeKShortestPaths,
+	algorithms.ModeAllSimplePaths,
+}
+
+// isPathListMode reports whether the current mode lists paths from the start to the target node
+func (g *Game) isPathListMode() bool {
+	for _, mode := range pathListModes {
+		if g.Sim.Mode == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// startPathList lists the paths from source to the target node and opens the list at its first page
+func (g *Game) startPathList(mode algorithms.TraversalMode, source int) {
+	if source < 0 || source >= len(g.Sim.Graph.Nodes) {
+		g.showMessage("Please select a start node first")
+		return
+	}
+	target := g.TargetNode
+	if target < 0 || target >= len(g.Sim.Graph.Nodes) {
+		g.showMessage(fmt.Sprintf("%s needs a target: right-click a node > Set as Target Node", mode))
+		return
+	}
+
+	if mode == algorithms.ModeKShortestPaths {
+		g.Sim.StartKShortestPaths(source, target)
+	} else {
+		g.Sim.StartAllSimplePaths(source, target)
+	}
+	g.ListPanel.Page = 0
+	g.canvasNeedsRedraw = true
+
+	route := fmt.Sprintf("%s to %s", nodeName(source), nodeName(target))
+	switch {
+	case len(g.Sim.Paths) == 0:
+		g.showMessage("No path from " + route)
+	case g.Sim.PathsCapped:
+		g.showMessage(fmt.Sprintf("Showing the first %d paths from %s. Click one to highlight it.", len(g.Sim.Paths), route))
+	default:
+		g.showMessage(fmt.Sprintf("%d paths from %s. Click one to highlight it.", len(g.Sim.Paths), route))
+	}
+}
+
+// drawPathCost labels the target node with the cost of the highlighted path
+func (g *Game) drawPathCost(canvas *ebiten.Image) {
+	if g.Sim.Path == nil {
+		return
+	}
+	g.drawNodeText(canvas, g.Sim.Target, fmt.Sprintf("cost %.1f", g.Sim.PathCost), 22, -14, color.RGBA{0, 0, 200, 255})
+}
+
+// pathListStatus describes the listed paths and the highlighted one in up to three lines
+func (g *Game) pathListStatus() (status, detail, extra string) {
+	route := fmt.Sprintf("%s to %s", nodeName(g.Sim.Source), nodeName(g.Sim.Target))
+	if g.Sim.Mode == algorithms.ModeKShortestPaths {
+		status = fmt.Sprintf("Yen: %d of %d cheapest loopless paths from %s", len(g.Sim.Paths), g.Sim.PathCount, route)
+	} else {
+		count := fmt.Sprintf("%d", len(g.Sim.Paths))
+		if g.Sim.PathsCapped {
+			count = "more than " + count
+		}
+		status = fmt.Sprintf("All simple paths from %s: %s", route, count)
+	}
+
+	if g.Sim.Path == nil {
+		detail = fmt.Sprintf("%s cannot be reached from %s", nodeName(g.Sim.Target), nodeName(g.Sim.Source))
+		return
+	}
+	detail = fmt.Sprintf("Path %d: %s, cost %.1f over %d edges", g.Sim.SelectedPath+1, formatPath(g.Sim.Path), g.Sim.PathCost, len(g.Sim.Path)-1)
+
+	switch {
+	case g.Sim.Mode == algorithms.ModeKShortestPaths && len(g.Sim.Paths) < g.Sim.PathCount:
+		extra = "No further loopless paths exist"
+	case g.Sim.Mode == algorithms.ModeKShortestPaths:
+		extra = "Each path leaves an earlier one at a spur node and takes the cheapest unused way on. Set K... finds more"
+	case g.Sim.PathsCapped:
+		extra = fmt.Sprintf("Stopped at the limit of %d; raise it with Set Path Limit...", g.Sim.PathLimit)
+	default:
+		extra = "Listed in depth-first order; click a path to highlight it"
+	}
+	return
+}
+
+// drawPathList shows the listed paths and their costs one page at a time
+func (g *Game) drawPathList(screen *ebiten.Image) {
+	items := make([]string, len(g.Sim.Paths))
+	for i, path := range g.Sim.Paths {
+		items[i] = fmt.Sprintf("%3d. %s (%.1f)", i+1, formatPath(path.Nodes), path.Cost)
+	}
+	title := fmt.Sprintf("Paths %s to %s (%d)", nodeName(g.Sim.Source), nodeName(g.Sim.Target), len(items))
+	if g.Sim.PathsCapped {
+		title = fmt.Sprintf("Paths %s to %s (first %d)", nodeName(g.Sim.Source), nodeName(g.Sim.Target), len(items))
+	}
+	g.ListPanel.Draw(screen, title, items, g.Sim.SelectedPath)
+}
+
+// openPathListInput opens the value modal for Yen's k ("path count") or the simple path limit
+func (g *Game) openPathListInput(action string) {
+	g.AVLAction = action
+	if action == "path count" {
+		g.AVLInputText = strconv.Itoa(g.Sim.PathCount)
+	} else {
+		g.AVLInputText = strconv.Itoa(g.Sim.PathLimit)
+	}
+	g.ShowAVLInput = true
+}
+
+// submitPathListInput applies the number typed into the modal
+func (g *Game) submitPathListInput() {
+	value, err := strconv.Atoi(g.AVLInputText)
+	if err != nil || value < 1 {
+		g.showMessage("Invalid " + g.AVLAction)
+		g.AVLInputText = ""
+		return
+	}
+
+	if g.AVLAction == "path count" {
+		g.Sim.PathCount = value
+		g.showMessage(fmt.Sprintf("Yen's algorithm finds %d paths", value))
+	} else {
+		g.Sim.PathLimit = value
+		g.showMessage(fmt.Sprintf("Simple path limit = %d", value))
+	}
+	g.ShowAVLInput = false
+
+	// List the paths again with the new setting
+	if g.isPathListMode() {
+		g.startPathList(g.Sim.Mode, g.Sim.Source)
+	}
+}
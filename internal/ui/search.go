@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"bfsdfs/internal/algorithms"
+)
+
+// searchModes lists the stepped uninformed searches offered in the Search menu
+var searchModes = []algorithms.TraversalMode{
+	algorithms.ModeBidirectional,
+	algorithms.ModeIDDFS,
+	algorithms.ModeDepthLimited,
+	algorithms.ModeUCS,
+}
+
+// usesTargetNode reports whether the current mode searches towards g.TargetNode or uses it as the sink
+func (g *Game) usesTargetNode() bool {
+	switch g.Sim.Mode {
+	case algorithms.ModeDijkstra, algorithms.ModeAStar, algorithms.ModeBFS:
+		return true
+	}
+	for _, mode := range searchModes {
+		if g.Sim.Mode == mode {
+			return true
+		}
+	}
+	// The target is the sink, or the end of the listed paths
+	return g.isFlowMode() || g.isPathListMode()
+}
+
+// startSearch starts one of the stepped searches from source towards the target node
+func (g *Game) startSearch(mode algorithms.TraversalMode, source int) {
+	if source < 0 || source >= len(g.Sim.Graph.Nodes) {
+		g.showMessage("Please select a start node first")
+		return
+	}
+
+	target := g.TargetNode
+	switch mode {
+	case algorithms.ModeBidirectional:
+		if target < 0 {
+			g.showMessage("Bidirectional BFS needs a target: right-click a node > Set as Target Node")
+			return
+		}
+		g.Sim.StartBidirectional(source, target)
+	case algorithms.ModeIDDFS:
+		g.Sim.StartIDDFS(source, target)
+	case algorithms.ModeDepthLimited:
+		g.Sim.StartDepthLimited(source, target)
+	case algorithms.ModeUCS:
+		g.Sim.StartUCS(source, target)
+	default:
+		return
+	}
+
+	msg := fmt.Sprintf("%s from %s", mode, string(rune('A'+source)))
+	if target >= 0 {
+		msg += " to " + string(rune('A'+target))
+	}
+	g.showMessage(msg + ". Press Step to expand the next node.")
+}
+
+// openDepthLimitInput opens the value modal for the depth-limited DFS limit
+func (g *Game) openDepthLimitInput() {
+	g.AVLAction = "depth limit"
+	g.AVLInputText = strconv.Itoa(g.Sim.DepthLimit)
+	g.ShowAVLInput = true
+}
+
+// submitDepthLimitInput applies the depth limit typed into the modal
+func (g *Game) submitDepthLimitInput() {
+	limit, err := strconv.Atoi(g.AVLInputText)
+	if err != nil || limit < 0 {
+		g.showMessage("Invalid depth limit")
+		g.AVLInputText = ""
+		return
+	}
+
+	g.Sim.DepthLimit = limit
+	g.ShowAVLInput = false
+	g.showMessage(fmt.Sprintf("Depth limit = %d", limit))
+
+	// Restart a running depth-limited search with the new limit
+	if g.Sim.Mode == algorithms.ModeDepthLimited {
+		g.startSearch(algorithms.ModeDepthLimited, g.Sim.Source)
+	}
+}
+
+// searchStatus describes the progress of the current stepped search in up to three lines
+func (g *Game) searchStatus() (status, detail, extra string) {
+	source := string(rune('A' + g.Sim.Source))
+	target := "every node"
+	if g.Sim.Target >= 0 {
+		target = string(rune('A' + g.Sim.Target))
+	}
+	result := func() string {
+		if g.Sim.Path == nil {
+			return target + " was not reached"
+		}
+		return "Path: " + formatPath(g.Sim.Path)
+	}
+
+	switch g.Sim.Mode {
+	case algorithms.ModeBidirectional:
+		b := g.Sim.GetBidirectional()
+		if b == nil {
+			return
+		}
+		status = fmt.Sprintf("Bidirectional BFS %s <-> %s: source side in teal, target side in orchid", source, target)
+		if b.Done() {
+			detail = result()
+			if b.Meeting != -1 {
+				detail += fmt.Sprintf("  (frontiers met at %s)", string(rune('A'+b.Meeting)))
+			}
+			return
+		}
+		side := "source"
+		if b.Side == 1 {
+			side = "target"
+		}
+		detail = fmt.Sprintf("Expanding the %s side. Source frontier: %s  Target frontier: %s",
+			side, formatNodeList(b.Frontier[0]), formatNodeList(b.Frontier[1]))
+
+	case algorithms.ModeDepthLimited:
+		d := g.Sim.GetDepthLimited()
+		if d == nil {
+			return
+		}
+		status = fmt.Sprintf("Depth-limited DFS from %s to %s (limit %d)", source, target, d.Limit)
+		detail, extra = depthLimitedStatus(d)
+		if d.Done() {
+			detail = result()
+			if d.Cutoff && !d.Found {
+				detail += "; the limit cut the search short"
+			}
+		}
+
+	case algorithms.ModeIDDFS:
+		it := g.Sim.GetIDDFS()
+		if it == nil {
+			return
+		}
+		status = fmt.Sprintf("Iterative deepening DFS from %s to %s, iteration with depth limit %d", source, target, it.Limit)
+		detail, _ = depthLimitedStatus(it.DepthLimitedStepper)
+		if it.Done() {
+			detail = result()
+		}
+		iterations := []string{}
+		for limit, order := range it.Iterations {
+			iterations = append(iterations, fmt.Sprintf("%d: %s", limit, formatNodeList(order)))
+		}
+		if len(iterations) > 0 {
+			extra = "Finished iterations  " + strings.Join(iterations, " | ")
+		}
+
+	case algorithms.ModeUCS:
+		u := g.Sim.GetUCS()
+		if u == nil {
+			return
+		}
+		status = fmt.Sprintf("Uniform-cost search from %s to %s", source, target)
+		if u.Done() {
+			detail = result()
+			if g.Sim.Path != nil {
+				detail += fmt.Sprintf("  (total cost %.1f)", g.Sim.PathCost)
+			}
+			return
+		}
+
+		// List the frontier in the order it will be expanded
+		frontier := u.Frontier()
+		nodes := make([]int, 0, len(frontier))
+		for v := range frontier {
+			nodes = append(nodes, v)
+		}
+		sort.Slice(nodes, func(i, j int) bool {
+			if frontier[nodes[i]] != frontier[nodes[j]] {
+				return frontier[nodes[i]] < frontier[nodes[j]]
+			}
+			return nodes[i] < nodes[j]
+		})
+		entries := []string{}
+		for _, v := range nodes {
+			entries = append(entries, fmt.Sprintf("%s(%.1f)", string(rune('A'+v)), frontier[v]))
+		}
+		detail = "Frontier: " + strings.Join(entries, " ")
+	}
+	return
+}
+
+// depthLimitedStatus describes the stack of a depth-limited search and its expansion order
+func depthLimitedStatus(d *algorithms.DepthLimitedStepper) (stack, order string) {
+	frames := []string{}
+	for _, frame := range d.Stack {
+		if frame.Depth == d.Depth[frame.Node] {
+			frames = append(frames, fmt.Sprintf("%s(%d)", string(rune('A'+frame.Node)), frame.Depth))
+		}
+	}
+	return "Stack (node(depth), top last): " + strings.Join(frames, " "), "Expanded: " + formatNodeList(d.Order)
+}
+
+// formatNodeList renders nodes as letters separated by spaces
+func formatNodeList(nodes []int) string {
+	if len(nodes) == 0 {
+		return "-"
+	}
+	labels := make([]string, len(nodes))
+	for i, v := range nodes {
+		labels[i] = string(rune('A' + v))
+	}
+	return strings.Join(labels, " ")
+}
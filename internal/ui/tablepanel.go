@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// drawTablePanel renders a read-only table anchored to the top-right corner of the screen
+// highlight is the row index to tint, or -1 for none
+func drawTablePanel(screen *ebiten.Image, title string, header []string, rows [][]string, highlight int) {
+	const (
+		charWidth   = 7
+		rowHeight   = 16
+		titleHeight = 22
+		padding     = 5
+	)
+
+	// Size each column to its widest cell
+	widths := make([]int, len(header))
+	for c, cell := range header {
+		widths[c] = len(cell)
+	}
+	for _, row := range rows {
+		for c, cell := range row {
+			if c < len(widths) && len(cell) > widths[c] {
+				widths[c] = len(cell)
+			}
+		}
+	}
+
+	width := padding * 2
+	for _, w := range widths {
+		width += (w + 2) * charWidth
+	}
+	if titleWidth := len(title)*charWidth + padding*2; titleWidth > width {
+		width = titleWidth
+	}
+	height := titleHeight + (len(rows)+1)*rowHeight + padding
+	x := screen.Bounds().Dx() - width - 20
+	y := 10
+
+	textColor := color.RGBA{220, 220, 220, 255}
+	draw.DrawCachedRect(screen, float64(x), float64(y), float64(width), float64(height), color.RGBA{40, 40, 40, 230})
+	text.Draw(screen, title, basicfont.Face7x13, x+padding, y+15, textColor)
+
+	drawRow := func(row []string, top int, clr color.Color) {
+		cx := x + padding
+		for c, cell := range row {
+			if c >= len(widths) {
+				break
+			}
+			text.Draw(screen, cell, basicfont.Face7x13, cx, top+12, clr)
+			cx += (widths[c] + 2) * charWidth
+		}
+	}
+
+	top := y + titleHeight
+	drawRow(header, top, color.RGBA{180, 180, 255, 255})
+	for r, row := range rows {
+		top += rowHeight
+		if r == highlight {
+			draw.DrawCachedRect(screen, float64(x+padding-2), float64(top), float64(width-padding*2+4), float64(rowHeight-1), color.RGBA{70, 90, 160, 255})
+		}
+		drawRow(row, top, textColor)
+	}
+}
+
+// drawHeuristicComparison shows how each A* heuristic fares on the current start and goal
+func (g *Game) drawHeuristicComparison(screen *ebiten.Image) {
+	if len(g.Sim.HeuristicComparison) == 0 {
+		return
+	}
+
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	header := []string{"Heuristic", "Expanded", "Cost", "Admissible", "Consistent"}
+	rows := make([][]string, 0, len(g.Sim.HeuristicComparison))
+	highlight := -1
+	for i, stats := range g.Sim.HeuristicComparison {
+		cost := "-"
+		if !math.IsInf(stats.Cost, 1) {
+			cost = fmt.Sprintf("%.1f", stats.Cost)
+		}
+		if stats.Kind == g.Sim.Heuristic {
+			highlight = i
+		}
+		rows = append(rows, []string{
+			stats.Kind.String(),
+			fmt.Sprintf("%d", stats.Expanded),
+			cost,
+			yesNo(stats.Admissible),
+			yesNo(stats.Consistent),
+		})
+	}
+
+	drawTablePanel(screen, "A* heuristic comparison", header, rows, highlight)
+}
+
+// drawKruskalPanel lists the sorted edges with Kruskal's decision for each, highlighting the next edge
+func (g *Game) drawKruskalPanel(screen *ebiten.Image, k *algorithms.KruskalStepper) {
+	header := []string{"#", "Edge", "Weight", "Decision"}
+	rows := make([][]string, 0, len(k.Sorted))
+	for i, edge := range k.Sorted {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			string(rune('A'+edge.From)) + "-" + string(rune('A'+edge.To)),
+			fmt.Sprintf("%.1f", edge.Weight),
+			k.Decisions[i].String(),
+		})
+	}
+
+	highlight := -1
+	if !k.Done() {
+		highlight = k.Index
+	}
+	drawTablePanel(screen, "Kruskal: edges sorted by weight", header, rows, highlight)
+}
+
+// reverseDeleteDecision names a reverse-delete decision, where accepted edges are kept
+func reverseDeleteDecision(d algorithms.EdgeDecision) string {
+	switch d {
+	case algorithms.EdgeAccepted:
+		return "kept (bridge)"
+	case algorithms.EdgeRejected:
+		return "deleted (on a cycle)"
+	}
+	return d.String()
+}
+
+// drawReverseDeletePanel lists the edges by decreasing weight with the decision for each, highlighting the next edge
+func (g *Game) drawReverseDeletePanel(screen *ebiten.Image, r *algorithms.ReverseDeleteStepper) {
+	header := []string{"#", "Edge", "Weight", "Decision"}
+	rows := make([][]string, 0, len(r.Sorted))
+	for i, edge := range r.Sorted {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			string(rune('A'+edge.From)) + "-" + string(rune('A'+edge.To)),
+			fmt.Sprintf("%.1f", edge.Weight),
+			reverseDeleteDecision(r.Decisions[i]),
+		})
+	}
+
+	highlight := -1
+	if !r.Done() {
+		highlight = r.Index
+	}
+	drawTablePanel(screen, "Reverse-delete: edges by decreasing weight", header, rows, highlight)
+}
+
+// drawMSTComparison tabulates the result of every MST algorithm, highlighting the first one
+// whose edge set differs from Kruskal's
+func (g *Game) drawMSTComparison(screen *ebiten.Image) {
+	header := []string{"Algorithm", "Weight", "Edges", "Operations", "Edge set"}
+	rows := make([][]string, 0, len(g.Sim.MSTComparison))
+	highlight := -1
+	reference := edgeSetString(g.Sim.MSTComparison[0].Edges)
+	for i, stats := range g.Sim.MSTComparison {
+		set := edgeSetString(stats.Edges)
+		if set != reference && highlight == -1 {
+			highlight = i
+		}
+		rows = append(rows, []string{
+			stats.Algorithm,
+			fmt.Sprintf("%.1f", stats.Weight),
+			fmt.Sprintf("%d", len(stats.Edges)),
+			fmt.Sprintf("%d %s", stats.Operations, stats.Counted),
+			set,
+		})
+	}
+	drawTablePanel(screen, "Minimum spanning tree algorithms", header, rows, highlight)
+}
+
+// edgeSetString renders a set of undirected edges in a canonical sorted order
+func edgeSetString(edges []algorithms.Edge) string {
+	labels := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		key := undirectedKey(edge.From, edge.To)
+		labels = append(labels, string(rune('A'+key[0]))+string(rune('A'+key[1])))
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, " ")
+}
+
+// drawTarjanStack lists the Tarjan component stack from the top down, highlighting the node of the last step
+func drawTarjanStack(screen *ebiten.Image, t *algorithms.TarjanStepper) {
+	header := []string{"Node", "Index", "Lowlink", "In call"}
+	rows := [][]string{}
+	highlight := -1
+	inCall := make(map[int]bool, len(t.CallStack))
+	for _, v := range t.CallStack {
+		inCall[v] = true
+	}
+	for i := len(t.Stack) - 1; i >= 0; i-- {
+		v := t.Stack[i]
+		if v == t.Current {
+			highlight = len(rows)
+		}
+		call := ""
+		if inCall[v] {
+			call = "yes"
+		}
+		rows = append(rows, []string{
+			string(rune('A' + v)),
+			fmt.Sprintf("%d", t.Index[v]),
+			fmt.Sprintf("%d", t.Lowlink[v]),
+			call,
+		})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{"(empty)"})
+	}
+	drawTablePanel(screen, "Tarjan stack (top first)", header, rows, highlight)
+}
+
+// drawInDegreePanel lists every node's remaining in-degree during Kahn's algorithm, highlighting the node just output
+func drawInDegreePanel(screen *ebiten.Image, k *algorithms.KahnStepper) {
+	position := make(map[int]int, len(k.Order))
+	for i, v := range k.Order {
+		position[v] = i + 1
+	}
+	queued := make(map[int]bool, len(k.Queue))
+	for _, v := range k.Queue {
+		queued[v] = true
+	}
+
+	header := []string{"Node", "In-degree", "State"}
+	rows := make([][]string, 0, len(k.InDegree))
+	for v, degree := range k.InDegree {
+		state := "waiting"
+		switch {
+		case k.Output[v]:
+			state = fmt.Sprintf("output #%d", position[v])
+		case queued[v]:
+			state = "queued"
+		}
+		in := fmt.Sprintf("%d", degree)
+		if k.Output[v] {
+			in = "-"
+		}
+		rows = append(rows, []string{string(rune('A' + v)), in, state})
+	}
+	drawTablePanel(screen, "Kahn: remaining in-degrees", header, rows, k.Current)
+}
+
+// edgeWeightSum returns the total weight of a set of edges
+func edgeWeightSum(edges []algorithms.Edge) float64 {
+	total := 0.0
+	for _, edge := range edges {
+		total += edge.Weight
+	}
+	return total
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"bfsdfs/internal/algorithms"
+	"bfsdfs/pkg/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// drawDFSTimeline draws the parenthesis structure of a DFS as nested discovery/finish intervals,
+// anchored to the top-right corner of the screen
+// Each node is a bar from its discovery to its finish time, one row per depth in the DFS forest
+func drawDFSTimeline(screen *ebiten.Image, d *algorithms.DFSTimesStepper) {
+	const (
+		cellWidth   = 14
+		rowHeight   = 16
+		titleHeight = 22
+		axisHeight  = 16
+		padding     = 5
+		charWidth   = 7
+	)
+
+	n := len(d.Discovery)
+	if n == 0 {
+		return
+	}
+
+	// Depth of every discovered node in the DFS forest
+	depth := make([]int, n)
+	maxDepth := 0
+	for v := 0; v < n; v++ {
+		if d.Discovery[v] == 0 {
+			continue
+		}
+		for p := d.Parent[v]; p != -1; p = d.Parent[p] {
+			depth[v]++
+		}
+		if depth[v] > maxDepth {
+			maxDepth = depth[v]
+		}
+	}
+
+	slots := 2 * n // Every node is discovered and finished once
+	width := slots*cellWidth + padding*2
+	charsPerLine := (width - padding*2) / charWidth
+	lines := wrapTokens(strings.Fields(d.ParenthesisString()), charsPerLine)
+	height := titleHeight + axisHeight + (maxDepth+1)*rowHeight + padding + len(lines)*rowHeight + padding
+
+	x := screen.Bounds().Dx() - width - 20
+	y := 10
+	textColor := color.RGBA{220, 220, 220, 255}
+	draw.DrawCachedRect(screen, float64(x), float64(y), float64(width), float64(height), color.RGBA{40, 40, 40, 230})
+	text.Draw(screen, fmt.Sprintf("Parenthesis structure (time %d of %d)", d.Time, slots), basicfont.Face7x13, x+padding, y+15, textColor)
+
+	// Time axis, labelling every other slot to keep the numbers readable
+	barsX := x + padding
+	axisY := y + titleHeight
+	for t := 1; t <= slots; t += 2 {
+		text.Draw(screen, fmt.Sprintf("%d", t), basicfont.Face7x13, barsX+(t-1)*cellWidth, axisY+12, color.RGBA{180, 180, 255, 255})
+	}
+
+	// Interval bars; nodes still on the stack extend to the current time
+	barsY := axisY + axisHeight
+	for v := 0; v < n; v++ {
+		start := d.Discovery[v]
+		if start == 0 {
+			continue
+		}
+		end := d.Finish[v]
+		barColor := color.RGBA{110, 110, 110, 255} // Open interval, node is on the stack
+		if end == 0 {
+			end = d.Time
+		} else {
+			barColor = color.RGBA{70, 90, 160, 255}
+		}
+		if v == d.Current {
+			barColor = color.RGBA{255, 69, 0, 255}
+		}
+
+		bx := barsX + (start-1)*cellWidth
+		by := barsY + depth[v]*rowHeight
+		draw.DrawCachedRect(screen, float64(bx), float64(by+1), float64((end-start+1)*cellWidth-2), float64(rowHeight-3), barColor)
+		label := string(rune('A' + v))
+		text.Draw(screen, label, basicfont.Face7x13, bx+3, by+12, textColor)
+	}
+
+	// The same events as text, where "(A" is A's discovery and "A)" its finish
+	lineY := barsY + (maxDepth+1)*rowHeight + padding
+	for i, line := range lines {
+		text.Draw(screen, line, basicfont.Face7x13, x+padding, lineY+i*rowHeight+12, textColor)
+	}
+}
+
+// wrapTokens joins tokens with spaces into lines of at most width characters
+func wrapTokens(tokens []string, width int) []string {
+	lines := []string{}
+	line := ""
+	for _, token := range tokens {
+		if line != "" && len(line)+1+len(token) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += token
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,56 @@
+package ui
+
+import "bfsdfs/internal/algorithms"
+
+// treeAnimation slides the tree nodes from where they were last drawn to the layout of the
+// tree shown now, so each replayed step shows the nodes moving to their new places
+// Nodes are matched by their first key, which no other node of the tree holds
+type treeAnimation struct {
+	tree   algorithms.TreeNode // Tree the nodes are moving into
+	width  int                 // Width of the window the tree is centered in
+	from   map[int][2]float64  // Where each first key was drawn when that tree appeared
+	drawn  map[int][2]float64  // Where each first key was drawn last
+	frame  int                 // Frames since the tree appeared
+	frames int                 // Frames the move takes
+}
+
+// retarget starts a new move when the tree to draw is not the one the nodes are moving into,
+// or when the window was resized and the tree is centered somewhere else
+func (a *treeAnimation) retarget(tree algorithms.TreeNode, width, frames int) {
+	if tree == a.tree && width == a.width {
+		return
+	}
+	a.tree = tree
+	a.width = width
+	a.from = a.drawn
+	a.drawn = map[int][2]float64{}
+	a.frame = 0
+	a.frames = frames
+}
+
+// running reports whether the nodes are still moving
+func (a *treeAnimation) running() bool {
+	return a.tree != nil && a.frame < a.frames
+}
+
+// at returns where to draw a node this frame, between its old place and its layout position
+// New nodes, and B-tree nodes emptied for a moment, appear at their layout position straight away
+func (a *treeAnimation) at(node algorithms.TreeNode) (float64, float64) {
+	pos := node.Pos()
+	x, y := float64(pos.X), float64(pos.Y)
+	keys := node.Keys()
+	if len(keys) == 0 {
+		return x, y
+	}
+	if start, ok := a.from[keys[0]]; ok && a.running() {
+		t := float64(a.frame) / float64(a.frames)
+		t = t * t * (3 - 2*t) // Ease in and out
+		x = start[0] + (x-start[0])*t
+		y = start[1] + (y-start[1])*t
+	}
+	if a.drawn == nil {
+		a.drawn = map[int][2]float64{}
+	}
+	a.drawn[keys[0]] = [2]float64{x, y}
+	return x, y
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxBTreeOrder keeps B-tree nodes narrow enough to fit side by side on the screen
+const maxBTreeOrder = 9
+
+// treeSequenceLength is how many values the menu inserts at once for a height comparison
+const treeSequenceLength = 15
+
+// selectTree switches the tree mode to another kind of tree, rebuilt from the same operations,
+// or starts the tree mode with an empty tree of that kind
+func (g *Game) selectTree(kind algorithms.TreeKind) {
+	if g.Sim.Maze != nil {
+		g.showMessage("Close the maze grid first: Algorithms > Maze > Close Maze Grid")
+		return
+	}
+	if g.Sim.Mode != algorithms.ModeIdle && g.Sim.Mode != algorithms.ModeAVL {
+		g.showMessage("Reset first to switch to the tree mode.")
+		return
+	}
+
+	g.AutoStep = false
+	g.Sim.SetTreeKind(kind)
+	g.canvasNeedsRedraw = true
+	if len(g.Sim.TreeOps) > 0 {
+		g.showMessage(fmt.Sprintf("Rebuilt as a %s from the same %d operations", kind, len(g.Sim.TreeOps)))
+	} else {
+		g.showMessage(kind.String() + " mode started. Use Insert/Delete/Search, then Step or Auto to replay.")
+	}
+}
+
+// insertTreeSequence inserts several values one after another; Step replays the last insertion
+func (g *Game) insertTreeSequence(values []int, description string) {
+	if g.Sim.Mode != algorithms.ModeAVL {
+		g.selectTree(g.Sim.TreeKind)
+		if g.Sim.Mode != algorithms.ModeAVL {
+			return
+		}
+	}
+
+	g.AutoStep = false
+	for _, value := range values {
+		g.Sim.InsertAVL(value)
+	}
+	g.Sim.SetAVLAction("insert")
+	g.Sim.SetAVLValue(values[len(values)-1])
+	g.canvasNeedsRedraw = true
+	g.showMessage("Inserted " + description + ". Step or Auto replays the last insertion.")
+}
+
+// ascendingTreeSequence returns the values 1 to treeSequenceLength, the worst order for an
+// unbalanced binary search tree
+func ascendingTreeSequence() []int {
+	values := make([]int, treeSequenceLength)
+	for i := range values {
+		values[i] = i + 1
+	}
+	return values
+}
+
+// randomTreeSequence returns treeSequenceLength distinct values between 1 and 99 in random order
+func randomTreeSequence() []int {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	values := r.Perm(99)[:treeSequenceLength]
+	for i := range values {
+		values[i]++
+	}
+	return values
+}
+
+// openBTreeOrderInput opens the value modal for the order of the B-tree
+func (g *Game) openBTreeOrderInput() {
+	g.AVLAction = "b-tree order"
+	g.AVLInputText = strconv.Itoa(g.Sim.BTreeOrder)
+	g.ShowAVLInput = true
+}
+
+// submitBTreeOrderInput applies the B-tree order typed into the modal
+func (g *Game) submitBTreeOrderInput() {
+	order, err := strconv.Atoi(g.AVLInputText)
+	if err != nil || order < 3 || order > maxBTreeOrder {
+		g.showMessage(fmt.Sprintf("The B-tree order must be between 3 and %d", maxBTreeOrder))
+		g.AVLInputText = ""
+		return
+	}
+
+	g.Sim.SetBTreeOrder(order)
+	g.ShowAVLInput = false
+	g.canvasNeedsRedraw = true
+	g.showMessage(fmt.Sprintf("B-tree nodes now hold up to %d keys and %d children", order-1, order))
+}
+
+// treeStepColor returns the color marking the node a replayed step concerns
+func treeStepColor(kind algorithms.StepKind) color.RGBA {
+	switch kind {
+	case algorithms.StepVisit:
+		return color.RGBA{255, 165, 0, 255} // Orange for the node compared with
+	case algorithms.StepFound:
+		return color.RGBA{255, 69, 0, 255} // Red-orange for a found node
+	case algorithms.StepCreate:
+		return color.RGBA{50, 205, 50, 255} // Green for a node taking a new key
+	case algorithms.StepUpdate:
+		return color.RGBA{218, 165, 32, 255} // Gold for a recomputed height, balance or color
+	case algorithms.StepViolation:
+		return color.RGBA{220, 20, 60, 255} // Crimson until the tree is repaired
+	case algorithms.StepRestructure:
+		return color.RGBA{148, 0, 211, 255} // Violet for rotations, splits and merges
+	}
+	return color.RGBA{0, 0, 0, 255}
+}
+
+// treeStatus describes the tree mode in up to three lines
+func (g *Game) treeStatus() (status, detail, extra string) {
+	tree := g.Sim.DisplayTree()
+	status = fmt.Sprintf("%s Mode, Tree Height: %d", g.Sim.TreeKind, algorithms.TreeHeight(tree))
+	if g.Sim.TreeKind == algorithms.TreeBTree {
+		status += fmt.Sprintf(", order %d", g.Sim.BTreeOrder)
+	}
+
+	// Show current action if any
+	steps := g.Sim.GetTreeSteps()
+	if g.Sim.GetAVLAction() != "" {
+		detail = fmt.Sprintf("Last Action: %s", g.Sim.GetAVLAction())
+		if len(steps) > 0 {
+			detail += fmt.Sprintf(" %d, step %d of %d", g.Sim.GetAVLValue(), g.Sim.Step, len(steps))
+		}
+	}
+
+	// Describe the replayed step
+	if step := g.Sim.CurrentTreeStep(); step != nil {
+		extra = step.Text
+	} else if len(steps) > 0 {
+		extra = "Press Step or Auto to replay the operation"
+	}
+	return
+}
+
+// drawTreeComparison lists the height every kind of tree reaches on the operations so far
+func (g *Game) drawTreeComparison(screen *ebiten.Image) {
+	if len(g.Sim.TreeComparison) == 0 {
+		return
+	}
+
+	header := []string{"Tree", "Height", "Nodes", "Steps"}
+	rows := [][]string{}
+	highlight := -1
+	for i, result := range g.Sim.TreeComparison {
+		name := result.Kind.String()
+		if result.Kind == algorithms.TreeBTree {
+			name += fmt.Sprintf(" (order %d)", g.Sim.BTreeOrder)
+		}
+		rows = append(rows, []string{name, strconv.Itoa(result.Height), strconv.Itoa(result.Nodes), strconv.Itoa(result.Steps)})
+		if result.Kind == g.Sim.TreeKind {
+			highlight = i
+		}
+	}
+	drawTablePanel(screen, fmt.Sprintf("Same %d operations on every tree", len(g.Sim.TreeOps)), header, rows, highlight)
+}
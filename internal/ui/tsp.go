@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// tspModes lists the travelling salesman algorithms offered in the Travelling Salesman menu
+var tspModes = []algorithms.TraversalMode{
+	algorithms.ModeNearestNeighborTSP,
+	algorithms.ModeTwoOpt,
+	algorithms.ModeMSTTour,
+	algorithms.ModeHeldKarp,
+	algorithms.ModeTSPCompare,
+}
+
+// startTSP builds a tour from the selected start node, or from A if none is selected
+func (g *Game) startTSP(mode algorithms.TraversalMode, start int) {
+	if start < 0 || start >= len(g.Sim.Graph.Nodes) {
+		start = 0
+	}
+	if len(g.Sim.Graph.Nodes) == 0 {
+		g.showMessage("The graph has no nodes")
+		return
+	}
+	if mode == algorithms.ModeHeldKarp && len(g.Sim.Graph.Nodes) > algorithms.HeldKarpMaxNodes {
+		g.showMessage(fmt.Sprintf("Held-Karp is limited to %d nodes", algorithms.HeldKarpMaxNodes))
+		return
+	}
+	if mode == algorithms.ModeMSTTour && !g.Sim.TSPSymmetric() {
+		g.showMessage("The MST tour needs the same distance both ways: use straight-line distance or undirected edges")
+		return
+	}
+
+	g.Sim.StartTSP(mode, start)
+	if math.IsInf(g.Sim.TourLength, 1) || g.Sim.Tour == nil {
+		g.showMessage("No tour: some nodes cannot reach each other along the edges")
+		return
+	}
+	if mode == algorithms.ModeTwoOpt {
+		g.showMessage("2-opt started from the nearest-neighbor tour. Each step applies the best move.")
+		return
+	}
+	g.showMessage(fmt.Sprintf("%s from %s: length %.1f", mode, string(rune('A'+start)), g.Sim.TourLength))
+}
+
+// drawTourEdges draws the tour as straight segments, the spanning tree behind the MST tour,
+// and the two edges the last 2-opt move swapped
+func (g *Game) drawTourEdges(canvas *ebiten.Image) {
+	for _, edge := range g.Sim.TourTree {
+		g.drawDashedEdge(canvas, edge[0], edge[1], 3, color.RGBA{130, 60, 180, 255})
+	}
+
+	tour := g.Sim.Tour
+	tourColor := color.RGBA{0, 120, 200, 255}
+	for i, v := range tour {
+		w := tour[(i+1)%len(tour)]
+		// With edge weights, consecutive tour nodes may be joined by a longer path instead of an edge
+		if _, ok := g.Sim.Graph.EdgeWeight(v, w); !ok && g.Sim.TSPMetric == algorithms.TSPEdgeWeights {
+			g.drawDashedEdge(canvas, v, w, 4, tourColor)
+		} else {
+			g.drawThickEdge(canvas, v, w, 4, tourColor)
+		}
+	}
+
+	if t := g.Sim.GetTwoOpt(); t != nil && t.Improvements > 0 && !t.Done() {
+		for _, edge := range t.Removed {
+			g.drawDashedEdge(canvas, edge[0], edge[1], 3, color.RGBA{220, 20, 60, 255})
+		}
+		for _, edge := range t.Added {
+			g.drawThickEdge(canvas, edge[0], edge[1], 5, color.RGBA{34, 139, 34, 255})
+		}
+	}
+}
+
+// drawTourLabels labels every node with its position in the tour
+func (g *Game) drawTourLabels(canvas *ebiten.Image) {
+	for i, v := range g.Sim.Tour {
+		g.drawNodeText(canvas, v, fmt.Sprintf("#%d", i+1), 22, -14, color.RGBA{0, 0, 200, 255})
+	}
+}
+
+// tspStatus describes the current tour in up to three lines
+func (g *Game) tspStatus() (status, detail, extra string) {
+	start := string(rune('A' + g.Sim.Source))
+	status = fmt.Sprintf("%s from %s using %s", g.Sim.Mode, start, g.Sim.TSPMetric)
+	if g.Sim.Tour == nil || math.IsInf(g.Sim.TourLength, 1) {
+		detail = "No tour: some nodes cannot reach each other along the edges"
+		return
+	}
+	tour := append(append([]int{}, g.Sim.Tour...), g.Sim.Tour[0])
+	detail = fmt.Sprintf("Length %.1f: %s", g.Sim.TourLength, formatPath(tour))
+
+	switch g.Sim.Mode {
+	case algorithms.ModeNearestNeighborTSP:
+		extra = "Always moves to the closest unvisited node; usually 15-25% above optimal"
+	case algorithms.ModeTwoOpt:
+		t := g.Sim.GetTwoOpt()
+		if t == nil {
+			return
+		}
+		switch {
+		case t.Done():
+			extra = fmt.Sprintf("2-optimal after %d moves: no pair of edges can be swapped to shorten it", t.Improvements)
+		case t.Improvements > 0:
+			r, a := t.Removed, t.Added
+			extra = fmt.Sprintf("Move %d: replaced %s-%s and %s-%s (dashed red) by %s-%s and %s-%s (green), saving %.1f",
+				t.Improvements, nodeName(r[0][0]), nodeName(r[0][1]), nodeName(r[1][0]), nodeName(r[1][1]),
+				nodeName(a[0][0]), nodeName(a[0][1]), nodeName(a[1][0]), nodeName(a[1][1]), t.Gain)
+		default:
+			extra = "Starting from the nearest-neighbor tour; each step applies the move that saves the most"
+		}
+	case algorithms.ModeMSTTour:
+		extra = "Visits the minimum spanning tree (dashed purple) in preorder; at most twice the optimum"
+	case algorithms.ModeHeldKarp:
+		extra = "Optimal: dynamic programming over every subset of nodes"
+	case algorithms.ModeTSPCompare:
+		extra = fmt.Sprintf("The shortest tour is drawn; no tour can be shorter than the MST weight %.1f", g.Sim.TSPLowerBound)
+		if math.IsNaN(g.Sim.TSPLowerBound) {
+			extra = "The shortest tour is drawn; distances differ by direction, so there is no MST tour or bound"
+		}
+	}
+	if g.Sim.TSPMetric == algorithms.TSPEdgeWeights {
+		extra += ". Dashed legs follow shortest paths"
+	}
+	return
+}
+
+// drawTSPComparison lists the tour length of every algorithm relative to the best one found
+func (g *Game) drawTSPComparison(screen *ebiten.Image) {
+	if len(g.Sim.TSPComparison) == 0 {
+		return
+	}
+	best := math.Inf(1)
+	for _, stats := range g.Sim.TSPComparison {
+		if stats.Tour != nil && stats.Length < best {
+			best = stats.Length
+		}
+	}
+
+	header := []string{"Algorithm", "Length", "vs best"}
+	rows := [][]string{}
+	highlight := -1
+	for i, stats := range g.Sim.TSPComparison {
+		switch {
+		case stats.Tour == nil:
+			rows = append(rows, []string{stats.Algorithm, "-", fmt.Sprintf("> %d nodes", algorithms.HeldKarpMaxNodes)})
+		case math.IsInf(stats.Length, 1):
+			rows = append(rows, []string{stats.Algorithm, "no tour", "-"})
+		default:
+			rows = append(rows, []string{stats.Algorithm, fmt.Sprintf("%.1f", stats.Length), fmt.Sprintf("+%.1f%%", (stats.Length/best-1)*100)})
+			if highlight == -1 && stats.Length == best {
+				highlight = i
+			}
+		}
+	}
+	if !math.IsNaN(g.Sim.TSPLowerBound) {
+		rows = append(rows, []string{"MST lower bound", fmt.Sprintf("%.1f", g.Sim.TSPLowerBound), ""})
+	}
+	drawTablePanel(screen, "Tour comparison", header, rows, highlight)
+}
+
+// nodeName returns a node's letter
+func nodeName(v int) string {
+	return string(rune('A' + v))
+}
@@ -124,6 +124,13 @@ func (g *Game) Update() error {
 
 	// Handle right-click for context menu
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) && !ebiten.IsKeyPressed(ebiten.KeyShift) && g.MouseY < screenHeight-100 {
+		// In the maze mode the right button erases cells instead of opening the menu
+		if g.Sim.Maze != nil {
+			g.mazePainting, g.mazeStroke = true, brushErase
+			g.paintMazeCell(g.MouseX, g.MouseY, brushErase)
+			return nil
+		}
+
 		// Calculate mouse position in canvas coordinates (accounting for offset)
 		canvasX := g.MouseX - int(g.CanvasOffsetX)
 		canvasY := g.MouseY - int(g.CanvasOffsetY)
@@ -150,6 +157,14 @@ func (g *Game) Update() error {
 				g.showMessage("Start node set to " + string(rune('A'+targetNode)))
 			})
 
+			g.ContextMenu.AddItem("Set as Target Node", func() {
+				g.setTargetNode(targetNode)
+			})
+
+			g.ContextMenu.AddItem("Set Heuristic Value...", func() {
+				g.openHeuristicInput("heuristic", targetNode)
+			})
+
 			g.ContextMenu.AddItem("Delete Node", func() {
 				// Don't allow removing the last node
 				if len(g.Sim.Graph.Nodes) > 1 {
@@ -277,8 +292,10 @@ func (g *Game) Update() error {
 				if err != nil {
 					g.showMessage("Error loading graph: " + err.Error())
 				} else {
+					g.Sim.CloseMaze()
 					g.Sim.Graph = *loadedGraph
 					g.Sim.Reset()
+					g.clearHighlights()
 					g.StartNode = 0
 					g.showMessage("Graph loaded from " + filePath)
 				}
@@ -310,6 +327,7 @@ func (g *Game) Update() error {
 	// Handle context menu clicks
 	if g.ContextMenu.Visible && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		if g.ContextMenu.HandleClick(g.MouseX, g.MouseY) {
+			g.MouseClicked = true // Don't treat the held button as a new canvas click
 			return nil
 		}
 	}
@@ -328,6 +346,36 @@ func (g *Game) Update() error {
 
 		// Handle Enter key (OK)
 		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			// Inputs that configure A* rather than operate on the AVL tree
+			if g.AVLAction == "heuristic" || g.AVLAction == "epsilon" {
+				g.submitHeuristicInput()
+				return nil
+			}
+			if g.AVLAction == "depth limit" {
+				g.submitDepthLimitInput()
+				return nil
+			}
+			if g.AVLAction == "search budget" {
+				g.submitHamiltonBudgetInput()
+				return nil
+			}
+			if g.AVLAction == "cycle limit" {
+				g.submitCycleLimitInput()
+				return nil
+			}
+			if g.AVLAction == "path count" || g.AVLAction == "path limit" {
+				g.submitPathListInput()
+				return nil
+			}
+			if g.AVLAction == "terrain cost" {
+				g.submitTerrainCostInput()
+				return nil
+			}
+			if g.AVLAction == "b-tree order" {
+				g.submitBTreeOrderInput()
+				return nil
+			}
+
 			if g.AVLAction != "" && g.AVLInputText != "" {
 				value, err := strconv.Atoi(g.AVLInputText)
 				if err == nil {
@@ -344,15 +392,19 @@ func (g *Game) Update() error {
 					switch g.AVLAction {
 					case "insert":
 						sim.InsertAVL(value)
-						g.showMessage(fmt.Sprintf("Inserted %d into AVL tree", value))
+						g.showMessage(fmt.Sprintf("Inserted %d into the %s. Step or Auto replays it.", value, sim.TreeKind))
 					case "delete":
 						sim.DeleteAVL(value)
-						g.showMessage(fmt.Sprintf("Deleted %d from AVL tree", value))
+						g.showMessage(fmt.Sprintf("Deleted %d from the %s. Step or Auto replays it.", value, sim.TreeKind))
 					case "search":
-						sim.SearchAVL(value)
-						g.showMessage(fmt.Sprintf("Searched for %d in AVL tree", value))
+						result := "not found"
+						if sim.SearchAVL(value) {
+							result = "found"
+						}
+						g.showMessage(fmt.Sprintf("Searched for %d in the %s: %s. Step or Auto replays it.", value, sim.TreeKind, result))
 					}
 					sim.UpdateAVL() // Update visualization after operation
+					g.canvasNeedsRedraw = true
 
 					// Close modal
 					g.ShowAVLInput = false
@@ -418,6 +470,28 @@ func (g *Game) Update() error {
 				}
 			}
 
+			// Check for clicks on the all-pairs matrix panel
+			if g.Sim.GetAllPairs() != nil && g.MatrixPanel.Contains(g.MouseX, g.MouseY) {
+				g.handleMatrixClick()
+				g.MouseClicked = true
+				return nil
+			}
+
+			// Check for clicks on the list of cycles or paths
+			if g.listPanelShown() && g.ListPanel.Contains(g.MouseX, g.MouseY) {
+				g.handleListPanelClick()
+				g.MouseClicked = true
+				return nil
+			}
+
+			// In the maze mode the canvas paints cells instead of editing the graph
+			if g.Sim.Maze != nil && g.MouseY < screenHeight-100 && g.mazeCellAt(g.MouseX, g.MouseY) != -1 {
+				g.mazePainting, g.mazeStroke = true, g.MazeBrush
+				g.paintMazeCell(g.MouseX, g.MouseY, g.MazeBrush)
+				g.MouseClicked = true
+				return nil
+			}
+
 			// Check for slider interaction in the HUD area
 			sliderBgWidth := 200
 			sliderBgHeight := 20
@@ -495,6 +569,11 @@ func (g *Game) Update() error {
 					g.showMessage("Start node set to " + string(rune('A'+targetNode)))
 				}
 
+				// In source-to-target modes, clicking a node chooses the target
+				if targetNode != -1 && !g.EditMode && g.usesTargetNode() {
+					g.setTargetNode(targetNode)
+				}
+
 				// Handle adding/removing nodes/edges in edit mode
 				if g.EditMode {
 					if g.RemovingNode {
@@ -600,6 +679,15 @@ func (g *Game) Update() error {
 		}
 	}
 
+	// Keep painting maze cells while a button is held
+	if g.mazePainting {
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) || ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
+			g.paintMazeCell(g.MouseX, g.MouseY, g.mazeStroke)
+		} else {
+			g.mazePainting = false
+		}
+	}
+
 	// Handle dragging a node (if not dragging a selection)
 	if g.DraggingNode != -1 && !g.DraggingSelection {
 		// Convert mouse position to canvas coordinates
@@ -681,15 +769,23 @@ func (g *Game) Update() error {
 	}
 
 	// Auto-stepping with frame rate consideration
-	if g.AutoStep && !g.Sim.Done && g.Sim.Mode != algorithms.ModeIdle && g.Sim.Mode != algorithms.ModeAVL {
+	if g.AutoStep && !g.Sim.Done && g.Sim.Mode != algorithms.ModeIdle {
 		g.StepCounter++
-		if g.StepCounter >= g.StepDelay {
+		if g.Sim.Mode == algorithms.ModeMaze {
+			// Mazes have hundreds of cells, so they take several steps every frame
+			for i := 0; i < g.mazeStepsPerFrame() && !g.Sim.Done; i++ {
+				g.Sim.Update()
+			}
+		} else if g.StepCounter >= g.StepDelay {
 			g.StepCounter = 0
 			g.Sim.Update()
 		}
-	} else if g.AutoStep && g.Sim.Mode == algorithms.ModeAVL {
-		// Disable auto-stepping when in AVL mode
-		g.AutoStep = false
+	}
+
+	// Keep redrawing while the AVL tree nodes move to their new places
+	if g.Sim.Mode == algorithms.ModeAVL && g.avlAnim.running() {
+		g.avlAnim.frame++
+		g.canvasNeedsRedraw = true
 	}
 
 	// Only update canvas if necessary
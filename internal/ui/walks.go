@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"bfsdfs/internal/algorithms"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// drawEulerEdges draws the finished trail in green with each edge's position in the walk and the
+// walk still on the stack in orange; without a walk it rings the nodes that break the degree rule
+func (g *Game) drawEulerEdges(canvas *ebiten.Image) {
+	h := g.Sim.GetHierholzer()
+	if h == nil {
+		return
+	}
+	if h.Diagnosis.Kind == algorithms.EulerNone {
+		for _, v := range eulerOffenders(h.Diagnosis) {
+			g.drawNodeRing(canvas, v, color.RGBA{220, 20, 60, 255})
+		}
+		return
+	}
+
+	trailColor := color.RGBA{34, 139, 34, 255}
+	for i, e := range h.TrailEdges {
+		edge := h.Edges[e]
+		g.drawThickEdge(canvas, edge[0], edge[1], 4, trailColor)
+		// The trail is finished backwards, so the first finished edge is the last one walked
+		g.drawEdgeLabel(canvas, edge[0], edge[1], strconv.Itoa(len(h.Edges)-i), trailColor)
+	}
+	for _, e := range h.StackEdges {
+		edge := h.Edges[e]
+		g.drawThickEdge(canvas, edge[0], edge[1], 4, color.RGBA{255, 140, 0, 255})
+	}
+	if h.LastEdge != -1 && h.Walked {
+		edge := h.Edges[h.LastEdge]
+		g.drawDashedEdge(canvas, edge[0], edge[1], 7, color.RGBA{220, 20, 60, 255})
+	}
+}
+
+// eulerOffenders returns the nodes whose degrees rule out an Eulerian walk
+func eulerOffenders(d algorithms.EulerDiagnosis) []int {
+	if d.Surplus == nil {
+		return d.Odd
+	}
+	nodes := []int{}
+	for v, surplus := range d.Surplus {
+		if surplus != 0 {
+			nodes = append(nodes, v)
+		}
+	}
+	return nodes
+}
+
+// drawEulerLabels labels nodes with their degree (undirected) or out-in surplus (directed)
+// when those decide that no walk exists
+func (g *Game) drawEulerLabels(canvas *ebiten.Image) {
+	h := g.Sim.GetHierholzer()
+	if h == nil || h.Diagnosis.Kind != algorithms.EulerNone {
+		return
+	}
+	red := color.RGBA{200, 0, 0, 255}
+	if h.Diagnosis.Surplus != nil {
+		for v, surplus := range h.Diagnosis.Surplus {
+			if surplus != 0 {
+				g.drawNodeText(canvas, v, fmt.Sprintf("%+d", surplus), 22, -14, red)
+			}
+		}
+		return
+	}
+	degree := make([]int, len(g.Sim.Graph.Nodes))
+	for _, edge := range h.Edges {
+		degree[edge[0]]++
+		degree[edge[1]]++
+	}
+	for _, v := range h.Diagnosis.Odd {
+		g.drawNodeText(canvas, v, fmt.Sprintf("deg:%d", degree[v]), 22, -14, red)
+	}
+}
+
+// drawEdgeLabel draws a short label just beside the midpoint of the edge between a and b
+func (g *Game) drawEdgeLabel(canvas *ebiten.Image, a, b int, label string, clr color.Color) {
+	if a < 0 || b < 0 || a >= len(g.Sim.Graph.Nodes) || b >= len(g.Sim.Graph.Nodes) {
+		return
+	}
+	na, nb := g.Sim.Graph.Nodes[a], g.Sim.Graph.Nodes[b]
+	x := (na.X+nb.X)/2 + int(g.CanvasOffsetX)
+	y := (na.Y+nb.Y)/2 + int(g.CanvasOffsetY)
+	text.Draw(canvas, label, basicfont.Face7x13, x+6, y-6, clr)
+}
+
+// drawHamiltonianPath draws the partial path of the Hamiltonian search, its closing edge once a
+// cycle is found, and rings the node the last step backtracked from
+func (g *Game) drawHamiltonianPath(canvas *ebiten.Image) {
+	h := g.Sim.GetHamiltonian()
+	if h == nil {
+		return
+	}
+	pathColor := color.RGBA{255, 140, 0, 255}
+	if h.Found {
+		pathColor = color.RGBA{34, 139, 34, 255}
+		if h.Cycle {
+			g.drawThickEdge(canvas, h.Path[len(h.Path)-1], h.Path[0], 5, pathColor)
+		}
+	}
+	g.drawPath(canvas, h.Path, 5, pathColor)
+	if h.Removed != -1 {
+		g.drawNodeRing(canvas, h.Removed, color.RGBA{220, 20, 60, 255})
+	}
+}
+
+// drawHamiltonianLabels labels the nodes of the partial path with their position
+func (g *Game) drawHamiltonianLabels(canvas *ebiten.Image) {
+	h := g.Sim.GetHamiltonian()
+	if h == nil {
+		return
+	}
+	for i, v := range h.Path {
+		g.drawNodeText(canvas, v, fmt.Sprintf("#%d", i+1), 22, -14, color.RGBA{0, 0, 200, 255})
+	}
+}
+
+// walkStatus describes the Eulerian or Hamiltonian search in up to three lines
+func (g *Game) walkStatus() (status, detail, extra string) {
+	if h := g.Sim.GetHierholzer(); h != nil {
+		return g.eulerStatus(h)
+	}
+	h := g.Sim.GetHamiltonian()
+	if h == nil {
+		return
+	}
+	kind := "path"
+	if h.Cycle {
+		kind = "cycle"
+	}
+	status = fmt.Sprintf("Hamiltonian %s: %d of %d nodes on the path, %d of %d extensions used", kind,
+		len(h.Path), len(g.Sim.Graph.Nodes), h.Expanded, h.Budget)
+	switch {
+	case h.Found:
+		detail = "Found: " + formatPath(h.Path)
+		if h.Cycle {
+			detail = "Found: " + formatPath(append(append([]int{}, h.Path...), h.Path[0]))
+		}
+	case h.OutOfTime:
+		detail = "Budget used up before the search finished: no answer. Raise it with Set Search Budget..."
+	case h.Done():
+		detail = fmt.Sprintf("No Hamiltonian %s exists: every branch was tried", kind)
+	case h.Removed != -1:
+		detail = fmt.Sprintf("Dead end at %s: backtracking", string(rune('A'+h.Removed)))
+	default:
+		detail = "Path: " + formatPath(h.Path)
+	}
+	extra = "Each step extends the path to the next unvisited neighbor or backtracks"
+	return
+}
+
+// eulerStatus explains the degree diagnosis and the progress of Hierholzer's algorithm
+func (g *Game) eulerStatus(h *algorithms.HierholzerStepper) (status, detail, extra string) {
+	d := h.Diagnosis
+	if len(h.Edges) == 0 {
+		status = "The graph has no edges to walk"
+		return
+	}
+	if d.Kind == algorithms.EulerNone {
+		status = "No Eulerian path or circuit exists"
+		switch {
+		case d.Components > 1:
+			detail = fmt.Sprintf("The edges lie in %d separate components", d.Components)
+		case d.Surplus != nil:
+			detail = fmt.Sprintf("Unbalanced nodes (labelled out-in): %s", formatNodeList(eulerOffenders(d)))
+		default:
+			detail = fmt.Sprintf("%d nodes have odd degree: %s", len(d.Odd), formatNodeList(d.Odd))
+		}
+		if d.Surplus != nil {
+			extra = "A directed walk needs in = out everywhere, except +1 at its start and -1 at its end"
+		} else {
+			extra = "A walk needs 0 odd-degree nodes (circuit) or exactly 2 (path between them)"
+		}
+		return
+	}
+
+	start, end := string(rune('A'+d.Start)), string(rune('A'+d.End))
+	if d.Kind == algorithms.EulerCircuit {
+		status = fmt.Sprintf("Hierholzer: Eulerian circuit from %s", start)
+	} else {
+		status = fmt.Sprintf("Hierholzer: Eulerian path from %s to %s", start, end)
+	}
+	status += fmt.Sprintf(", %d of %d edges finished", len(h.TrailEdges), len(h.Edges))
+	switch {
+	case h.Done():
+		detail = "Done: " + formatPath(g.Sim.Path)
+	case h.LastEdge != -1 && h.Walked:
+		edge := h.Edges[h.LastEdge]
+		detail = fmt.Sprintf("Walked edge %s-%s (dashed)", string(rune('A'+edge[0])), string(rune('A'+edge[1])))
+	case h.LastEdge != -1 || len(h.Trail) > 0:
+		detail = fmt.Sprintf("%s has no unused edges left: it moves onto the trail", string(rune('A'+h.Trail[len(h.Trail)-1])))
+	default:
+		detail = "Press Step to walk the first edge"
+	}
+	extra = "Walk in progress orange; finished trail green, numbered by position; stuck nodes splice in sub-circuits"
+	return
+}
+
+// openHamiltonBudgetInput opens the value modal for the Hamiltonian search budget
+func (g *Game) openHamiltonBudgetInput() {
+	g.AVLAction = "search budget"
+	g.AVLInputText = strconv.Itoa(g.Sim.HamiltonBudget)
+	g.ShowAVLInput = true
+}
+
+// submitHamiltonBudgetInput applies the budget typed into the modal
+func (g *Game) submitHamiltonBudgetInput() {
+	budget, err := strconv.Atoi(g.AVLInputText)
+	if err != nil || budget < 1 {
+		g.showMessage("Invalid search budget")
+		g.AVLInputText = ""
+		return
+	}
+
+	g.Sim.HamiltonBudget = budget
+	g.ShowAVLInput = false
+	g.showMessage(fmt.Sprintf("Hamiltonian search budget = %d extensions", budget))
+
+	// Restart a running Hamiltonian search with the new budget
+	if h := g.Sim.GetHamiltonian(); h != nil {
+		g.Sim.StartHamiltonian(h.Cycle)
+	}
+}
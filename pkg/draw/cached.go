@@ -51,6 +51,53 @@ func DrawCachedLine(img *ebiten.Image, x0, y0, x1, y1 float64, clr color.Color)
 	img.DrawImage(lineImg, opts)
 }
 
+// DrawCachedThickLine draws a line of the given thickness centered on (x0,y0)-(x1,y1)
+// Uses the same cached 1x1 pixel image as DrawCachedLine
+func DrawCachedThickLine(img *ebiten.Image, x0, y0, x1, y1, thickness float64, clr color.Color) {
+	// Convert color to RGBA for cache key
+	r, g, b, a := clr.RGBA()
+	rgba := color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+
+	lineImg := getOrCreateLineImage(rgba)
+
+	length := math.Sqrt((x1-x0)*(x1-x0) + (y1-y0)*(y1-y0))
+	angle := math.Atan2(y1-y0, x1-x0)
+
+	// Scale to length x thickness and center the thickness on the line before rotating
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(length, thickness)
+	opts.GeoM.Translate(0, -thickness/2)
+	opts.GeoM.Rotate(angle)
+	opts.GeoM.Translate(x0, y0)
+
+	img.DrawImage(lineImg, opts)
+}
+
+// DrawCachedDashedLine draws a thick line as dashes of length dash separated by gaps of length gap
+func DrawCachedDashedLine(img *ebiten.Image, x0, y0, x1, y1, thickness, dash, gap float64, clr color.Color) {
+	length := math.Sqrt((x1-x0)*(x1-x0) + (y1-y0)*(y1-y0))
+	if length == 0 || dash <= 0 {
+		return
+	}
+	dx := (x1 - x0) / length
+	dy := (y1 - y0) / length
+
+	for start := 0.0; start < length; start += dash + gap {
+		end := math.Min(start+dash, length)
+		DrawCachedThickLine(img, x0+dx*start, y0+dy*start, x0+dx*end, y0+dy*end, thickness, clr)
+	}
+}
+
+// DrawCachedArrowHead draws an arrowhead with its tip at (x1,y1), pointing away from (x0,y0)
+func DrawCachedArrowHead(img *ebiten.Image, x0, y0, x1, y1, size, thickness float64, clr color.Color) {
+	angle := math.Atan2(y1-y0, x1-x0)
+	for _, spread := range []float64{-math.Pi / 6, math.Pi / 6} {
+		wx := x1 - size*math.Cos(angle+spread)
+		wy := y1 - size*math.Sin(angle+spread)
+		DrawCachedThickLine(img, wx, wy, x1, y1, thickness, clr)
+	}
+}
+
 // DrawCachedCircle draws a filled circle with center (cx,cy) and radius r
 // Uses cached circle images for better performance
 func DrawCachedCircle(img *ebiten.Image, cx, cy, r int, clr color.Color) {
@@ -71,6 +118,20 @@ func DrawCachedCircle(img *ebiten.Image, cx, cy, r int, clr color.Color) {
 	img.DrawImage(circleImg, opts)
 }
 
+// DrawCachedRect draws a filled axis-aligned rectangle with the given color
+// Uses the cached 1x1 pixel image instead of allocating a new image per call
+func DrawCachedRect(img *ebiten.Image, x, y, width, height float64, clr color.Color) {
+	// Convert color to RGBA for cache key
+	r, g, b, a := clr.RGBA()
+	rgba := color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(width, height)
+	opts.GeoM.Translate(x, y)
+
+	img.DrawImage(getOrCreateLineImage(rgba), opts)
+}
+
 // getOrCreateLineImage retrieves a line image from cache or creates a new one
 func getOrCreateLineImage(clr color.RGBA) *ebiten.Image {
 	cacheMutex.RLock()
@@ -4,6 +4,8 @@ import (
 	"bfsdfs/internal/algorithms"
 	"bfsdfs/internal/graph"
 	"fmt"
+	"math"
+	"math/rand"
 )
 
 func main() {
@@ -32,8 +34,12 @@ func main() {
 	// Test Topological Sort
 	fmt.Println("\n3. Topological Sort:")
 	unweightedNeighbors := g.GetUnweightedNeighbors()
-	topOrder := algorithms.TopologicalSort(unweightedNeighbors, len(g.Nodes))
-	fmt.Printf("  Topological order: %v\n", topOrder)
+	topOrder, cycle := algorithms.TopologicalSort(unweightedNeighbors, len(g.Nodes))
+	if cycle != nil {
+		fmt.Printf("  No topological order, the graph has a cycle: %v\n", cycle)
+	} else {
+		fmt.Printf("  Topological order: %v\n", topOrder)
+	}
 
 	// Test Kruskal's MST
 	fmt.Println("\n4. Kruskal's MST:")
@@ -67,5 +73,326 @@ func main() {
 		fmt.Printf("    SCC %d: %v\n", i+1, scc)
 	}
 
+	// Test all-pairs shortest paths
+	fmt.Println("\n8. Floyd-Warshall / Johnson:")
+	fw := algorithms.FloydWarshall(neighbors, len(g.Nodes))
+	johnson := algorithms.Johnson(neighbors, len(g.Nodes))
+	for i := range fw.Dist {
+		fmt.Printf("  From %d: %.2f (Johnson: %.2f)\n", i, fw.Dist[i], johnson.Dist[i])
+	}
+	fmt.Printf("  Path 0 -> %d: %v\n", len(g.Nodes)-1, algorithms.ReconstructPath(fw.Next, 0, len(g.Nodes)-1))
+
+	// Compare A* heuristics on the same start and goal
+	fmt.Println("\n9. A* Heuristics:")
+	opts := algorithms.HeuristicOptions{
+		Positions: positions,
+		Scale:     algorithms.AdmissibleScale(neighbors, positions),
+		Epsilon:   2,
+	}
+	for _, stats := range algorithms.CompareHeuristics(neighbors, 0, len(g.Nodes)-1, len(g.Nodes), opts) {
+		fmt.Printf("  %-10s expanded %d, cost %.2f, admissible %v, consistent %v\n",
+			stats.Kind, stats.Expanded, stats.Cost, stats.Admissible, stats.Consistent)
+	}
+
+	// Test the uninformed searches from the first to the last node
+	fmt.Println("\n10. Bidirectional BFS / IDDFS / Depth-Limited DFS / UCS:")
+	last := len(g.Nodes) - 1
+	fmt.Printf("  Bidirectional BFS path: %v\n", algorithms.BidirectionalBFS(unweightedNeighbors, 0, last))
+	iddfsPath, depth := algorithms.IDDFS(unweightedNeighbors, 0, last, len(g.Nodes))
+	fmt.Printf("  IDDFS path: %v (found with depth limit %d)\n", iddfsPath, depth)
+	dlsPath, cutoff := algorithms.DepthLimitedSearch(unweightedNeighbors, 0, last, 1)
+	fmt.Printf("  Depth-limited DFS (limit 1) path: %v, cut off: %v\n", dlsPath, cutoff)
+	ucsPath, ucsCost := algorithms.UniformCostSearch(neighbors, 0, last)
+	fmt.Printf("  UCS path: %v (cost %.2f)\n", ucsPath, ucsCost)
+
+	// Test DFS timestamps and edge classification
+	fmt.Println("\n11. DFS Discovery/Finish Times:")
+	discovery, finish, parents, classified := algorithms.DFSTimes(unweightedNeighbors, 0, len(g.Nodes), false)
+	for i := range discovery {
+		fmt.Printf("  Node %d: %d/%d (parent %d)\n", i, discovery[i], finish[i], parents[i])
+	}
+	for _, edge := range classified {
+		fmt.Printf("    %d -> %d: %s\n", edge.From, edge.To, edge.Class)
+	}
+
+	// Test BFS levels and parents
+	fmt.Println("\n12. BFS Levels and Parents:")
+	queue := []int{0}
+	visited := map[int]bool{}
+	levels := map[int]int{0: 0}
+	bfsParents := map[int]int{}
+	for done := false; !done; {
+		var node int
+		queue, node, done = algorithms.BFSTreeStep(queue, visited, unweightedNeighbors, levels, bfsParents)
+		if node != -1 {
+			visited[node] = true
+		}
+	}
+	for i := range g.Nodes {
+		fmt.Printf("  Node %d: level %d, path %v\n", i, levels[i], algorithms.PathFromPredecessors(bfsParents, i))
+	}
+
+	// Test Prim from a different root; the total weight matches Kruskal
+	fmt.Println("\n13. Prim From Another Root:")
+	primFromLast := algorithms.PrimFrom(neighbors, len(g.Nodes)-1, len(g.Nodes))
+	fmt.Printf("  Root %d: %d edges, total weight %.2f\n", len(g.Nodes)-1, len(primFromLast), mstWeight(primFromLast))
+	fmt.Printf("  Kruskal total weight: %.2f\n", mstWeight(mst))
+
+	// Test every MST algorithm on the same edges
+	fmt.Println("\n14. MST Algorithm Comparison:")
+	for _, stats := range algorithms.CompareMST(g.WeightedEdges, len(g.Nodes), 0) {
+		fmt.Printf("  %-15s %d edges, total weight %.2f, %d %s\n", stats.Algorithm, len(stats.Edges), stats.Weight, stats.Operations, stats.Counted)
+	}
+
+	// Step through Tarjan and show the final index/lowlink of every node
+	fmt.Println("\n15. Stepped Tarjan:")
+	tarjan := algorithms.NewTarjanStepper(unweightedNeighbors, len(g.Nodes))
+	steps := 0
+	for !tarjan.Done() {
+		tarjan.Step()
+		steps++
+	}
+	for v := range g.Nodes {
+		fmt.Printf("  Node %d: index %d, lowlink %d, component %d\n", v, tarjan.Index[v], tarjan.Lowlink[v], tarjan.Component[v]+1)
+	}
+	fmt.Printf("  %d steps, %d components (one-shot Tarjan found %d)\n", steps, len(tarjan.SCCs), len(sccs))
+
+	// Test undirected connectivity before the graph is made directed
+	fmt.Println("\n16. Connectivity:")
+	fmt.Printf("  Connected components: %v\n", algorithms.ConnectedComponents(unweightedNeighbors, len(g.Nodes)))
+	biconnectivity := algorithms.Biconnectivity(unweightedNeighbors, len(g.Nodes))
+	fmt.Printf("  Bridges: %v\n", biconnectivity.Bridges)
+	fmt.Printf("  Articulation points: %v\n", biconnectivity.ArticulationPoints)
+	fmt.Printf("  Biconnected components: %v\n", biconnectivity.BlockNodes())
+
+	// Make the edges directed and collapse the SCCs into the condensation DAG
+	fmt.Println("\n17. Condensation DAG:")
+	g.SetDirected(true)
+	directedSCCs := algorithms.Tarjan(g.GetUnweightedNeighbors(), len(g.Nodes))
+	dag, componentOf := g.Condensation(directedSCCs)
+	fmt.Printf("  %d components, %d edges between them\n", len(dag.Nodes), len(dag.Edges))
+	fmt.Printf("  Component of each node: %v\n", componentOf)
+	dagOrder, _ := algorithms.TopologicalSort(dag.GetUnweightedNeighbors(), len(dag.Nodes))
+	fmt.Printf("  Topological order of components: %v\n", dagOrder)
+
+	// Kahn's algorithm agrees with the DFS order on the DAG and finds a cycle in the directed graph
+	fmt.Println("\n18. Kahn's Algorithm:")
+	kahnOrder, _ := algorithms.Kahn(dag.GetUnweightedNeighbors(), len(dag.Nodes))
+	fmt.Printf("  Condensation order: %v\n", kahnOrder)
+	if _, kahnCycle := algorithms.Kahn(g.GetUnweightedNeighbors(), len(g.Nodes)); kahnCycle != nil {
+		fmt.Printf("  Directed graph cycle: %v\n", kahnCycle)
+	} else {
+		fmt.Println("  The directed graph is acyclic")
+	}
+
+	// Test max flow on the undirected edges with weights as capacities; the flow equals the min cut
+	fmt.Println("\n19. Maximum Flow:")
+	ekFlow, network := algorithms.EdmondsKarp(neighbors, 0, len(g.Nodes)-1, len(g.Nodes))
+	dinicFlow, _ := algorithms.Dinic(neighbors, 0, len(g.Nodes)-1, len(g.Nodes))
+	cutCapacity := 0.0
+	for _, i := range network.CutArcs(network.ResidualReachable(0)) {
+		cutCapacity += network.Arcs[i].Capacity
+	}
+	fmt.Printf("  From 0 to %d: Edmonds-Karp %.2f, Dinic %.2f, min cut capacity %.2f\n", len(g.Nodes)-1, ekFlow, dinicFlow, cutCapacity)
+
+	// Test bipartiteness on the undirected graph, then matching on a fixed workers/jobs graph
+	fmt.Println("\n20. Bipartite Matching:")
+	if side, oddCycle := algorithms.Bipartite(unweightedNeighbors, len(g.Nodes)); oddCycle != nil {
+		fmt.Printf("  Random graph is not bipartite, odd cycle: %v\n", oddCycle)
+	} else {
+		fmt.Printf("  Random graph is bipartite, sides: %v\n", side)
+	}
+	// Workers 0-2 and jobs 3-5, weights are costs
+	jobs := map[int][]algorithms.Edge{}
+	for _, e := range []algorithms.Edge{{From: 0, To: 3, Weight: 4}, {From: 0, To: 4, Weight: 1}, {From: 1, To: 3, Weight: 2},
+		{From: 1, To: 5, Weight: 5}, {From: 2, To: 4, Weight: 3}, {From: 2, To: 5, Weight: 2}} {
+		jobs[e.From] = append(jobs[e.From], e)
+		jobs[e.To] = append(jobs[e.To], algorithms.Edge{From: e.To, To: e.From, Weight: e.Weight})
+	}
+	jobNeighbors := map[int][]int{}
+	for v, edges := range jobs {
+		for _, e := range edges {
+			jobNeighbors[v] = append(jobNeighbors[v], e.To)
+		}
+	}
+	side, _ := algorithms.Bipartite(jobNeighbors, 6)
+	matching, phases := algorithms.HopcroftKarp(jobNeighbors, side)
+	assignment, cost := algorithms.Hungarian(jobs, side)
+	fmt.Printf("  Hopcroft-Karp: %v after %d phases\n", matching, phases)
+	fmt.Printf("  Hungarian: %v with total weight %.1f\n", assignment, cost)
+
+	// Compare the greedy coloring orders with the chromatic number
+	fmt.Println("\n21. Graph Coloring:")
+	strategies := []algorithms.ColoringOrder{algorithms.ColoringIndex, algorithms.ColoringWelshPowell, algorithms.ColoringDSatur}
+	for i, name := range []string{"Index order", "Welsh-Powell", "DSatur"} {
+		colors, used := algorithms.GreedyColoring(unweightedNeighbors, len(g.Nodes), strategies[i])
+		fmt.Printf("  %s: %d colors %v\n", name, used, colors)
+	}
+	chromatic, coloring := algorithms.ChromaticNumber(unweightedNeighbors, len(g.Nodes))
+	fmt.Printf("  Chromatic number: %d %v\n", chromatic, coloring)
+
+	// Eulerian walks on the directed graph and on a square with one diagonal, whose ends have odd degree
+	fmt.Println("\n22. Euler and Hamilton:")
+	if walk, diagnosis := algorithms.EulerianPath(g.Edges, len(g.Nodes), g.Directed); walk != nil {
+		fmt.Printf("  Directed graph: Eulerian walk %v\n", walk)
+	} else {
+		fmt.Printf("  Directed graph: no Eulerian walk, out-in surplus %v\n", diagnosis.Surplus)
+	}
+	square := [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 2}}
+	walk, diagnosis := algorithms.EulerianPath(square, 4, false)
+	fmt.Printf("  Square with a diagonal: odd-degree nodes %v, Eulerian path %v\n", diagnosis.Odd, walk)
+	hamiltonPath, outOfBudget := algorithms.HamiltonianPath(g.GetUnweightedNeighbors(), len(g.Nodes), false, 5000)
+	fmt.Printf("  Hamiltonian path: %v (budget exhausted: %v)\n", hamiltonPath, outOfBudget)
+	hamiltonCycle, _ := algorithms.HamiltonianPath(unweightedNeighbors, len(g.Nodes), true, 5000)
+	fmt.Printf("  Undirected Hamiltonian cycle: %v\n", hamiltonCycle)
+
+	// Compare the tour heuristics with the optimal Held-Karp tour on straight-line distances
+	fmt.Println("\n23. Travelling Salesman:")
+	tspDistances := algorithms.TSPDistances(algorithms.TSPStraightLine, nil, g.GetPositions(), len(g.Nodes))
+	tours, lowerBound := algorithms.CompareTSP(tspDistances, 0)
+	for _, stats := range tours {
+		fmt.Printf("  %s: %v length %.1f\n", stats.Algorithm, stats.Tour, stats.Length)
+	}
+	fmt.Printf("  MST lower bound: %.1f\n", lowerBound)
+
+	// One-way distances: 2-opt must count the reversed stretch and still finish
+	oneWay := [][]float64{
+		{0, 1, 9, 9, 9},
+		{9, 0, 1, 9, 9},
+		{9, 9, 0, 1, 9},
+		{9, 9, 9, 0, 1},
+		{1, 9, 9, 9, 0},
+	}
+	backwards := []int{0, 4, 3, 2, 1}
+	twoOpt := algorithms.NewTwoOptStepper(oneWay, backwards)
+	for moves := 0; !twoOpt.Step(); moves++ {
+		if moves > 100 {
+			fmt.Println("  ✗ 2-opt did not finish on asymmetric distances")
+			break
+		}
+	}
+	inf := math.Inf(1)
+	apart := [][]float64{{0, 1, inf, inf}, {1, 0, inf, inf}, {inf, inf, 0, 1}, {inf, inf, 1, 0}}
+	apartTour, apartTree := algorithms.MSTTour(apart, 0)
+	fmt.Printf("  MST tour over two components: %v along %v\n", apartTour, apartTree)
+	oneWayTours, oneWayBound := algorithms.CompareTSP(oneWay, 0)
+	fmt.Printf("  Asymmetric 2-opt: %v -> %v length %.0f in %d moves; %d tours compared, MST bound %.1f\n",
+		backwards, twoOpt.Tour, twoOpt.Length, twoOpt.Improvements, len(oneWayTours), oneWayBound)
+
+	// Detect a cycle in both graphs, then list the elementary cycles of the directed one
+	fmt.Println("\n24. Cycles:")
+	fmt.Printf("  Undirected witness: %v\n", algorithms.FindUndirectedCycle(unweightedNeighbors, len(g.Nodes)))
+	if _, cycle := algorithms.TopologicalSort(g.GetUnweightedNeighbors(), len(g.Nodes)); cycle != nil {
+		fmt.Printf("  Directed witness: %v\n", cycle)
+	} else {
+		fmt.Println("  Directed graph: acyclic")
+	}
+	cycles, capped := algorithms.ElementaryCycles(g.GetUnweightedNeighbors(), len(g.Nodes), true, 10)
+	fmt.Printf("  Johnson: %d directed cycles (limit reached: %v)\n", len(cycles), capped)
+	for _, cycle := range cycles {
+		fmt.Printf("    %v\n", cycle)
+	}
+	undirectedCycles, _ := algorithms.ElementaryCycles(unweightedNeighbors, len(g.Nodes), false, 0)
+	fmt.Printf("  Undirected graph: %d elementary cycles\n", len(undirectedCycles))
+
+	// Summarize the directed graph and rank its nodes by every centrality
+	fmt.Println("\n25. Graph Metrics:")
+	stats := g.Stats()
+	fmt.Printf("  %d nodes, %d edges, density %.2f, degrees %v\n", stats.Nodes, stats.Edges, stats.Density, stats.DegreeCounts)
+	fmt.Printf("  Diameter %d, radius %d, girth %d, clustering %.2f, %d components\n",
+		stats.Diameter, stats.Radius, stats.Girth, stats.Clustering, stats.Components)
+	for _, kind := range algorithms.Centralities {
+		fmt.Printf("  %s: %.3f\n", kind, g.Centrality(kind))
+	}
+
+	// List the cheapest paths and every simple path from the first to the last node
+	fmt.Println("\n26. Paths:")
+	for i, p := range algorithms.YenKShortestPaths(g.GetWeightedNeighbors(), 0, last, len(g.Nodes), 3) {
+		fmt.Printf("  Yen #%d: %v cost %.1f\n", i+1, p.Nodes, p.Cost)
+	}
+	simplePaths, morePaths := algorithms.AllSimplePaths(g.GetWeightedNeighbors(), 0, last, len(g.Nodes), 10)
+	fmt.Printf("  %d simple paths (limit reached: %v)\n", len(simplePaths), morePaths)
+	for _, p := range simplePaths {
+		fmt.Printf("    %v cost %.1f\n", p.Nodes, p.Cost)
+	}
+
+	// Generate a small maze with each generator and race the four searches through it
+	fmt.Println("\n27. Maze:")
+	mazeRand := rand.New(rand.NewSource(1))
+	for _, kind := range algorithms.MazeKinds {
+		maze := graph.NewGridMap(11, 11, 20)
+		maze.Generate(kind, mazeRand)
+		mazeGraph := maze.Graph()
+		fmt.Printf("  %s:\n", kind)
+		for _, search := range []algorithms.TraversalMode{algorithms.ModeBFS, algorithms.ModeDFS, algorithms.ModeDijkstra, algorithms.ModeAStar} {
+			neighbors := mazeGraph.GetWeightedNeighbors()
+			positions := mazeGraph.GetPositions()
+			h := algorithms.NewHeuristic(algorithms.HeuristicManhattan, maze.Goal, algorithms.HeuristicOptions{
+				Positions: positions,
+				Scale:     algorithms.AdmissibleScale(neighbors, positions),
+			})
+			stepper := algorithms.NewFrontierStepper(search, neighbors, maze.Start, maze.Goal, h)
+			for !stepper.Step() {
+			}
+			path, cost := stepper.Path()
+			fmt.Printf("    %-8s %3d cells expanded, path of %d moves, cost %.0f\n", search, len(stepper.Order), len(path)-1, cost)
+		}
+	}
+
+	// Insert values that need each of the four rotation cases, then replay a deletion
+	fmt.Println("\n28. AVL Replay:")
+	avl := algorithms.NewAVLTree()
+	for _, value := range []int{30, 20, 10, 40, 50, 5, 7, 60, 55} {
+		avl.Insert(value)
+		rotations := []string{}
+		for _, event := range avl.Events {
+			if event.Kind == algorithms.AVLRotate {
+				rotations = append(rotations, event.String())
+			}
+		}
+		fmt.Printf("  Insert %d: %d steps, height %d %v\n", value, len(avl.Events), avl.Root.Height, rotations)
+	}
+	avl.Delete(30)
+	for _, event := range avl.Events {
+		fmt.Printf("    %s\n", event)
+	}
+
+	fmt.Println("\n29. Trees:")
+	ascending := []algorithms.TreeOp{}
+	for value := 1; value <= 31; value++ {
+		ascending = append(ascending, algorithms.TreeOp{Action: "insert", Key: value})
+	}
+	for _, result := range algorithms.CompareTrees(ascending, 4) {
+		fmt.Printf("  %s on 1..31: height %d, %d nodes\n", result.Kind, result.Height, result.Nodes)
+	}
+	btree := algorithms.NewSearchTree(algorithms.TreeBTree, 3)
+	for value := 1; value <= 10; value++ {
+		btree.Insert(value)
+	}
+	btree.Delete(4)
+	for _, step := range btree.Steps() {
+		fmt.Printf("    %s\n", step.Text)
+	}
+
+	fmt.Println("\n30. Tidy Tree Layout:")
+	complete := algorithms.NewAVLTree()
+	for value := 1; value <= 15; value++ {
+		complete.Insert(value)
+	}
+	complete.UpdatePositions(400, 50, 60)
+	for _, value := range []int{8, 4, 12, 2, 6, 1, 3} {
+		node := complete.Search(value)
+		fmt.Printf("  %d at (%d, %d)\n", value, node.Position.X, node.Position.Y)
+	}
+
 	fmt.Println("\nAll algorithms tested successfully!")
 }
+
+// mstWeight returns the total weight of a set of edges
+func mstWeight(edges []algorithms.Edge) float64 {
+	total := 0.0
+	for _, edge := range edges {
+		total += edge.Weight
+	}
+	return total
+}
@@ -47,6 +47,9 @@ func main() {
 	// Reset and test Kruskal
 	sim.Reset()
 	sim.StartKruskal()
+	for !sim.Done {
+		sim.Update() // Kruskal considers one edge per step
+	}
 	if sim.Mode == algorithms.ModeKruskal && sim.Done {
 		fmt.Println("✓ StartKruskal working")
 	} else {
@@ -56,6 +59,9 @@ func main() {
 	// Reset and test Prim
 	sim.Reset()
 	sim.StartPrim()
+	for !sim.Done {
+		sim.Update() // Prim adds one node per step
+	}
 	if sim.Mode == algorithms.ModePrim && sim.Done {
 		fmt.Println("✓ StartPrim working")
 	} else {
@@ -65,6 +71,9 @@ func main() {
 	// Reset and test Tarjan
 	sim.Reset()
 	sim.StartTarjan()
+	for !sim.Done {
+		sim.Update() // Tarjan visits a node, examines an edge or returns per step
+	}
 	if sim.Mode == algorithms.ModeTarjan && sim.Done {
 		fmt.Println("✓ StartTarjan working")
 	} else {
@@ -111,6 +120,9 @@ func main() {
 
 	// Test SCC getter (after running Tarjan)
 	sim.StartTarjan()
+	for !sim.Done {
+		sim.Update()
+	}
 	sccs := sim.GetSCCs()
 	if sccs != nil {
 		fmt.Println("✓ GetSCCs working")
@@ -119,9 +131,10 @@ func main() {
 	}
 
 	// Test topological order getter
+	// A graph with a cycle has no order, only a witness cycle
 	sim.StartTopological()
 	topOrder := sim.GetTopologicalOrder()
-	if topOrder != nil {
+	if topOrder != nil || sim.GetCycle() != nil {
 		fmt.Println("✓ GetTopologicalOrder working")
 	} else {
 		log.Fatal("✗ GetTopologicalOrder failed")